@@ -0,0 +1,345 @@
+/*
+Package callback encode structured action into callback data of inline keyboard button and decode it back from callback query.
+
+Callback data is signed with HMAC so forged payloads are rejected, payloads not fitting the 64 bytes limit of telegram are
+saved in Store and replaced with a short key.
+
+Signature alone doesn't bind callback data to a chat, a user who has seen a button can send its data from any chat.
+Encode data with a scope such as the chat id using EncodeScoped and set the same scope of update with SetScope,
+so data is only accepted in the chat it was sent to.
+
+	codec := callback.New([]byte(<secret>)).SetStore(callback.NewMemoryStore(24 * time.Hour))
+	button, err := codec.Button("Delete", "delete", "42")
+
+	dispatcher.New().Match(codec.Action("delete"), telegraph.HandlerFunc(func(ctx context.Context, update *telegraph.Update) {
+		payload, _ := callback.PayloadFromContext(ctx)
+		id, err := payload.Int64(0)
+	}))
+*/
+package callback
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"telegraph"
+	"telegraph/dispatcher"
+	"time"
+)
+
+const (
+	// MaxData maximum size of callback data in bytes
+	MaxData = 64
+	// DefaultTagSize default length of signature in callback data
+	DefaultTagSize = 8
+
+	separator    = ":"
+	tagSeparator = "~"
+	storedPrefix = "#"
+	keySize      = 9
+)
+
+var (
+	// ErrInvalidData returned when callback data isn't encoded by the codec
+	ErrInvalidData = errors.New("callback: invalid data")
+	// ErrInvalidSignature returned when signature of callback data doesn't match, the data is forged or signed with other secret
+	ErrInvalidSignature = errors.New("callback: invalid signature")
+	// ErrDataTooLong returned when encoded payload is longer than 64 bytes and the codec has no store
+	ErrDataTooLong = errors.New("callback: data too long")
+	// ErrPayloadNotFound returned by Store when payload of the key doesn't exist or has expired
+	ErrPayloadNotFound = errors.New("callback: payload not found")
+
+	escaper   = strings.NewReplacer("%", "%25", separator, "%3A", tagSeparator, "%7E", storedPrefix, "%23")
+	unescaper = strings.NewReplacer("%25", "%", "%3A", separator, "%7E", tagSeparator, "%23", storedPrefix)
+)
+
+type (
+	// Payload action and its arguments carried by callback data
+	Payload struct {
+		Action string
+		Args   []string
+	}
+
+	// Store save payload too long for callback data under a short key
+	Store interface {
+		Save(key, payload string) error
+		// Load return ErrPayloadNotFound when key doesn't exist
+		Load(key string) (string, error)
+	}
+
+	// Codec encode and decode signed callback data
+	Codec struct {
+		secret  []byte
+		tagSize int
+		store   Store
+		scope   func(update *telegraph.Update) string
+	}
+
+	// MemoryStore Store keeping payload in memory, payloads are lost when the process exit
+	MemoryStore struct {
+		ttl      time.Duration
+		mutex    sync.Mutex
+		payloads map[string]storedPayload
+		expiring []expiringKey
+	}
+
+	// expiringKey key in order of expiry, all payloads have the same ttl so it's the order they are saved
+	expiringKey struct {
+		key    string
+		expire time.Time
+	}
+
+	storedPayload struct {
+		payload string
+		expire  time.Time
+	}
+
+	contextKey int
+)
+
+const payloadKey contextKey = iota
+
+// New create codec signing callback data with secret, the secret must be kept private and stable across restart
+func New(secret []byte) *Codec {
+	return &Codec{
+		secret:  secret,
+		tagSize: DefaultTagSize,
+	}
+}
+
+// SetTagSize length of signature in characters, each character carry 6 bits, longer tag is harder to forge but leave less room for payload
+func (codec *Codec) SetTagSize(size int) *Codec {
+	codec.tagSize = size
+	return codec
+}
+
+// SetStore save payload too long for callback data in store, without store Encode return ErrDataTooLong
+func (codec *Codec) SetStore(store Store) *Codec {
+	codec.store = store
+	return codec
+}
+
+// SetScope scope of callback query used by Action to verify data encoded with EncodeScoped, e.g. ChatScope
+func (codec *Codec) SetScope(scope func(update *telegraph.Update) string) *Codec {
+	codec.scope = scope
+	return codec
+}
+
+// Encode action and args into signed callback data, the data is valid in any chat, see EncodeScoped
+func (codec *Codec) Encode(action string, args ...string) (string, error) {
+	return codec.EncodeScoped("", action, args...)
+}
+
+// EncodeScoped action and args into callback data signed for scope, e.g. chat id, the data is only decoded with the same scope
+func (codec *Codec) EncodeScoped(scope, action string, args ...string) (string, error) {
+	if action == "" {
+		return "", fmt.Errorf("%w: action is required", ErrInvalidData)
+	}
+
+	fields := make([]string, 0, len(args)+1)
+	for _, field := range append([]string{action}, args...) {
+		fields = append(fields, escaper.Replace(field))
+	}
+	body := strings.Join(fields, separator)
+
+	if data := codec.sign(scope, body); len(data) <= MaxData {
+		return data, nil
+	}
+	if codec.store == nil {
+		return "", fmt.Errorf("%w: action %q is %d bytes", ErrDataTooLong, action, len(codec.sign(scope, body)))
+	}
+
+	key := make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	id := base64.RawURLEncoding.EncodeToString(key)
+	if err := codec.store.Save(id, body); err != nil {
+		return "", err
+	}
+
+	return codec.sign(scope, storedPrefix+id), nil
+}
+
+// Decode verify signature of callback data encoded without scope and return its payload
+func (codec *Codec) Decode(data string) (Payload, error) {
+	return codec.DecodeScoped("", data)
+}
+
+// DecodeScoped verify signature of callback data encoded for scope and return its payload
+func (codec *Codec) DecodeScoped(scope, data string) (Payload, error) {
+	index := strings.LastIndex(data, tagSeparator)
+	if index < 0 {
+		return Payload{}, ErrInvalidData
+	}
+	body := data[:index]
+	if !hmac.Equal([]byte(codec.sign(scope, body)), []byte(data)) {
+		return Payload{}, ErrInvalidSignature
+	}
+
+	if strings.HasPrefix(body, storedPrefix) {
+		if codec.store == nil {
+			return Payload{}, ErrPayloadNotFound
+		}
+		stored, err := codec.store.Load(strings.TrimPrefix(body, storedPrefix))
+		if err != nil {
+			return Payload{}, err
+		}
+		body = stored
+	}
+
+	fields := strings.Split(body, separator)
+	for i, field := range fields {
+		fields[i] = unescaper.Replace(field)
+	}
+
+	return Payload{Action: fields[0], Args: fields[1:]}, nil
+}
+
+// Button inline keyboard button with callback data encoding action and args
+func (codec *Codec) Button(text, action string, args ...string) (telegraph.InlineKeyboardButton, error) {
+	return codec.ButtonScoped("", text, action, args...)
+}
+
+// ButtonScoped inline keyboard button with callback data encoding action and args for scope, see EncodeScoped
+func (codec *Codec) ButtonScoped(scope, text, action string, args ...string) (telegraph.InlineKeyboardButton, error) {
+	data, err := codec.EncodeScoped(scope, action, args...)
+	if err != nil {
+		return telegraph.InlineKeyboardButton{}, err
+	}
+
+	return telegraph.InlineKeyboardButton{Text: text, CallbackData: data}, nil
+}
+
+// Action match callback query whose data is signed by the codec and carry action, the payload is available with PayloadFromContext.
+// Callback data with invalid signature, or signed for other scope when scope is set, never match
+func (codec *Codec) Action(action string) dispatcher.Matcher {
+	return func(ctx context.Context, update *telegraph.Update) (context.Context, bool) {
+		if update.CallbackQuery == nil {
+			return ctx, false
+		}
+		scope := ""
+		if codec.scope != nil {
+			scope = codec.scope(update)
+		}
+		payload, err := codec.DecodeScoped(scope, update.CallbackQuery.Data)
+		if err != nil || payload.Action != action {
+			return ctx, false
+		}
+
+		return context.WithValue(ctx, payloadKey, payload), true
+	}
+}
+
+// sign append signature tag of scope and body to body
+func (codec *Codec) sign(scope, body string) string {
+	mac := hmac.New(sha256.New, codec.secret)
+	if scope != "" {
+		mac.Write([]byte(scope))
+		mac.Write([]byte{0})
+	}
+	mac.Write([]byte(body))
+	tag := base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+	if codec.tagSize > 0 && codec.tagSize < len(tag) {
+		tag = tag[:codec.tagSize]
+	}
+
+	return body + tagSeparator + tag
+}
+
+// ChatScope scope of callback query by chat id of the message with the button, or inline message id for inline message
+func ChatScope(update *telegraph.Update) string {
+	if query := update.CallbackQuery; query != nil {
+		if query.Message != nil {
+			return strconv.FormatInt(query.Message.Chat.ID, 10)
+		}
+		return query.InlineMessageID
+	}
+	if chat := update.Chat(); chat != nil {
+		return strconv.FormatInt(chat.ID, 10)
+	}
+	return ""
+}
+
+// PayloadFromContext payload decoded by Codec.Action
+func PayloadFromContext(ctx context.Context) (Payload, bool) {
+	payload, ok := ctx.Value(payloadKey).(Payload)
+	return payload, ok
+}
+
+// Arg argument at index, empty when payload has fewer arguments
+func (payload Payload) Arg(index int) string {
+	if index < 0 || index >= len(payload.Args) {
+		return ""
+	}
+	return payload.Args[index]
+}
+
+// Int64 parse argument at index as int64, such as chat or message identifier
+func (payload Payload) Int64(index int) (int64, error) {
+	return strconv.ParseInt(payload.Arg(index), 10, 64)
+}
+
+// NewMemoryStore create store keeping payload for ttl, zero ttl keep payload forever
+func NewMemoryStore(ttl time.Duration) *MemoryStore {
+	return &MemoryStore{
+		ttl:      ttl,
+		payloads: map[string]storedPayload{},
+	}
+}
+
+// Save payload under key and remove payloads expired so far, oldest first
+func (store *MemoryStore) Save(key, payload string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	now := time.Now()
+	for len(store.expiring) > 0 && now.After(store.expiring[0].expire) {
+		oldest := store.expiring[0]
+		if stored, ok := store.payloads[oldest.key]; ok && stored.expire.Equal(oldest.expire) {
+			delete(store.payloads, oldest.key)
+		}
+		store.expiring = store.expiring[1:]
+	}
+
+	stored := storedPayload{payload: payload}
+	if store.ttl > 0 {
+		stored.expire = now.Add(store.ttl)
+		store.expiring = append(store.expiring, expiringKey{key: key, expire: stored.expire})
+	}
+	store.payloads[key] = stored
+
+	return nil
+}
+
+// Len number of payloads kept in store, expired payloads included until they are removed
+func (store *MemoryStore) Len() int {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	return len(store.payloads)
+}
+
+// Load payload saved under key
+func (store *MemoryStore) Load(key string) (string, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	stored, ok := store.payloads[key]
+	if !ok || stored.expired(time.Now()) {
+		return "", ErrPayloadNotFound
+	}
+
+	return stored.payload, nil
+}
+
+func (stored storedPayload) expired(now time.Time) bool {
+	return !stored.expire.IsZero() && now.After(stored.expire)
+}
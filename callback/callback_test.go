@@ -0,0 +1,149 @@
+package callback_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"telegraph"
+	"telegraph/callback"
+	"telegraph/dispatcher"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	codec := callback.New([]byte("secret"))
+
+	data, err := codec.Encode("delete", "42", "a:b~c#d%e")
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, len(data), callback.MaxData)
+
+	payload, err := codec.Decode(data)
+	assert.NoError(t, err)
+	assert.Equal(t, callback.Payload{Action: "delete", Args: []string{"42", "a:b~c#d%e"}}, payload)
+
+	id, err := payload.Int64(0)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), id)
+	assert.Equal(t, "", payload.Arg(5))
+
+	_, err = codec.Encode("")
+	assert.True(t, errors.Is(err, callback.ErrInvalidData))
+}
+
+func TestDecodeForged(t *testing.T) {
+	codec := callback.New([]byte("secret"))
+	data, _ := codec.Encode("delete", "42")
+
+	_, err := codec.Decode(strings.Replace(data, "42", "43", 1))
+	assert.True(t, errors.Is(err, callback.ErrInvalidSignature))
+
+	_, err = callback.New([]byte("other")).Decode(data)
+	assert.True(t, errors.Is(err, callback.ErrInvalidSignature))
+
+	_, err = codec.Decode("delete:42")
+	assert.True(t, errors.Is(err, callback.ErrInvalidData))
+}
+
+func TestStore(t *testing.T) {
+	long := strings.Repeat("x", 100)
+
+	_, err := callback.New([]byte("secret")).Encode("long", long)
+	assert.True(t, errors.Is(err, callback.ErrDataTooLong))
+
+	store := callback.NewMemoryStore(time.Hour)
+	codec := callback.New([]byte("secret")).SetStore(store)
+	data, err := codec.Encode("long", long)
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, len(data), callback.MaxData)
+	assert.True(t, strings.HasPrefix(data, "#"))
+
+	payload, err := codec.Decode(data)
+	assert.NoError(t, err)
+	assert.Equal(t, callback.Payload{Action: "long", Args: []string{long}}, payload)
+
+	_, err = callback.New([]byte("secret")).SetStore(callback.NewMemoryStore(0)).Decode(data)
+	assert.True(t, errors.Is(err, callback.ErrPayloadNotFound))
+}
+
+func TestMemoryStoreExpire(t *testing.T) {
+	store := callback.NewMemoryStore(time.Millisecond)
+	assert.NoError(t, store.Save("key", "payload"))
+
+	payload, err := store.Load("key")
+	assert.NoError(t, err)
+	assert.Equal(t, "payload", payload)
+
+	time.Sleep(5 * time.Millisecond)
+	_, err = store.Load("key")
+	assert.True(t, errors.Is(err, callback.ErrPayloadNotFound))
+}
+
+func TestMemoryStoreRemoveExpired(t *testing.T) {
+	store := callback.NewMemoryStore(time.Millisecond)
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, store.Save(fmt.Sprint(i), "payload"))
+	}
+	assert.Equal(t, 10, store.Len())
+
+	time.Sleep(5 * time.Millisecond)
+	assert.NoError(t, store.Save("new", "payload"))
+	assert.Equal(t, 1, store.Len())
+
+	payload, err := store.Load("new")
+	assert.NoError(t, err)
+	assert.Equal(t, "payload", payload)
+}
+
+func TestScope(t *testing.T) {
+	codec := callback.New([]byte("secret")).SetScope(callback.ChatScope)
+	button, err := codec.ButtonScoped("100", "Delete", "delete", "42")
+	assert.NoError(t, err)
+
+	_, err = codec.Decode(button.CallbackData)
+	assert.True(t, errors.Is(err, callback.ErrInvalidSignature))
+	_, err = codec.DecodeScoped("200", button.CallbackData)
+	assert.True(t, errors.Is(err, callback.ErrInvalidSignature))
+
+	query := func(chatID int64) *telegraph.Update {
+		return &telegraph.Update{CallbackQuery: &telegraph.CallbackQuery{
+			Message: &telegraph.Message{Chat: telegraph.Chat{ID: chatID}},
+			Data:    button.CallbackData,
+		}}
+	}
+	_, ok := codec.Action("delete")(context.Background(), query(100))
+	assert.True(t, ok)
+	_, ok = codec.Action("delete")(context.Background(), query(200))
+	assert.False(t, ok)
+}
+
+func TestAction(t *testing.T) {
+	codec := callback.New([]byte("secret"))
+	button, err := codec.Button("Delete", "delete", "42")
+	assert.NoError(t, err)
+	assert.Equal(t, "Delete", button.Text)
+
+	var handled []callback.Payload
+	fallback := 0
+	router := dispatcher.New().
+		Match(codec.Action("delete"), telegraph.HandlerFunc(func(ctx context.Context, update *telegraph.Update) {
+			payload, ok := callback.PayloadFromContext(ctx)
+			assert.True(t, ok)
+			handled = append(handled, payload)
+		})).
+		Fallback(telegraph.HandlerFunc(func(ctx context.Context, update *telegraph.Update) {
+			fallback++
+		}))
+
+	edit, _ := codec.Encode("edit", "42")
+	for _, data := range []string{button.CallbackData, edit, "delete:42~forged00"} {
+		router.HandleUpdate(context.Background(), &telegraph.Update{CallbackQuery: &telegraph.CallbackQuery{Data: data}})
+	}
+	router.HandleUpdate(context.Background(), &telegraph.Update{Message: &telegraph.Message{Text: "delete"}})
+
+	assert.Equal(t, []callback.Payload{{Action: "delete", Args: []string{"42"}}}, handled)
+	assert.Equal(t, 3, fallback)
+}
@@ -1,31 +1,34 @@
 package telegraph
 
 import (
+	"context"
 	"fmt"
 
 	"net/http"
 
-	"github.com/cenkalti/backoff"
 	"github.com/parnurzeal/gorequest"
 )
 
 type (
 	// ChatResponse struct to handle request and response telegram api
 	ChatResponse struct {
-		Client  *Client
-		Request *gorequest.SuperAgent
+		Client      *Client
+		Request     *gorequest.SuperAgent
+		retryPolicy RetryPolicy
 	}
 
 	// ChatMemberResponse struct to handle request and response telegram api
 	ChatMemberResponse struct {
-		Client  *Client
-		Request *gorequest.SuperAgent
+		Client      *Client
+		Request     *gorequest.SuperAgent
+		retryPolicy RetryPolicy
 	}
 
 	// ArrayChatMemberResponse struct to handle request and response telegram api
 	ArrayChatMemberResponse struct {
-		Client  *Client
-		Request *gorequest.SuperAgent
+		Client      *Client
+		Request     *gorequest.SuperAgent
+		retryPolicy RetryPolicy
 	}
 )
 
@@ -36,7 +39,7 @@ current username of a user, group or channel, etc.). Returns a Chat object on su
 */
 func (client *Client) GetChat(chatId interface{}) *ChatResponse {
 	url := client.baseURL + fmt.Sprintf(EndpointGetChat, client.accessToken)
-	request := gorequest.New().Type(gorequest.TypeJSON).Get(url).Set(UserAgentHeader, UserAgent+"/"+Version).
+	request := gorequest.New().Type(gorequest.TypeJSON).Get(url).Set(UserAgentHeader, client.userAgent).
 		Query(fmt.Sprintf("chat_id=%v", chatId))
 
 	return &ChatResponse{
@@ -45,29 +48,27 @@ func (client *Client) GetChat(chatId interface{}) *ChatResponse {
 	}
 }
 
+// SetRetryPolicy decide how this request is retried when it fails, override retry policy of the client
+func (void *ChatResponse) SetRetryPolicy(policy RetryPolicy) *ChatResponse {
+	void.retryPolicy = policy
+
+	return void
+}
+
 // Commit execute request to telegram
 func (void *ChatResponse) Commit() (*Chat, *http.Response, error) {
-	var errs []error
+	return void.CommitContext(context.Background())
+}
 
-	res := &http.Response{}
+// CommitContext execute request to telegram, the request and its back off retry are aborted when ctx is done
+func (void *ChatResponse) CommitContext(ctx context.Context) (*Chat, *http.Response, error) {
 	model := struct {
-		ErrorResponse
 		Result *Chat `json:"result,omitempty"`
 	}{}
 
-	operation := func() error {
-		res, _, errs = void.Request.EndStruct(&model)
-		if len(errs) > 0 {
-			return errs[0]
-		}
-		return nil
-	}
-
-	if err := backoff.Retry(operation, void.Client.expBackOff); err != nil {
-		return nil, MakeHTTPResponse(void.Request), err
-	}
-	if res.StatusCode != http.StatusOK {
-		return nil, res, fmt.Errorf("%v %v", model.ErrorCode, model.Description)
+	_, res, err := void.Client.commit(ctx, void.Request, void.retryPolicy, &model)
+	if err != nil {
+		return nil, res, err
 	}
 
 	return model.Result, res, nil
@@ -81,7 +82,7 @@ If the chat is a group or a supergroup and no administrators were appointed, onl
 */
 func (client *Client) GetChatAdministrator(chatId interface{}) *ArrayChatMemberResponse {
 	url := client.baseURL + fmt.Sprintf(EndpointGetChatAdministrators, client.accessToken)
-	request := gorequest.New().Type(gorequest.TypeJSON).Get(url).Set(UserAgentHeader, UserAgent+"/"+Version).
+	request := gorequest.New().Type(gorequest.TypeJSON).Get(url).Set(UserAgentHeader, client.userAgent).
 		Query(fmt.Sprintf("chat_id=%v", chatId))
 
 	return &ArrayChatMemberResponse{
@@ -90,29 +91,27 @@ func (client *Client) GetChatAdministrator(chatId interface{}) *ArrayChatMemberR
 	}
 }
 
+// SetRetryPolicy decide how this request is retried when it fails, override retry policy of the client
+func (void *ArrayChatMemberResponse) SetRetryPolicy(policy RetryPolicy) *ArrayChatMemberResponse {
+	void.retryPolicy = policy
+
+	return void
+}
+
 // Commit execute request to telegram
 func (void *ArrayChatMemberResponse) Commit() ([]ChatMember, *http.Response, error) {
-	var errs []error
+	return void.CommitContext(context.Background())
+}
 
-	res := &http.Response{}
+// CommitContext execute request to telegram, the request and its back off retry are aborted when ctx is done
+func (void *ArrayChatMemberResponse) CommitContext(ctx context.Context) ([]ChatMember, *http.Response, error) {
 	model := struct {
-		ErrorResponse
 		Result []ChatMember `json:"result,omitempty"`
 	}{}
 
-	operation := func() error {
-		res, _, errs = void.Request.EndStruct(&model)
-		if len(errs) > 0 {
-			return errs[0]
-		}
-		return nil
-	}
-
-	if err := backoff.Retry(operation, void.Client.expBackOff); err != nil {
-		return nil, MakeHTTPResponse(void.Request), err
-	}
-	if res.StatusCode != http.StatusOK {
-		return nil, res, fmt.Errorf("%v %v", model.ErrorCode, model.Description)
+	_, res, err := void.Client.commit(ctx, void.Request, void.retryPolicy, &model)
+	if err != nil {
+		return nil, res, err
 	}
 
 	return model.Result, res, nil
@@ -125,7 +124,7 @@ GetChatMember Use this method to get information about a member of a chat. Retur
 */
 func (client *Client) GetChatMember(chatId interface{}, userId int64) *ChatMemberResponse {
 	url := client.baseURL + fmt.Sprintf(EndpointGetChatMember, client.accessToken)
-	request := gorequest.New().Type(gorequest.TypeJSON).Get(url).Set(UserAgentHeader, UserAgent+"/"+Version).
+	request := gorequest.New().Type(gorequest.TypeJSON).Get(url).Set(UserAgentHeader, client.userAgent).
 		Query(fmt.Sprintf("chat_id=%v&user_id=%v", chatId, userId))
 
 	return &ChatMemberResponse{
@@ -134,29 +133,27 @@ func (client *Client) GetChatMember(chatId interface{}, userId int64) *ChatMembe
 	}
 }
 
+// SetRetryPolicy decide how this request is retried when it fails, override retry policy of the client
+func (void *ChatMemberResponse) SetRetryPolicy(policy RetryPolicy) *ChatMemberResponse {
+	void.retryPolicy = policy
+
+	return void
+}
+
 // Commit execute request to telegram
 func (void *ChatMemberResponse) Commit() (*ChatMember, *http.Response, error) {
-	var errs []error
+	return void.CommitContext(context.Background())
+}
 
-	res := &http.Response{}
+// CommitContext execute request to telegram, the request and its back off retry are aborted when ctx is done
+func (void *ChatMemberResponse) CommitContext(ctx context.Context) (*ChatMember, *http.Response, error) {
 	model := struct {
-		ErrorResponse
 		Result *ChatMember `json:"result,omitempty"`
 	}{}
 
-	operation := func() error {
-		res, _, errs = void.Request.EndStruct(&model)
-		if len(errs) > 0 {
-			return errs[0]
-		}
-		return nil
-	}
-
-	if err := backoff.Retry(operation, void.Client.expBackOff); err != nil {
-		return nil, MakeHTTPResponse(void.Request), err
-	}
-	if res.StatusCode != http.StatusOK {
-		return nil, res, fmt.Errorf("%v %v", model.ErrorCode, model.Description)
+	_, res, err := void.Client.commit(ctx, void.Request, void.retryPolicy, &model)
+	if err != nil {
+		return nil, res, err
 	}
 
 	return model.Result, res, nil
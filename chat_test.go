@@ -41,7 +41,7 @@ func TestGetChat_Error(t *testing.T) {
 		Reply(http.StatusOK).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.GetChat(32423423).Commit()
 
 	assert.Nil(t, body)
@@ -58,7 +58,7 @@ func TestGetChat_Failed(t *testing.T) {
 		}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.GetChat(32423423).Commit()
 
 	assert.Nil(t, body)
@@ -95,7 +95,7 @@ func TestGetChatAdministrator_Error(t *testing.T) {
 		Reply(http.StatusOK).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.GetChatAdministrator(32423423).Commit()
 
 	assert.Nil(t, body)
@@ -112,7 +112,7 @@ func TestGetChatAdministrator_Failed(t *testing.T) {
 		}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.GetChatAdministrator(32423423).Commit()
 
 	assert.Nil(t, body)
@@ -151,7 +151,7 @@ func TestGetChatMember_Error(t *testing.T) {
 		ParamPresent("user_id").Reply(http.StatusOK).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.GetChatMember(32423423, 23423423).Commit()
 
 	assert.Nil(t, body)
@@ -168,7 +168,7 @@ func TestGetChatMember_Failed(t *testing.T) {
 		}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.GetChatMember(32423423, 23423423).Commit()
 
 	assert.Nil(t, body)
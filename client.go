@@ -1,40 +1,260 @@
 package telegraph
 
 import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/cenkalti/backoff"
+	"github.com/parnurzeal/gorequest"
 )
 
-// Client struct configuration telegram
-type Client struct {
-	accessToken string
-	baseURL     string
-	expBackOff  *backoff.ExponentialBackOff
+type (
+	// Client struct configuration telegram
+	Client struct {
+		accessToken      string
+		baseURL          string
+		userAgent        string
+		retryPolicy      RetryPolicy
+		rateLimiter      *RateLimiter
+		uploadLimit      int64
+		photoUploadLimit int64
+		httpClient       *http.Client
+	}
+
+	// ClientOption optional configuration applied when client is created
+	ClientOption func(*Client)
+)
+
+// NewClient create new telegram configuration with access token, failed request is retried up to 5 minutes
+func NewClient(accessToken string, options ...ClientOption) *Client {
+	return NewClientWithBackOff(accessToken, NewBackOff(60, 300), options...)
 }
 
-// NewClient create new telegram configuration with access token
-func NewClient(accessToken string) *Client {
-	return &Client{
-		accessToken: accessToken,
-		baseURL:     BaseURL,
-		expBackOff:  NewBackOff(60, -1),
+// NewClientWithBackOff constructor for client with retry back off
+func NewClientWithBackOff(accessToken string, expBackOff *backoff.ExponentialBackOff, options ...ClientOption) *Client {
+	client := &Client{
+		accessToken:      accessToken,
+		baseURL:          BaseURL,
+		userAgent:        UserAgent + "/" + Version,
+		retryPolicy:      &BackOffRetryPolicy{BackOff: expBackOff},
+		uploadLimit:      MaxUploadSize,
+		photoUploadLimit: MaxPhotoUploadSize,
+		httpClient:       &http.Client{},
 	}
+
+	for _, option := range options {
+		option(client)
+	}
+
+	return client
 }
 
-// NewClientWithBackOff constructor for client with retry back off
-func NewClientWithBackOff(accessToken string, expBackOff *backoff.ExponentialBackOff) *Client {
-	return &Client{
-		accessToken: accessToken,
-		baseURL:     BaseURL,
-		expBackOff:  expBackOff,
+// WithBaseURL send all request, file download included, to baseURL instead of telegram api, e.g. self-hosted bot api server
+func WithBaseURL(baseURL string) ClientOption {
+	return func(client *Client) {
+		client.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithHTTPClient use a copy of httpClient to send request to telegram
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(client *Client) {
+		clone := *httpClient
+		client.httpClient = &clone
+	}
+}
+
+// WithTransport use transport to send request to telegram
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(client *Client) {
+		client.httpClient.Transport = transport
+	}
+}
+
+// WithProxy send all request to telegram through proxy.
+// The transport is replaced with a clone of http.DefaultTransport if it is not an *http.Transport
+func WithProxy(proxy *url.URL) ClientOption {
+	return func(client *Client) {
+		transport := client.cloneTransport()
+		transport.Proxy = http.ProxyURL(proxy)
+		client.httpClient.Transport = transport
 	}
 }
 
-// NewBackOff declare retry exponential back off with max interval time and max elapsed time in second
+// WithTLSConfig use config for TLS connection to telegram.
+// The transport is replaced with a clone of http.DefaultTransport if it is not an *http.Transport
+func WithTLSConfig(config *tls.Config) ClientOption {
+	return func(client *Client) {
+		transport := client.cloneTransport()
+		transport.TLSClientConfig = config
+		client.httpClient.Transport = transport
+	}
+}
+
+// WithTimeout time limit for each request to telegram, back off retry is not counted. Zero means no timeout
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(client *Client) {
+		client.httpClient.Timeout = timeout
+	}
+}
+
+// WithRetryPolicy decide how failed request is retried with policy instead of the client back off,
+// can be overridden per request with SetRetryPolicy
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(client *Client) {
+		client.retryPolicy = policy
+	}
+}
+
+// WithUserAgent send userAgent as User-Agent header instead of the default one
+func WithUserAgent(userAgent string) ClientOption {
+	return func(client *Client) {
+		client.userAgent = userAgent
+	}
+}
+
+// WithUploadLimit maximum size in bytes of uploaded file and uploaded photo, checked before the upload start.
+// Zero means unlimited, e.g. for self-hosted bot api server which accept larger file
+func WithUploadLimit(limit, photoLimit int64) ClientOption {
+	return func(client *Client) {
+		client.uploadLimit = limit
+		client.photoUploadLimit = photoLimit
+	}
+}
+
+// WithTestEnvironment send all request to telegram test environment instead of production,
+// where test payment providers can be used to pay invoices without real money.
+// The bot token must be created with BotFather of the test environment
+func WithTestEnvironment() ClientOption {
+	return func(client *Client) {
+		if !strings.HasSuffix(client.accessToken, "/test") {
+			client.accessToken += "/test"
+		}
+	}
+}
+
+// NewBackOff declare retry exponential back off with max interval time and max elapsed time in second,
+// zero or negative max elapsed time retry until the request context is done
 func NewBackOff(maxInterval, maxElapsedTime int) *backoff.ExponentialBackOff {
 	expBackOff := backoff.NewExponentialBackOff()
 	expBackOff.MaxElapsedTime = time.Duration(maxElapsedTime) * time.Second
 	expBackOff.MaxInterval = time.Duration(maxInterval) * time.Second
 	return expBackOff
 }
+
+// commit send request to telegram and retry it according to policy, client retry policy is used when policy is nil.
+// When model is not nil the success response is decoded into it.
+// The in-flight request and the retry are aborted when ctx is done, in that case ctx.Err() is returned
+func (client *Client) commit(ctx context.Context, agent *gorequest.SuperAgent, policy RetryPolicy, model interface{}) ([]byte, *http.Response, error) {
+	if len(agent.Errors) > 0 {
+		return nil, MakeHTTPResponse(agent), agent.Errors[0]
+	}
+	if err := client.checkUploads(agent); err != nil {
+		return nil, MakeHTTPResponse(agent), err
+	}
+	if policy == nil {
+		policy = client.retryPolicy
+	}
+
+	start := time.Now()
+	for attempt := 1; ; attempt++ {
+		if err := client.waitRateLimit(ctx, agent); err != nil {
+			return nil, MakeHTTPResponse(agent), err
+		}
+
+		body, res, err := client.send(ctx, agent, model)
+		if err == nil {
+			return body, res, nil
+		}
+		if ctx.Err() != nil {
+			return nil, MakeHTTPResponse(agent), ctx.Err()
+		}
+
+		wait, retry := policy.Retry(attempt, time.Since(start), err)
+		if !retry || !repeatableUploads(agent) {
+			if _, ok := err.(*APIError); ok {
+				return nil, res, err
+			}
+			return nil, MakeHTTPResponse(agent), err
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, MakeHTTPResponse(agent), ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// send execute one attempt of request to telegram, unsuccessful response is returned as *APIError along with the response
+func (client *Client) send(ctx context.Context, agent *gorequest.SuperAgent, model interface{}) ([]byte, *http.Response, error) {
+	req, err := client.makeRequest(agent)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res, err := client.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		if body, ok := req.Body.(*multipartBody); ok && errors.Is(body.err, ErrFileTooLarge) {
+			return nil, nil, body.err
+		}
+		return nil, nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	res.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	if res.StatusCode != http.StatusOK {
+		return nil, res, newAPIError(res, body)
+	}
+	if model != nil {
+		if err := json.Unmarshal(body, model); err != nil {
+			return nil, nil, &DecodeError{Body: body, Err: err}
+		}
+	}
+
+	return body, res, nil
+}
+
+// cloneTransport return copy of client transport which can be modified safely
+func (client *Client) cloneTransport() *http.Transport {
+	transport := client.httpClient.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	if transport, ok := transport.(*http.Transport); ok {
+		return transport.Clone()
+	}
+
+	return &http.Transport{Proxy: http.ProxyFromEnvironment}
+}
+
+// makeRequest build http request from gorequest agent, forced type is resolved the same way gorequest does.
+// Request with uploaded file is streamed as multipart form limited by client upload limit
+func (client *Client) makeRequest(agent *gorequest.SuperAgent) (*http.Request, error) {
+	if files := uploadFiles(agent); len(files) > 0 {
+		return makeMultipartRequest(agent, files, client.uploadLimitOf)
+	}
+	if agent.ForceType != "" {
+		agent.TargetType = agent.ForceType
+	}
+
+	return agent.MakeRequest()
+}
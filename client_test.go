@@ -1,10 +1,20 @@
 package telegraph_test
 
 import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
 	"telegraph"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
 )
 
 func TestNewClient(t *testing.T) {
@@ -18,3 +28,128 @@ func TestNewClientWithBackOff(t *testing.T) {
 
 	assert.NotNil(t, client)
 }
+
+func TestCommitContext_Canceled(t *testing.T) {
+	gock.New(telegraph.BaseURL).Post(fmt.Sprintf(telegraph.EndpointSendMessage, "token")).Reply(http.StatusOK).JSON(`{
+		"ok": true,
+		"result": {
+			"message_id": 100,
+			"date": 1510125931,
+			"chat": {
+				"id": 1234567890,
+				"type": "private"
+			},
+			"text": "test via server"
+		}
+	}`)
+	defer gock.Off()
+
+	client := telegraph.NewClient("token")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	message, res, err := client.SendMessage(2434234, "test").CommitContext(ctx)
+
+	assert.Nil(t, message)
+	assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestCommitContext_DeadlineStopRetry(t *testing.T) {
+	gock.New(telegraph.BaseURL).Head(fmt.Sprintf(telegraph.EndpointDeleteWebHook, "token")).Reply(http.StatusInternalServerError).JSON("")
+	defer gock.Off()
+
+	client := telegraph.NewClientWithBackOff("token", telegraph.NewBackOff(1, 60))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	body, res, err := client.DeleteWebHook().CommitContext(ctx)
+
+	assert.Nil(t, body)
+	assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.True(t, time.Since(start) < 5*time.Second)
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (fn roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return fn(req)
+}
+
+func TestNewClient_WithBaseURLAndUserAgent(t *testing.T) {
+	gock.New("https://bot.cubesoft.co.id").Get(fmt.Sprintf(telegraph.EndpointGetMe, "token")).
+		MatchHeader(telegraph.UserAgentHeader, "cube-bot/1.0").Reply(http.StatusOK).JSON(`{
+		"ok": true,
+		"result": {
+			"id": 1234567890,
+			"is_bot": true,
+			"first_name": "cube",
+			"username": "cubesoft"
+		}
+	}`)
+	gock.New("https://bot.cubesoft.co.id").Get(fmt.Sprintf(telegraph.EndpointGetContent, "token", "photo/file_1.jpg")).
+		MatchHeader(telegraph.UserAgentHeader, "cube-bot/1.0").Reply(http.StatusOK).BodyString("content")
+	defer gock.Off()
+
+	client := telegraph.NewClient("token", telegraph.WithBaseURL("https://bot.cubesoft.co.id/"),
+		telegraph.WithUserAgent("cube-bot/1.0"))
+
+	user, res, err := client.GetMe().Commit()
+
+	assert.NotNil(t, user)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.NoError(t, err)
+
+	body, res, err := client.GetContent("photo/file_1.jpg").Commit()
+
+	assert.Equal(t, []byte("content"), body)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.NoError(t, err)
+}
+
+func TestNewClient_WithTransport(t *testing.T) {
+	var requested string
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requested = req.URL.String()
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       ioutil.NopCloser(strings.NewReader(`{"ok": true, "result": 100}`)),
+			Request:    req,
+		}, nil
+	})
+
+	client := telegraph.NewClient("token", telegraph.WithTransport(transport), telegraph.WithTimeout(time.Second))
+
+	count, res, err := client.GetChatMembersCount(2434234).Commit()
+
+	assert.Equal(t, int64(100), *count)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.NoError(t, err)
+	assert.Equal(t, telegraph.BaseURL+fmt.Sprintf(telegraph.EndpointGetChatMembersCount, "token")+"?chat_id=2434234", requested)
+}
+
+func TestNewClient_WithProxy(t *testing.T) {
+	var requested string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = r.URL.String()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok": true, "result": true}`))
+	}))
+	defer proxy.Close()
+
+	proxyURL, _ := url.Parse(proxy.URL)
+	client := telegraph.NewClient("token", telegraph.WithBaseURL("http://api.telegram.invalid"),
+		telegraph.WithHTTPClient(&http.Client{}), telegraph.WithProxy(proxyURL), telegraph.WithTLSConfig(&tls.Config{}))
+
+	body, res, err := client.DeleteWebHook().Commit()
+
+	assert.NotNil(t, body)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.NoError(t, err)
+	assert.Equal(t, "http://api.telegram.invalid"+fmt.Sprintf(telegraph.EndpointDeleteWebHook, "token"), requested)
+}
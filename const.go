@@ -9,6 +9,14 @@ const (
 	// UserAgent header send to telegram
 	UserAgent = "Telegram Go SDK(Telegraph)"
 
+	// MaxUploadSize maximum size of file uploaded to telegram bot api
+	MaxUploadSize = 50 << 20
+	// MaxPhotoUploadSize maximum size of photo uploaded to telegram bot api
+	MaxPhotoUploadSize = 10 << 20
+
+	// WebHookSecretHeader header contains secret token in every web hook request from telegram
+	WebHookSecretHeader = "X-Telegram-Bot-Api-Secret-Token"
+
 	EndpointGetMe                   = "/bot%v/getMe"
 	EndpointSetWebHook              = "/bot%v/setWebhook"
 	EndpointGetUpdate               = "/bot%v/getUpdates"
@@ -63,4 +71,10 @@ const (
 	EndpointSetStickerPositionInSet = "/bot%v/setStickerPositionInSet"
 	EndpointDeleteStickerFromSet    = "/bot%v/deleteStickerFromSet"
 	EndpointAnswerInlineQuery       = "/bot%v/answerInlineQuery"
+	EndpointSendInvoice             = "/bot%v/sendInvoice"
+	EndpointAnswerShippingQuery     = "/bot%v/answerShippingQuery"
+	EndpointAnswerPreCheckoutQuery  = "/bot%v/answerPreCheckoutQuery"
+	EndpointSendGame                = "/bot%v/sendGame"
+	EndpointSetGameScore            = "/bot%v/setGameScore"
+	EndpointGetGameHighScores       = "/bot%v/getGameHighScores"
 )
@@ -0,0 +1,69 @@
+package telegraph
+
+// ContentType kind of content carried by the message, empty if the message content is unknown
+func (message *Message) ContentType() ContentType {
+	switch {
+	case message.Text != "":
+		return ContentTypeText
+	case message.Audio != nil:
+		return ContentTypeAudio
+	case message.Document != nil:
+		return ContentTypeDocument
+	case message.Game != nil:
+		return ContentTypeGame
+	case len(message.Photos) > 0:
+		return ContentTypePhoto
+	case message.Sticker != nil:
+		return ContentTypeSticker
+	case message.Video != nil:
+		return ContentTypeVideo
+	case message.Voice != nil:
+		return ContentTypeVoice
+	case message.VideoNote != nil:
+		return ContentTypeVideoNote
+	case message.Contact != nil:
+		return ContentTypeContact
+	case message.Venue != nil:
+		return ContentTypeVenue
+	case message.Location != nil:
+		return ContentTypeLocation
+	case message.Invoice != nil:
+		return ContentTypeInvoice
+	case message.SuccessfulPayment != nil:
+		return ContentTypeSuccessfulPayment
+	case len(message.NewChatMembers) > 0:
+		return ContentTypeNewChatMembers
+	case message.LeftChatMember != nil:
+		return ContentTypeLeftChatMember
+	case message.NewChatTitle != "":
+		return ContentTypeNewChatTitle
+	case len(message.NewChatPhoto) > 0:
+		return ContentTypeNewChatPhoto
+	case message.DeleteChatPhoto:
+		return ContentTypeDeleteChatPhoto
+	case message.GroupChatCreated:
+		return ContentTypeGroupChatCreated
+	case message.SuperGroupChatCreated:
+		return ContentTypeSuperGroupChatCreated
+	case message.ChannelChatCreated:
+		return ContentTypeChannelChatCreated
+	case message.MigrateToChatID != 0:
+		return ContentTypeMigrateToChatID
+	case message.MigrateFromChatID != 0:
+		return ContentTypeMigrateFromChatID
+	case message.PinnedMessage != nil:
+		return ContentTypePinnedMessage
+	}
+	return ""
+}
+
+// IsService report whether the message is a service message, such as new chat members or pinned message
+func (message *Message) IsService() bool {
+	switch message.ContentType() {
+	case ContentTypeNewChatMembers, ContentTypeLeftChatMember, ContentTypeNewChatTitle, ContentTypeNewChatPhoto,
+		ContentTypeDeleteChatPhoto, ContentTypeGroupChatCreated, ContentTypeSuperGroupChatCreated,
+		ContentTypeChannelChatCreated, ContentTypeMigrateToChatID, ContentTypeMigrateFromChatID, ContentTypePinnedMessage:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package telegraph_test
+
+import (
+	"telegraph"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebHookParseRequest_ReplyToMessage(t *testing.T) {
+	payload := []byte(`{
+		"update_id": 651868730,
+		"message": {
+			"message_id": 21,
+			"chat": {"id": 23423423, "type": "private"},
+			"date": 1508298330,
+			"text": "reply",
+			"reply_to_message": {
+				"message_id": 20,
+				"chat": {"id": 23423423, "type": "private"},
+				"date": 1508298329,
+				"photo": [{"file_id": "photo", "width": 90, "height": 90}],
+				"reply_to_message": {
+					"message_id": 19,
+					"chat": {"id": 23423423, "type": "private"},
+					"date": 1508298328,
+					"pinned_message": {"message_id": 18, "chat": {"id": 23423423, "type": "private"}, "date": 1508298327, "text": "pinned"}
+				}
+			}
+		}
+	}`)
+
+	update, err := telegraph.WebHookParseRequest(payload)
+
+	assert.NoError(t, err)
+	assert.Equal(t, telegraph.ContentTypeText, update.Message.ContentType())
+	assert.Equal(t, int64(20), update.Message.ReplyToMessage.MessageID)
+	assert.Equal(t, telegraph.ContentTypePhoto, update.Message.ReplyToMessage.ContentType())
+	assert.Equal(t, telegraph.ContentTypePinnedMessage, update.Message.ReplyToMessage.ReplyToMessage.ContentType())
+	assert.True(t, update.Message.ReplyToMessage.ReplyToMessage.IsService())
+}
+
+func TestMessage_ContentType(t *testing.T) {
+	cases := map[telegraph.ContentType]telegraph.Message{
+		telegraph.ContentTypeText:                  {Text: "text"},
+		telegraph.ContentTypeAudio:                 {Audio: &telegraph.Audio{}, Caption: "caption"},
+		telegraph.ContentTypeDocument:              {Document: &telegraph.Document{}},
+		telegraph.ContentTypeGame:                  {Game: &telegraph.Game{}},
+		telegraph.ContentTypePhoto:                 {Photos: []telegraph.PhotoSize{{}}},
+		telegraph.ContentTypeSticker:               {Sticker: &telegraph.Sticker{}},
+		telegraph.ContentTypeVideo:                 {Video: &telegraph.Video{}},
+		telegraph.ContentTypeVoice:                 {Voice: &telegraph.Voice{}},
+		telegraph.ContentTypeVideoNote:             {VideoNote: &telegraph.VideoNote{}},
+		telegraph.ContentTypeContact:               {Contact: &telegraph.Contact{}},
+		telegraph.ContentTypeLocation:              {Location: &telegraph.Location{}},
+		telegraph.ContentTypeVenue:                 {Venue: &telegraph.Venue{}, Location: &telegraph.Location{}},
+		telegraph.ContentTypeInvoice:               {Invoice: &telegraph.Invoice{}},
+		telegraph.ContentTypeSuccessfulPayment:     {SuccessfulPayment: &telegraph.SuccessfulPayment{}},
+		telegraph.ContentTypeNewChatMembers:        {NewChatMembers: []telegraph.User{{}}},
+		telegraph.ContentTypeLeftChatMember:        {LeftChatMember: &telegraph.User{}},
+		telegraph.ContentTypeNewChatTitle:          {NewChatTitle: "title"},
+		telegraph.ContentTypeNewChatPhoto:          {NewChatPhoto: []telegraph.PhotoSize{{}}},
+		telegraph.ContentTypeDeleteChatPhoto:       {DeleteChatPhoto: true},
+		telegraph.ContentTypeGroupChatCreated:      {GroupChatCreated: true},
+		telegraph.ContentTypeSuperGroupChatCreated: {SuperGroupChatCreated: true},
+		telegraph.ContentTypeChannelChatCreated:    {ChannelChatCreated: true},
+		telegraph.ContentTypeMigrateToChatID:       {MigrateToChatID: -100123},
+		telegraph.ContentTypeMigrateFromChatID:     {MigrateFromChatID: -123},
+		telegraph.ContentTypePinnedMessage:         {PinnedMessage: &telegraph.Message{}},
+		"":                                         {},
+	}
+
+	for expected, message := range cases {
+		message := message
+		assert.Equal(t, expected, message.ContentType(), string(expected))
+	}
+
+	assert.False(t, (&telegraph.Message{Text: "text"}).IsService())
+	assert.True(t, (&telegraph.Message{NewChatMembers: []telegraph.User{{}}}).IsService())
+}
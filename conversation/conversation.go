@@ -0,0 +1,400 @@
+/*
+Package conversation run multi-step flow such as registration form or wizard, keeping the state of each user in each chat
+across updates in a Storage.
+
+A conversation starts when an update matches an entry, every step handle the update and return the next state,
+End finish the conversation. Conversation expires after timeout and can be canceled by command.
+
+	conv := conversation.New(conversation.NewMemoryStorage()).
+		Entry(conversation.Command("register"), askName).
+		State("name", conversation.Text(), askAge).
+		State("age", conversation.Text(), finish).
+		SetTimeout(10 * time.Minute).
+		SetCancelCommands("cancel")
+
+	router := dispatcher.New().Match(conv.Matcher(), conv).Command("help", help)
+
+Routed with Matcher, update which doesn't match any transition of the user's state, such as /help in the middle
+of the conversation, goes to the next routes. Sessions of users who never come back are deleted by Expire.
+*/
+package conversation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"telegraph"
+	"telegraph/dispatcher"
+	"time"
+)
+
+// End state returned by step to finish the conversation, the session is deleted
+const End State = ""
+
+type (
+	// State name of conversation step the user is in
+	State string
+
+	// Key identify session of a user in a chat, chat or user is zero when the update doesn't have one
+	Key struct {
+		ChatID int64
+		UserID int64
+	}
+
+	// Session state and data of conversation, Data keeps answers collected by previous steps
+	Session struct {
+		State   State             `json:"state"`
+		Data    map[string]string `json:"data,omitempty"`
+		Updated time.Time         `json:"updated"`
+	}
+
+	// Step handle update in the current state and return the next state, End finish the conversation
+	Step func(ctx context.Context, update *telegraph.Update, session *Session) State
+
+	// Conversation telegraph.Handler moving user through states, updates of the same key must be handled sequentially,
+	// e.g. with telegraph.WorkerPool
+	Conversation struct {
+		storage        Storage
+		entries        []transition
+		states         map[State][]transition
+		timeout        time.Duration
+		cancelCommands []string
+		onTimeout      func(ctx context.Context, update *telegraph.Update, session Session)
+		onCancel       func(ctx context.Context, update *telegraph.Update, session Session)
+		onError        func(err error)
+		fallback       telegraph.Handler
+		key            func(update *telegraph.Update) (Key, bool)
+	}
+
+	transition struct {
+		match dispatcher.Matcher
+		step  Step
+	}
+
+	// loaded session loaded by Matcher, reused by HandleUpdate of the same update
+	loaded struct {
+		conv    *Conversation
+		key     Key
+		session Session
+		found   bool
+		err     error
+	}
+
+	contextKey int
+)
+
+const loadedKey contextKey = iota
+
+// New create conversation keeping sessions in storage
+func New(storage Storage) *Conversation {
+	return &Conversation{
+		storage: storage,
+		states:  map[State][]transition{},
+		onError: func(error) {},
+		key:     KeyByChatUser,
+	}
+}
+
+// Entry start conversation with step when user without conversation send update matching match
+func (conv *Conversation) Entry(match dispatcher.Matcher, step Step) *Conversation {
+	conv.entries = append(conv.entries, transition{match: match, step: step})
+	return conv
+}
+
+// State handle update matching match with step when user is in state, transitions of a state are matched in order
+func (conv *Conversation) State(state State, match dispatcher.Matcher, step Step) *Conversation {
+	conv.states[state] = append(conv.states[state], transition{match: match, step: step})
+	return conv
+}
+
+// SetTimeout expire conversation when user doesn't answer within timeout, expiration is checked on the next update of the user.
+// Sessions of users who never come back stay in storage until Expire is called. Zero means never expire
+func (conv *Conversation) SetTimeout(timeout time.Duration) *Conversation {
+	conv.timeout = timeout
+	return conv
+}
+
+// SetCancelCommands bot commands without slash finishing the conversation in any state, e.g. cancel
+func (conv *Conversation) SetCancelCommands(commands ...string) *Conversation {
+	conv.cancelCommands = commands
+	return conv
+}
+
+// OnTimeout called with the expired session before the update is handled as if user had no conversation
+func (conv *Conversation) OnTimeout(fn func(ctx context.Context, update *telegraph.Update, session Session)) *Conversation {
+	conv.onTimeout = fn
+	return conv
+}
+
+// OnCancel called with the canceled session when user send cancel command
+func (conv *Conversation) OnCancel(fn func(ctx context.Context, update *telegraph.Update, session Session)) *Conversation {
+	conv.onCancel = fn
+	return conv
+}
+
+// SetErrorHandler called when storage fails, the update is dropped
+func (conv *Conversation) SetErrorHandler(fn func(err error)) *Conversation {
+	conv.onError = fn
+	return conv
+}
+
+// SetFallback handle update which doesn't belong to conversation, e.g. no entry matches
+func (conv *Conversation) SetFallback(handler telegraph.Handler) *Conversation {
+	conv.fallback = handler
+	return conv
+}
+
+// SetKey decide how session is keyed, default is KeyByChatUser
+func (conv *Conversation) SetKey(key func(update *telegraph.Update) (Key, bool)) *Conversation {
+	conv.key = key
+	return conv
+}
+
+// HandleUpdate move user to the next state, update which doesn't match any transition is passed to fallback
+func (conv *Conversation) HandleUpdate(ctx context.Context, update *telegraph.Update) {
+	key, ok := conv.key(update)
+	if !ok {
+		conv.pass(ctx, update)
+		return
+	}
+
+	session, found, err := conv.load(ctx, key, update)
+	if err != nil {
+		conv.onError(err)
+		return
+	}
+
+	if found && conv.isCancel(update) {
+		if err := conv.storage.Delete(ctx, key); err != nil {
+			conv.onError(fmt.Errorf("conversation: delete session %v: %w", key, err))
+			return
+		}
+		if conv.onCancel != nil {
+			conv.onCancel(ctx, update, session)
+		}
+		return
+	}
+
+	if !found {
+		session = Session{Data: map[string]string{}}
+	}
+
+	for _, transition := range conv.transitions(session, found) {
+		matched, ok := transition.match(ctx, update)
+		if !ok {
+			continue
+		}
+		if session.Data == nil {
+			session.Data = map[string]string{}
+		}
+
+		next := transition.step(matched, update, &session)
+		if next == End {
+			err = conv.storage.Delete(ctx, key)
+		} else {
+			session.State = next
+			session.Updated = time.Now()
+			err = conv.storage.Save(ctx, key, session)
+		}
+		if err != nil {
+			conv.onError(fmt.Errorf("conversation: save session %v: %w", key, err))
+		}
+		return
+	}
+
+	conv.pass(ctx, update)
+}
+
+/*
+Matcher match update of user in conversation matching a transition of the state or a cancel command,
+or update of user without conversation matching an entry, to route update to the conversation with dispatcher.
+Other updates, e.g. global command such as /help in the middle of conversation, are left to the next routes.
+Expired session is deleted and OnTimeout is called before the update is matched against entries.
+Update whose session can't be loaded is matched too, so HandleUpdate report the error to the error handler.
+The loaded session is reused by HandleUpdate through the returned context
+*/
+func (conv *Conversation) Matcher() dispatcher.Matcher {
+	return func(ctx context.Context, update *telegraph.Update) (context.Context, bool) {
+		key, ok := conv.key(update)
+		if !ok {
+			return ctx, false
+		}
+		session, found, err := conv.resume(ctx, key, update)
+		matched := context.WithValue(ctx, loadedKey, loaded{conv: conv, key: key, session: session, found: found, err: err})
+		if err != nil || (found && conv.isCancel(update)) {
+			return matched, true
+		}
+		for _, transition := range conv.transitions(session, found) {
+			if _, ok := transition.match(ctx, update); ok {
+				return matched, true
+			}
+		}
+		return ctx, false
+	}
+}
+
+// Expire delete sessions expired by timeout from storage, OnTimeout isn't called because there is no update of the user.
+// Call it periodically, e.g. with time.Ticker, so sessions of users who never come back don't pile up
+func (conv *Conversation) Expire(ctx context.Context) error {
+	if conv.timeout <= 0 {
+		return nil
+	}
+	return conv.storage.Expire(ctx, time.Now().Add(-conv.timeout))
+}
+
+// Reset finish conversation of key, e.g. when user block the bot
+func (conv *Conversation) Reset(ctx context.Context, key Key) error {
+	return conv.storage.Delete(ctx, key)
+}
+
+// load session of key resumed by Matcher of this conversation, or resume it from storage
+func (conv *Conversation) load(ctx context.Context, key Key, update *telegraph.Update) (Session, bool, error) {
+	if loaded, ok := ctx.Value(loadedKey).(loaded); ok && loaded.conv == conv && loaded.key == key {
+		return loaded.session, loaded.found, loaded.err
+	}
+	return conv.resume(ctx, key, update)
+}
+
+// resume load session of key from storage, expired session is deleted and reported to OnTimeout as not found
+func (conv *Conversation) resume(ctx context.Context, key Key, update *telegraph.Update) (Session, bool, error) {
+	session, found, err := conv.storage.Load(ctx, key)
+	if err != nil {
+		return Session{}, false, fmt.Errorf("conversation: load session %v: %w", key, err)
+	}
+	if !found || !conv.expired(session) {
+		return session, found, nil
+	}
+
+	if err := conv.storage.Delete(ctx, key); err != nil {
+		return Session{}, false, fmt.Errorf("conversation: delete session %v: %w", key, err)
+	}
+	if conv.onTimeout != nil {
+		conv.onTimeout(ctx, update, session)
+	}
+	return Session{}, false, nil
+}
+
+// transitions of the session state, or entries when user has no conversation
+func (conv *Conversation) transitions(session Session, found bool) []transition {
+	if found {
+		return conv.states[session.State]
+	}
+	return conv.entries
+}
+
+func (conv *Conversation) expired(session Session) bool {
+	return conv.timeout > 0 && time.Since(session.Updated) > conv.timeout
+}
+
+func (conv *Conversation) isCancel(update *telegraph.Update) bool {
+	message := update.Message
+	if message == nil {
+		return false
+	}
+	command, ok := dispatcher.ParseCommand(message)
+	if !ok {
+		return false
+	}
+	for _, cancel := range conv.cancelCommands {
+		if strings.EqualFold(command.Name, strings.TrimPrefix(cancel, "/")) {
+			return true
+		}
+	}
+	return false
+}
+
+func (conv *Conversation) pass(ctx context.Context, update *telegraph.Update) {
+	if conv.fallback != nil {
+		conv.fallback.HandleUpdate(ctx, update)
+	}
+}
+
+// KeyByChatUser session of each user in each chat, update without user such as channel post is keyed by chat
+func KeyByChatUser(update *telegraph.Update) (Key, bool) {
+	key := Key{}
+	if chat := update.Chat(); chat != nil {
+		key.ChatID = chat.ID
+	}
+	if user := update.From(); user != nil {
+		key.UserID = user.ID
+	}
+	return key, key != Key{}
+}
+
+// KeyByChat session shared by every user in a chat
+func KeyByChat(update *telegraph.Update) (Key, bool) {
+	chat := update.Chat()
+	if chat == nil {
+		return Key{}, false
+	}
+	return Key{ChatID: chat.ID}, true
+}
+
+// KeyByUser session of user shared across chats and inline queries
+func KeyByUser(update *telegraph.Update) (Key, bool) {
+	user := update.From()
+	if user == nil {
+		return Key{}, false
+	}
+	return Key{UserID: user.ID}, true
+}
+
+// String key as chat:user
+func (key Key) String() string {
+	return fmt.Sprintf("%d:%d", key.ChatID, key.UserID)
+}
+
+// Any match every update
+func Any() dispatcher.Matcher {
+	return func(ctx context.Context, update *telegraph.Update) (context.Context, bool) {
+		return ctx, true
+	}
+}
+
+// Text match message with text which isn't a bot command
+func Text() dispatcher.Matcher {
+	return func(ctx context.Context, update *telegraph.Update) (context.Context, bool) {
+		if update.Message == nil || update.Message.Text == "" {
+			return ctx, false
+		}
+		_, command := dispatcher.ParseCommand(update.Message)
+		return ctx, !command
+	}
+}
+
+// Command match message starting with bot command without slash, arguments can be parsed with dispatcher.ParseCommand
+func Command(command string) dispatcher.Matcher {
+	return func(ctx context.Context, update *telegraph.Update) (context.Context, bool) {
+		if update.Message == nil {
+			return ctx, false
+		}
+		parsed, ok := dispatcher.ParseCommand(update.Message)
+		if !ok || !strings.EqualFold(parsed.Name, strings.TrimPrefix(command, "/")) {
+			return ctx, false
+		}
+		return ctx, true
+	}
+}
+
+// Callback match callback query whose data starts with prefix
+func Callback(prefix string) dispatcher.Matcher {
+	return func(ctx context.Context, update *telegraph.Update) (context.Context, bool) {
+		if update.CallbackQuery == nil || !strings.HasPrefix(update.CallbackQuery.Data, prefix) {
+			return ctx, false
+		}
+		return ctx, true
+	}
+}
+
+// Set save value of key in session data
+func (session *Session) Set(key, value string) {
+	if session.Data == nil {
+		session.Data = map[string]string{}
+	}
+	session.Data[key] = value
+}
+
+// Get value of key in session data, empty if it's not set
+func (session *Session) Get(key string) string {
+	return session.Data[key]
+}
@@ -0,0 +1,310 @@
+package conversation_test
+
+import (
+	"context"
+	"errors"
+	"telegraph"
+	"telegraph/conversation"
+	"telegraph/dispatcher"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func text(userID int64, text string) *telegraph.Update {
+	return &telegraph.Update{
+		Message: &telegraph.Message{
+			Text: text,
+			Chat: telegraph.Chat{ID: 1, Type: telegraph.ChatTypeGroup},
+			From: &telegraph.User{ID: userID},
+		},
+	}
+}
+
+func command(userID int64, name string) *telegraph.Update {
+	update := text(userID, "/"+name)
+	update.Message.Entities = []telegraph.MessageEntity{{Type: "bot_command", Offset: 0, Length: len(name) + 1}}
+	return update
+}
+
+func callbackQuery(userID int64, data string) *telegraph.Update {
+	return &telegraph.Update{
+		CallbackQuery: &telegraph.CallbackQuery{
+			From:    telegraph.User{ID: userID},
+			Message: &telegraph.Message{Chat: telegraph.Chat{ID: 1}},
+			Data:    data,
+		},
+	}
+}
+
+func registration(storage conversation.Storage, done *[]conversation.Session) *conversation.Conversation {
+	return conversation.New(storage).
+		Entry(conversation.Command("register"), func(ctx context.Context, update *telegraph.Update, session *conversation.Session) conversation.State {
+			return "name"
+		}).
+		State("name", conversation.Text(), func(ctx context.Context, update *telegraph.Update, session *conversation.Session) conversation.State {
+			session.Set("name", update.Message.Text)
+			return "confirm"
+		}).
+		State("confirm", conversation.Callback("yes"), func(ctx context.Context, update *telegraph.Update, session *conversation.Session) conversation.State {
+			*done = append(*done, *session)
+			return conversation.End
+		}).
+		State("confirm", conversation.Callback("no"), func(ctx context.Context, update *telegraph.Update, session *conversation.Session) conversation.State {
+			return "name"
+		})
+}
+
+func TestConversation_Flow(t *testing.T) {
+	ctx := context.Background()
+	storage := conversation.NewMemoryStorage()
+	done := []conversation.Session{}
+	conv := registration(storage, &done)
+
+	conv.HandleUpdate(ctx, command(10, "register"))
+	conv.HandleUpdate(ctx, text(10, "Alice"))
+	conv.HandleUpdate(ctx, callbackQuery(10, "no"))
+	conv.HandleUpdate(ctx, text(10, "Bob"))
+
+	session, found, err := storage.Load(ctx, conversation.Key{ChatID: 1, UserID: 10})
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, conversation.State("confirm"), session.State)
+	assert.Equal(t, "Bob", session.Get("name"))
+
+	conv.HandleUpdate(ctx, callbackQuery(10, "yes"))
+	assert.Len(t, done, 1)
+	assert.Equal(t, "Bob", done[0].Get("name"))
+
+	_, found, _ = storage.Load(ctx, conversation.Key{ChatID: 1, UserID: 10})
+	assert.False(t, found)
+}
+
+func TestConversation_PerUser(t *testing.T) {
+	ctx := context.Background()
+	storage := conversation.NewMemoryStorage()
+	conv := registration(storage, &[]conversation.Session{})
+
+	conv.HandleUpdate(ctx, command(10, "register"))
+	conv.HandleUpdate(ctx, text(20, "Mallory"))
+	conv.HandleUpdate(ctx, text(10, "Alice"))
+
+	session, _, _ := storage.Load(ctx, conversation.Key{ChatID: 1, UserID: 10})
+	assert.Equal(t, "Alice", session.Get("name"))
+
+	_, found, _ := storage.Load(ctx, conversation.Key{ChatID: 1, UserID: 20})
+	assert.False(t, found)
+}
+
+func TestConversation_Cancel(t *testing.T) {
+	ctx := context.Background()
+	storage := conversation.NewMemoryStorage()
+	canceled := []conversation.State{}
+	conv := registration(storage, &[]conversation.Session{}).
+		SetCancelCommands("/cancel").
+		OnCancel(func(ctx context.Context, update *telegraph.Update, session conversation.Session) {
+			canceled = append(canceled, session.State)
+		})
+
+	conv.HandleUpdate(ctx, command(10, "cancel"))
+	assert.Empty(t, canceled)
+
+	conv.HandleUpdate(ctx, command(10, "register"))
+	conv.HandleUpdate(ctx, command(10, "cancel"))
+	assert.Equal(t, []conversation.State{"name"}, canceled)
+
+	_, found, _ := storage.Load(ctx, conversation.Key{ChatID: 1, UserID: 10})
+	assert.False(t, found)
+}
+
+func TestConversation_Timeout(t *testing.T) {
+	ctx := context.Background()
+	storage := conversation.NewMemoryStorage()
+	expired := []conversation.State{}
+	conv := registration(storage, &[]conversation.Session{}).
+		SetTimeout(time.Minute).
+		OnTimeout(func(ctx context.Context, update *telegraph.Update, session conversation.Session) {
+			expired = append(expired, session.State)
+		})
+
+	key := conversation.Key{ChatID: 1, UserID: 10}
+	assert.NoError(t, storage.Save(ctx, key, conversation.Session{State: "name", Updated: time.Now().Add(-time.Hour)}))
+
+	conv.HandleUpdate(ctx, text(10, "Alice"))
+	assert.Equal(t, []conversation.State{"name"}, expired)
+
+	_, found, _ := storage.Load(ctx, key)
+	assert.False(t, found)
+
+	conv.HandleUpdate(ctx, command(10, "register"))
+	conv.HandleUpdate(ctx, text(10, "Alice"))
+	session, _, _ := storage.Load(ctx, key)
+	assert.Equal(t, conversation.State("confirm"), session.State)
+	assert.Len(t, expired, 1)
+}
+
+func TestConversation_Dispatcher(t *testing.T) {
+	ctx := context.Background()
+	handled := []string{}
+	conv := registration(conversation.NewMemoryStorage(), &[]conversation.Session{})
+	router := dispatcher.New().
+		Match(conv.Matcher(), conv).
+		Fallback(telegraph.HandlerFunc(func(ctx context.Context, update *telegraph.Update) {
+			handled = append(handled, update.Message.Text)
+		}))
+
+	router.HandleUpdate(ctx, text(10, "hello"))
+	router.HandleUpdate(ctx, command(10, "register"))
+	router.HandleUpdate(ctx, text(10, "Alice"))
+	assert.Equal(t, []string{"hello"}, handled)
+}
+
+func TestConversation_Fallback(t *testing.T) {
+	ctx := context.Background()
+	handled := 0
+	conv := registration(conversation.NewMemoryStorage(), &[]conversation.Session{}).
+		SetFallback(telegraph.HandlerFunc(func(ctx context.Context, update *telegraph.Update) {
+			handled++
+		}))
+
+	conv.HandleUpdate(ctx, text(10, "hello"))
+	conv.HandleUpdate(ctx, &telegraph.Update{})
+	conv.HandleUpdate(ctx, command(10, "register"))
+	conv.HandleUpdate(ctx, callbackQuery(10, "yes"))
+	assert.Equal(t, 3, handled)
+}
+
+type failingStorage struct {
+	conversation.Storage
+}
+
+func (failingStorage) Save(ctx context.Context, key conversation.Key, session conversation.Session) error {
+	return errors.New("disk full")
+}
+
+func TestConversation_StorageError(t *testing.T) {
+	var handled error
+	conv := registration(failingStorage{conversation.NewMemoryStorage()}, &[]conversation.Session{}).
+		SetErrorHandler(func(err error) {
+			handled = err
+		})
+
+	conv.HandleUpdate(context.Background(), command(10, "register"))
+	assert.EqualError(t, handled, "conversation: save session 1:10: disk full")
+}
+
+type countingStorage struct {
+	conversation.Storage
+	loads int
+	err   error
+}
+
+func (storage *countingStorage) Load(ctx context.Context, key conversation.Key) (conversation.Session, bool, error) {
+	storage.loads++
+	if storage.err != nil {
+		return conversation.Session{}, false, storage.err
+	}
+	return storage.Storage.Load(ctx, key)
+}
+
+func TestConversation_DispatcherLoad(t *testing.T) {
+	ctx := context.Background()
+	storage := &countingStorage{Storage: conversation.NewMemoryStorage()}
+	handled := 0
+	var failure error
+	conv := registration(storage, &[]conversation.Session{}).
+		SetErrorHandler(func(err error) {
+			failure = err
+		})
+	router := dispatcher.New().
+		Match(conv.Matcher(), conv).
+		Fallback(telegraph.HandlerFunc(func(ctx context.Context, update *telegraph.Update) {
+			handled++
+		}))
+
+	router.HandleUpdate(ctx, command(10, "register"))
+	router.HandleUpdate(ctx, text(10, "Alice"))
+	assert.Equal(t, 2, storage.loads)
+	session, _, _ := storage.Storage.Load(ctx, conversation.Key{ChatID: 1, UserID: 10})
+	assert.Equal(t, conversation.State("confirm"), session.State)
+
+	storage.err = errors.New("connection refused")
+	router.HandleUpdate(ctx, text(10, "hello"))
+	assert.Equal(t, 0, handled)
+	assert.EqualError(t, failure, "conversation: load session 1:10: connection refused")
+}
+
+func TestConversation_Expire(t *testing.T) {
+	ctx := context.Background()
+	storage := conversation.NewMemoryStorage()
+	conv := registration(storage, &[]conversation.Session{}).SetTimeout(time.Minute)
+
+	stale := conversation.Key{ChatID: 1, UserID: 20}
+	assert.NoError(t, storage.Save(ctx, stale, conversation.Session{State: "name", Updated: time.Now().Add(-time.Hour)}))
+
+	conv.HandleUpdate(ctx, command(10, "register"))
+	_, found, _ := storage.Load(ctx, stale)
+	assert.True(t, found)
+
+	assert.NoError(t, conv.Expire(ctx))
+	_, found, _ = storage.Load(ctx, stale)
+	assert.False(t, found)
+	_, found, _ = storage.Load(ctx, conversation.Key{ChatID: 1, UserID: 10})
+	assert.True(t, found)
+}
+
+func TestConversation_DispatcherRoutes(t *testing.T) {
+	ctx := context.Background()
+	storage := conversation.NewMemoryStorage()
+	expired := 0
+	handled := []string{}
+	conv := registration(storage, &[]conversation.Session{}).
+		SetTimeout(time.Minute).
+		OnTimeout(func(ctx context.Context, update *telegraph.Update, session conversation.Session) {
+			expired++
+		})
+	router := dispatcher.New().
+		Match(conv.Matcher(), conv).
+		Command("help", telegraph.HandlerFunc(func(ctx context.Context, update *telegraph.Update) {
+			handled = append(handled, "help")
+		})).
+		CallbackPrefix("vote", telegraph.HandlerFunc(func(ctx context.Context, update *telegraph.Update) {
+			handled = append(handled, "vote")
+		}))
+	key := conversation.Key{ChatID: 1, UserID: 10}
+
+	router.HandleUpdate(ctx, command(10, "register"))
+	router.HandleUpdate(ctx, command(10, "help"))
+	router.HandleUpdate(ctx, callbackQuery(10, "vote"))
+	session, _, _ := storage.Load(ctx, key)
+	assert.Equal(t, conversation.State("name"), session.State)
+	assert.Equal(t, []string{"help", "vote"}, handled)
+
+	session.Updated = time.Now().Add(-time.Hour)
+	assert.NoError(t, storage.Save(ctx, key, session))
+	router.HandleUpdate(ctx, command(10, "help"))
+	assert.Equal(t, []string{"help", "vote", "help"}, handled)
+	assert.Equal(t, 1, expired)
+	_, found, _ := storage.Load(ctx, key)
+	assert.False(t, found)
+}
+
+func TestKey(t *testing.T) {
+	update := callbackQuery(10, "yes")
+
+	key, ok := conversation.KeyByChatUser(update)
+	assert.True(t, ok)
+	assert.Equal(t, conversation.Key{ChatID: 1, UserID: 10}, key)
+
+	key, ok = conversation.KeyByChat(update)
+	assert.True(t, ok)
+	assert.Equal(t, conversation.Key{ChatID: 1}, key)
+
+	key, ok = conversation.KeyByUser(&telegraph.Update{InlineQuery: &telegraph.InlineQuery{From: telegraph.User{ID: 10}}})
+	assert.True(t, ok)
+	assert.Equal(t, conversation.Key{UserID: 10}, key)
+
+	_, ok = conversation.KeyByChatUser(&telegraph.Update{})
+	assert.False(t, ok)
+}
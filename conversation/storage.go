@@ -0,0 +1,209 @@
+package conversation
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+type (
+	// Storage keep session of each key, found is false when key doesn't have a session
+	Storage interface {
+		Load(ctx context.Context, key Key) (session Session, found bool, err error)
+		Save(ctx context.Context, key Key, session Session) error
+		Delete(ctx context.Context, key Key) error
+		// Expire delete sessions last updated before before, so sessions of users who never come back don't pile up
+		Expire(ctx context.Context, before time.Time) error
+	}
+
+	// MemoryStorage Storage in memory, sessions are lost when the bot restarts
+	MemoryStorage struct {
+		mutex    sync.Mutex
+		sessions map[Key]Session
+	}
+
+	// FileStorage Storage in a JSON file, the whole file is rewritten atomically on every change
+	// so it's suited for bot with small number of active conversations
+	FileStorage struct {
+		path     string
+		mutex    sync.Mutex
+		sessions map[string]Session
+	}
+)
+
+// NewMemoryStorage create empty memory storage
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{sessions: map[Key]Session{}}
+}
+
+// Load session of key
+func (storage *MemoryStorage) Load(ctx context.Context, key Key) (Session, bool, error) {
+	storage.mutex.Lock()
+	defer storage.mutex.Unlock()
+
+	session, found := storage.sessions[key]
+	return copySession(session), found, nil
+}
+
+// Save session of key
+func (storage *MemoryStorage) Save(ctx context.Context, key Key, session Session) error {
+	storage.mutex.Lock()
+	defer storage.mutex.Unlock()
+
+	storage.sessions[key] = copySession(session)
+	return nil
+}
+
+// Delete session of key
+func (storage *MemoryStorage) Delete(ctx context.Context, key Key) error {
+	storage.mutex.Lock()
+	defer storage.mutex.Unlock()
+
+	delete(storage.sessions, key)
+	return nil
+}
+
+// Expire delete sessions last updated before before
+func (storage *MemoryStorage) Expire(ctx context.Context, before time.Time) error {
+	storage.mutex.Lock()
+	defer storage.mutex.Unlock()
+
+	for key, session := range storage.sessions {
+		if session.Updated.Before(before) {
+			delete(storage.sessions, key)
+		}
+	}
+	return nil
+}
+
+// NewFileStorage create storage in JSON file at path, sessions saved previously are loaded when the file exists
+func NewFileStorage(path string) (*FileStorage, error) {
+	storage := &FileStorage{path: path, sessions: map[string]Session{}}
+
+	content, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return storage, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(content) > 0 {
+		if err := json.Unmarshal(content, &storage.sessions); err != nil {
+			return nil, err
+		}
+	}
+
+	return storage, nil
+}
+
+// Load session of key
+func (storage *FileStorage) Load(ctx context.Context, key Key) (Session, bool, error) {
+	storage.mutex.Lock()
+	defer storage.mutex.Unlock()
+
+	session, found := storage.sessions[key.String()]
+	return copySession(session), found, nil
+}
+
+// Save session of key and write the file
+func (storage *FileStorage) Save(ctx context.Context, key Key, session Session) error {
+	storage.mutex.Lock()
+	defer storage.mutex.Unlock()
+
+	previous, found := storage.sessions[key.String()]
+	storage.sessions[key.String()] = copySession(session)
+	if err := storage.write(); err != nil {
+		if found {
+			storage.sessions[key.String()] = previous
+		} else {
+			delete(storage.sessions, key.String())
+		}
+		return err
+	}
+	return nil
+}
+
+// Delete session of key and write the file
+func (storage *FileStorage) Delete(ctx context.Context, key Key) error {
+	storage.mutex.Lock()
+	defer storage.mutex.Unlock()
+
+	previous, found := storage.sessions[key.String()]
+	if !found {
+		return nil
+	}
+	delete(storage.sessions, key.String())
+	if err := storage.write(); err != nil {
+		storage.sessions[key.String()] = previous
+		return err
+	}
+	return nil
+}
+
+// Expire delete sessions last updated before before, the file is written only when a session is deleted
+func (storage *FileStorage) Expire(ctx context.Context, before time.Time) error {
+	storage.mutex.Lock()
+	defer storage.mutex.Unlock()
+
+	expired := map[string]Session{}
+	for key, session := range storage.sessions {
+		if session.Updated.Before(before) {
+			expired[key] = session
+			delete(storage.sessions, key)
+		}
+	}
+	if len(expired) == 0 {
+		return nil
+	}
+	if err := storage.write(); err != nil {
+		for key, session := range expired {
+			storage.sessions[key] = session
+		}
+		return err
+	}
+	return nil
+}
+
+// write sessions to temporary file and rename it, so the file is never left half written
+func (storage *FileStorage) write() error {
+	content, err := json.MarshalIndent(storage.sessions, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	temp, err := ioutil.TempFile(filepath.Dir(storage.path), filepath.Base(storage.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temp.Name())
+
+	if _, err := temp.Write(content); err != nil {
+		temp.Close()
+		return err
+	}
+	if err := temp.Sync(); err != nil {
+		temp.Close()
+		return err
+	}
+	if err := temp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(temp.Name(), storage.path)
+}
+
+// copySession copy of session so caller can't modify stored data
+func copySession(session Session) Session {
+	if session.Data != nil {
+		data := make(map[string]string, len(session.Data))
+		for key, value := range session.Data {
+			data[key] = value
+		}
+		session.Data = data
+	}
+	return session
+}
@@ -0,0 +1,93 @@
+package conversation_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"telegraph/conversation"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testStorage(t *testing.T, storage conversation.Storage) {
+	ctx := context.Background()
+	key := conversation.Key{ChatID: 1, UserID: 10}
+
+	_, found, err := storage.Load(ctx, key)
+	assert.NoError(t, err)
+	assert.False(t, found)
+
+	session := conversation.Session{State: "name", Data: map[string]string{"lang": "en"}, Updated: time.Now().UTC()}
+	assert.NoError(t, storage.Save(ctx, key, session))
+
+	loaded, found, err := storage.Load(ctx, key)
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, session.State, loaded.State)
+	assert.Equal(t, session.Data, loaded.Data)
+	assert.True(t, session.Updated.Equal(loaded.Updated))
+
+	loaded.Set("lang", "id")
+	again, _, _ := storage.Load(ctx, key)
+	assert.Equal(t, "en", again.Get("lang"))
+
+	assert.NoError(t, storage.Delete(ctx, key))
+	assert.NoError(t, storage.Delete(ctx, key))
+	_, found, _ = storage.Load(ctx, key)
+	assert.False(t, found)
+
+	stale := conversation.Key{ChatID: 2, UserID: 10}
+	assert.NoError(t, storage.Save(ctx, stale, conversation.Session{State: "name", Updated: time.Now().Add(-time.Hour)}))
+	assert.NoError(t, storage.Save(ctx, key, session))
+	assert.NoError(t, storage.Expire(ctx, time.Now().Add(-time.Minute)))
+	_, found, _ = storage.Load(ctx, stale)
+	assert.False(t, found)
+	_, found, _ = storage.Load(ctx, key)
+	assert.True(t, found)
+	assert.NoError(t, storage.Delete(ctx, key))
+}
+
+func TestMemoryStorage(t *testing.T) {
+	testStorage(t, conversation.NewMemoryStorage())
+}
+
+func TestFileStorage(t *testing.T) {
+	dir, err := ioutil.TempDir("", "conversation")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "sessions.json")
+	storage, err := conversation.NewFileStorage(path)
+	assert.NoError(t, err)
+	testStorage(t, storage)
+
+	ctx := context.Background()
+	key := conversation.Key{ChatID: -100, UserID: 10}
+	assert.NoError(t, storage.Save(ctx, key, conversation.Session{State: "age", Data: map[string]string{"name": "Alice"}}))
+
+	reopened, err := conversation.NewFileStorage(path)
+	assert.NoError(t, err)
+	session, found, err := reopened.Load(ctx, key)
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, conversation.State("age"), session.State)
+	assert.Equal(t, "Alice", session.Get("name"))
+
+	files, _ := ioutil.ReadDir(dir)
+	assert.Len(t, files, 1)
+}
+
+func TestFileStorage_Invalid(t *testing.T) {
+	file, err := ioutil.TempFile("", "conversation")
+	assert.NoError(t, err)
+	defer os.Remove(file.Name())
+
+	file.WriteString("{invalid")
+	file.Close()
+
+	_, err = conversation.NewFileStorage(file.Name())
+	assert.Error(t, err)
+}
@@ -0,0 +1,199 @@
+/*
+Package dispatcher route telegram updates to handlers by bot command, callback data, inline query,
+chat type and update type.
+
+Routes are matched in a fixed priority, the first matching route handles the update:
+ 1. Match (custom matcher)
+ 2. Command
+ 3. CallbackPrefix and CallbackRegexp
+ 4. InlineQuery
+ 5. ChatType
+ 6. UpdateType
+ 7. Fallback
+
+Routes in the same priority are matched in the order they are registered.
+*/
+package dispatcher
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"telegraph"
+)
+
+const (
+	priorityMatch = iota
+	priorityCommand
+	priorityCallback
+	priorityInlineQuery
+	priorityChatType
+	priorityUpdateType
+	priorityCount
+)
+
+type (
+	// Matcher report whether update should be handled by route, ctx returned is passed to the handler
+	Matcher func(ctx context.Context, update *telegraph.Update) (context.Context, bool)
+
+	// Command bot command parsed from message, e.g. /start@cubebot payload
+	Command = telegraph.BotCommand
+
+	// Dispatcher route update to the first matching handler, Dispatcher is a telegraph.Handler
+	Dispatcher struct {
+		botName  string
+		routes   [priorityCount][]route
+		fallback telegraph.Handler
+	}
+
+	route struct {
+		match   Matcher
+		handler telegraph.Handler
+	}
+
+	contextKey int
+)
+
+const (
+	commandKey contextKey = iota
+	matchKey
+)
+
+// New create dispatcher without any route
+func New() *Dispatcher {
+	return &Dispatcher{}
+}
+
+// SetBotName username of the bot, command addressed to another bot such as /start@otherbot are ignored
+func (dispatcher *Dispatcher) SetBotName(username string) *Dispatcher {
+	dispatcher.botName = strings.TrimPrefix(username, "@")
+	return dispatcher
+}
+
+// Match route update to handler when match return true
+func (dispatcher *Dispatcher) Match(match Matcher, handler telegraph.Handler) *Dispatcher {
+	return dispatcher.add(priorityMatch, match, handler)
+}
+
+// Command route message and channel post starting with bot command, e.g. Command("start", handler) handle "/start payload".
+// Parsed command is available from CommandFromContext
+func (dispatcher *Dispatcher) Command(command string, handler telegraph.Handler) *Dispatcher {
+	command = strings.TrimPrefix(command, "/")
+
+	return dispatcher.add(priorityCommand, func(ctx context.Context, update *telegraph.Update) (context.Context, bool) {
+		message := update.Message
+		if message == nil {
+			message = update.ChannelPost
+		}
+		if message == nil {
+			return ctx, false
+		}
+
+		parsed, ok := ParseCommand(message)
+		if !ok || parsed.Name != command {
+			return ctx, false
+		}
+		if parsed.BotName != "" && dispatcher.botName != "" && !strings.EqualFold(parsed.BotName, dispatcher.botName) {
+			return ctx, false
+		}
+
+		return context.WithValue(ctx, commandKey, parsed), true
+	}, handler)
+}
+
+// CallbackPrefix route callback query with data starts with prefix, the data is available from MatchFromContext
+func (dispatcher *Dispatcher) CallbackPrefix(prefix string, handler telegraph.Handler) *Dispatcher {
+	return dispatcher.add(priorityCallback, func(ctx context.Context, update *telegraph.Update) (context.Context, bool) {
+		if update.CallbackQuery == nil || !strings.HasPrefix(update.CallbackQuery.Data, prefix) {
+			return ctx, false
+		}
+		return context.WithValue(ctx, matchKey, []string{update.CallbackQuery.Data}), true
+	}, handler)
+}
+
+// CallbackRegexp route callback query with data matches pattern, submatches are available from MatchFromContext
+func (dispatcher *Dispatcher) CallbackRegexp(pattern *regexp.Regexp, handler telegraph.Handler) *Dispatcher {
+	return dispatcher.add(priorityCallback, func(ctx context.Context, update *telegraph.Update) (context.Context, bool) {
+		if update.CallbackQuery == nil {
+			return ctx, false
+		}
+		return matchRegexp(ctx, pattern, update.CallbackQuery.Data)
+	}, handler)
+}
+
+// InlineQuery route inline query with query matches pattern, submatches are available from MatchFromContext
+func (dispatcher *Dispatcher) InlineQuery(pattern *regexp.Regexp, handler telegraph.Handler) *Dispatcher {
+	return dispatcher.add(priorityInlineQuery, func(ctx context.Context, update *telegraph.Update) (context.Context, bool) {
+		if update.InlineQuery == nil {
+			return ctx, false
+		}
+		return matchRegexp(ctx, pattern, update.InlineQuery.Query)
+	}, handler)
+}
+
+// ChatType route update happened in chat with type, e.g. telegraph.ChatTypePrivate
+func (dispatcher *Dispatcher) ChatType(chatType telegraph.ChatType, handler telegraph.Handler) *Dispatcher {
+	return dispatcher.add(priorityChatType, func(ctx context.Context, update *telegraph.Update) (context.Context, bool) {
+		chat := update.Chat()
+		return ctx, chat != nil && chat.Type == chatType
+	}, handler)
+}
+
+// UpdateType route update with type, e.g. telegraph.UpdateTypeEditedMessage
+func (dispatcher *Dispatcher) UpdateType(updateType telegraph.UpdateType, handler telegraph.Handler) *Dispatcher {
+	return dispatcher.add(priorityUpdateType, func(ctx context.Context, update *telegraph.Update) (context.Context, bool) {
+		return ctx, update.Type() == updateType
+	}, handler)
+}
+
+// Fallback handle update which doesn't match any route, unmatched update is dropped if fallback is not set
+func (dispatcher *Dispatcher) Fallback(handler telegraph.Handler) *Dispatcher {
+	dispatcher.fallback = handler
+	return dispatcher
+}
+
+// HandleUpdate pass update to handler of the first matching route
+func (dispatcher *Dispatcher) HandleUpdate(ctx context.Context, update *telegraph.Update) {
+	for _, routes := range dispatcher.routes {
+		for _, route := range routes {
+			if ctx, ok := route.match(ctx, update); ok {
+				route.handler.HandleUpdate(ctx, update)
+				return
+			}
+		}
+	}
+
+	if dispatcher.fallback != nil {
+		dispatcher.fallback.HandleUpdate(ctx, update)
+	}
+}
+
+func (dispatcher *Dispatcher) add(priority int, match Matcher, handler telegraph.Handler) *Dispatcher {
+	dispatcher.routes[priority] = append(dispatcher.routes[priority], route{match: match, handler: handler})
+	return dispatcher
+}
+
+func matchRegexp(ctx context.Context, pattern *regexp.Regexp, text string) (context.Context, bool) {
+	match := pattern.FindStringSubmatch(text)
+	if match == nil {
+		return ctx, false
+	}
+	return context.WithValue(ctx, matchKey, match), true
+}
+
+// CommandFromContext command parsed by Command route
+func CommandFromContext(ctx context.Context) (Command, bool) {
+	command, ok := ctx.Value(commandKey).(Command)
+	return command, ok
+}
+
+// MatchFromContext callback data or inline query matched by route, with regexp submatches if any
+func MatchFromContext(ctx context.Context) []string {
+	match, _ := ctx.Value(matchKey).([]string)
+	return match
+}
+
+// ParseCommand parse bot command at the beginning of message text or caption, see telegraph.Message.Command
+func ParseCommand(message *telegraph.Message) (Command, bool) {
+	return message.Command()
+}
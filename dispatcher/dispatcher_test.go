@@ -0,0 +1,115 @@
+package dispatcher_test
+
+import (
+	"context"
+	"regexp"
+	"telegraph"
+	"telegraph/dispatcher"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func record(name string, handled *[]string) telegraph.Handler {
+	return telegraph.HandlerFunc(func(ctx context.Context, update *telegraph.Update) {
+		*handled = append(*handled, name)
+	})
+}
+
+func commandMessage(text string, length int) *telegraph.Update {
+	return &telegraph.Update{
+		Message: &telegraph.Message{
+			Text:     text,
+			Chat:     telegraph.Chat{ID: 1, Type: telegraph.ChatTypePrivate},
+			Entities: []telegraph.MessageEntity{{Type: "bot_command", Offset: 0, Length: length}},
+		},
+	}
+}
+
+func TestDispatcher_Command(t *testing.T) {
+	var command dispatcher.Command
+	handled := []string{}
+	d := dispatcher.New().SetBotName("@cubebot").
+		Command("/start", telegraph.HandlerFunc(func(ctx context.Context, update *telegraph.Update) {
+			command, _ = dispatcher.CommandFromContext(ctx)
+			handled = append(handled, "start")
+		})).
+		Fallback(record("fallback", &handled))
+
+	d.HandleUpdate(context.Background(), commandMessage("/start@CubeBot  payload 👋", 14))
+	assert.Equal(t, []string{"start"}, handled)
+	assert.Equal(t, dispatcher.Command{Name: "start", BotName: "CubeBot", Args: "payload 👋"}, command)
+
+	d.HandleUpdate(context.Background(), commandMessage("/start@otherbot", 15))
+	d.HandleUpdate(context.Background(), commandMessage("/stop", 5))
+	assert.Equal(t, []string{"start", "fallback", "fallback"}, handled)
+}
+
+func TestParseCommand_UTF16Caption(t *testing.T) {
+	command, ok := dispatcher.ParseCommand(&telegraph.Message{
+		Caption:         "/echo 😀 hi",
+		CaptionEntities: []telegraph.MessageEntity{{Type: "bot_command", Offset: 0, Length: 5}},
+	})
+	assert.True(t, ok)
+	assert.Equal(t, dispatcher.Command{Name: "echo", Args: "😀 hi"}, command)
+
+	_, ok = dispatcher.ParseCommand(&telegraph.Message{Text: "hello /echo"})
+	assert.False(t, ok)
+}
+
+func TestParseCommand_InvalidEntity(t *testing.T) {
+	for _, length := range []int{0, 1, 6} {
+		_, ok := dispatcher.ParseCommand(&telegraph.Message{
+			Text:     "/echo",
+			Entities: []telegraph.MessageEntity{{Type: "bot_command", Offset: 0, Length: length}},
+		})
+		assert.False(t, ok)
+	}
+}
+
+func TestDispatcher_Callback(t *testing.T) {
+	var match []string
+	handled := []string{}
+	d := dispatcher.New().
+		CallbackRegexp(regexp.MustCompile(`^vote:(\d+)$`), telegraph.HandlerFunc(func(ctx context.Context, update *telegraph.Update) {
+			match = dispatcher.MatchFromContext(ctx)
+			handled = append(handled, "vote")
+		})).
+		CallbackPrefix("page:", record("page", &handled))
+
+	d.HandleUpdate(context.Background(), &telegraph.Update{CallbackQuery: &telegraph.CallbackQuery{Data: "vote:42"}})
+	d.HandleUpdate(context.Background(), &telegraph.Update{CallbackQuery: &telegraph.CallbackQuery{Data: "page:2"}})
+	d.HandleUpdate(context.Background(), &telegraph.Update{CallbackQuery: &telegraph.CallbackQuery{Data: "other"}})
+
+	assert.Equal(t, []string{"vote", "page"}, handled)
+	assert.Equal(t, []string{"vote:42", "42"}, match)
+}
+
+func TestDispatcher_Priority(t *testing.T) {
+	handled := []string{}
+	d := dispatcher.New().
+		UpdateType(telegraph.UpdateTypeMessage, record("message", &handled)).
+		ChatType(telegraph.ChatTypePrivate, record("private", &handled)).
+		Command("help", record("help", &handled)).
+		InlineQuery(regexp.MustCompile(`^gif`), record("inline", &handled))
+
+	d.HandleUpdate(context.Background(), commandMessage("/help", 5))
+	d.HandleUpdate(context.Background(), &telegraph.Update{Message: &telegraph.Message{Text: "hi", Chat: telegraph.Chat{Type: telegraph.ChatTypePrivate}}})
+	d.HandleUpdate(context.Background(), &telegraph.Update{Message: &telegraph.Message{Text: "hi", Chat: telegraph.Chat{Type: telegraph.ChatTypeGroup}}})
+	d.HandleUpdate(context.Background(), &telegraph.Update{InlineQuery: &telegraph.InlineQuery{Query: "gif cat"}})
+	d.HandleUpdate(context.Background(), &telegraph.Update{InlineQuery: &telegraph.InlineQuery{Query: "cat"}})
+
+	assert.Equal(t, []string{"help", "private", "message", "inline"}, handled)
+}
+
+func TestDispatcher_Match(t *testing.T) {
+	handled := []string{}
+	d := dispatcher.New().
+		Command("start", record("start", &handled)).
+		Match(func(ctx context.Context, update *telegraph.Update) (context.Context, bool) {
+			return ctx, update.Message != nil && update.Message.Chat.ID == 1
+		}, record("custom", &handled))
+
+	d.HandleUpdate(context.Background(), commandMessage("/start", 6))
+	assert.Equal(t, []string{"custom"}, handled)
+}
@@ -0,0 +1,246 @@
+package telegraph
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrDownloadSizeMismatch returned when downloaded content size is different from File.FileSize
+	ErrDownloadSizeMismatch = errors.New("telegraph: downloaded size doesn't match file size")
+
+	// ErrChecksumMismatch returned when checksum of downloaded content is different from the expected one
+	ErrChecksumMismatch = errors.New("telegraph: downloaded checksum doesn't match")
+)
+
+// maxLinkRefresh number of times expired file link is refreshed in a row without receiving any content
+const maxLinkRefresh = 2
+
+type (
+	// DownloadResponse struct to handle file download from telegram
+	DownloadResponse struct {
+		Client      *Client
+		fileID      string
+		writer      io.Writer
+		path        string
+		hash        hash.Hash
+		checksum    []byte
+		progress    func(received, total int64)
+		retryPolicy RetryPolicy
+	}
+)
+
+/*
+DownloadFile Download file from telegram and stream its content to writer. The file path is resolved with GetFile,
+the download is resumed with Range request when connection is broken and the file link is refreshed when it expires.
+The downloaded size is verified against File.FileSize. On success, the File object is returned.
++ fileId - File identifier to download
++ writer - Destination of file content
+
+Available method can used with this method
++ SetChecksum()
++ SetProgress()
++ SetRetryPolicy()
+*/
+func (client *Client) DownloadFile(fileId string, writer io.Writer) *DownloadResponse {
+	return &DownloadResponse{
+		Client: client,
+		fileID: fileId,
+		writer: writer,
+	}
+}
+
+/*
+DownloadFileToPath Download file from telegram to local path, the same as DownloadFile.
+Content already in the path is kept and the download continue from its end, so failed download can be resumed by committing again.
++ fileId - File identifier to download
++ path - Local file path
+
+Available method can used with this method
++ SetChecksum()
++ SetProgress()
++ SetRetryPolicy()
+*/
+func (client *Client) DownloadFileToPath(fileId string, path string) *DownloadResponse {
+	return &DownloadResponse{
+		Client: client,
+		fileID: fileId,
+		path:   path,
+	}
+}
+
+// SetChecksum verify downloaded content is hashed by h into sum, e.g. sha256.New() and the expected digest
+func (download *DownloadResponse) SetChecksum(h hash.Hash, sum []byte) *DownloadResponse {
+	download.hash = h
+	download.checksum = sum
+
+	return download
+}
+
+// SetProgress call fn while the file is downloaded with bytes received so far and total size, total is -1 when size is unknown
+func (download *DownloadResponse) SetProgress(fn func(received, total int64)) *DownloadResponse {
+	download.progress = fn
+
+	return download
+}
+
+// SetRetryPolicy decide how the download is retried when it fails, override retry policy of the client
+func (download *DownloadResponse) SetRetryPolicy(policy RetryPolicy) *DownloadResponse {
+	download.retryPolicy = policy
+
+	return download
+}
+
+// Commit execute download from telegram
+func (download *DownloadResponse) Commit() (*File, *http.Response, error) {
+	return download.CommitContext(context.Background())
+}
+
+// CommitContext execute download from telegram, the download and its back off retry are aborted when ctx is done
+func (download *DownloadResponse) CommitContext(ctx context.Context) (*File, *http.Response, error) {
+	writer, closeWriter, offset, err := download.open()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer closeWriter()
+
+	policy := download.retryPolicy
+	if policy == nil {
+		policy = download.Client.retryPolicy
+	}
+
+	file, res, err := download.Client.GetFile(download.fileID).SetRetryPolicy(policy).CommitContext(ctx)
+	if err != nil {
+		return nil, res, err
+	}
+	total := int64(file.FileSize)
+	if total == 0 {
+		total = -1
+	}
+	if download.hash != nil {
+		writer = io.MultiWriter(writer, download.hash)
+	}
+
+	start, refreshed := time.Now(), 0
+	for attempt := 1; total < 0 || offset < total; attempt++ {
+		var received int64
+		received, res, err = download.fetch(ctx, file.FilePath, writer, offset, total)
+		offset += received
+		if err == nil {
+			break
+		}
+		if ctx.Err() != nil {
+			return nil, res, ctx.Err()
+		}
+		if received > 0 {
+			refreshed = 0
+		}
+
+		if IsNotFound(err) && refreshed < maxLinkRefresh {
+			refreshed++
+			refresh, refreshRes, refreshErr := download.Client.GetFile(download.fileID).SetRetryPolicy(policy).CommitContext(ctx)
+			if refreshErr != nil {
+				return nil, refreshRes, refreshErr
+			}
+			file = refresh
+			continue
+		}
+
+		wait, retry := policy.Retry(attempt, time.Since(start), err)
+		if !retry {
+			return nil, res, err
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, res, ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	if total >= 0 && offset != total {
+		return nil, res, fmt.Errorf("%w: received %d bytes, file size is %d bytes", ErrDownloadSizeMismatch, offset, total)
+	}
+	if download.hash != nil && !bytes.Equal(download.hash.Sum(nil), download.checksum) {
+		return nil, res, ErrChecksumMismatch
+	}
+
+	return file, res, nil
+}
+
+// open return destination of the download, func closing it and number of bytes already downloaded to it.
+// Writer given by caller is never closed, checksum is reset so committing again starts a fresh hash
+func (download *DownloadResponse) open() (io.Writer, func() error, int64, error) {
+	if download.hash != nil {
+		download.hash.Reset()
+	}
+	if download.path == "" {
+		return download.writer, func() error { return nil }, 0, nil
+	}
+
+	file, err := os.OpenFile(download.path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	// Content already downloaded is hashed so checksum cover the whole file
+	var offset int64
+	if download.hash != nil {
+		offset, err = io.Copy(download.hash, file)
+	} else {
+		offset, err = file.Seek(0, io.SeekEnd)
+	}
+	if err != nil {
+		file.Close()
+		return nil, nil, 0, err
+	}
+
+	return file, file.Close, offset, nil
+}
+
+// fetch download file content from offset to writer, returning number of bytes written
+func (download *DownloadResponse) fetch(ctx context.Context, path string, writer io.Writer, offset, total int64) (int64, *http.Response, error) {
+	endpoint := download.Client.baseURL + fmt.Sprintf(EndpointGetContent, download.Client.accessToken, path)
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return 0, nil, err
+	}
+	req.Header.Set(UserAgentHeader, download.Client.userAgent)
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	res, err := download.Client.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return 0, nil, err
+	}
+	defer res.Body.Close()
+
+	switch {
+	case res.StatusCode == http.StatusPartialContent && strings.HasPrefix(res.Header.Get("Content-Range"), fmt.Sprintf("bytes %d-", offset)):
+	case res.StatusCode == http.StatusOK:
+		// Range is ignored by server, skip content already written
+		if _, err := io.CopyN(ioutil.Discard, res.Body, offset); err != nil {
+			return 0, res, err
+		}
+	case res.StatusCode == http.StatusPartialContent:
+		return 0, res, fmt.Errorf("telegraph: unexpected content range %q", res.Header.Get("Content-Range"))
+	default:
+		body, _ := ioutil.ReadAll(res.Body)
+		return 0, res, newAPIError(res, body)
+	}
+
+	received, err := io.Copy(writer, &progressReader{reader: res.Body, sent: offset, total: total, progress: download.progress})
+	return received, res, err
+}
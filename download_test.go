@@ -0,0 +1,197 @@
+package telegraph_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"telegraph"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var downloadContent = bytes.Repeat([]byte("telegraph download "), 4096)
+
+// fakeFileServer serve getFile and file content, the file path returned by getFile is paths[n] for the n-th call
+type fakeFileServer struct {
+	mu        sync.Mutex
+	paths     []string
+	getFile   int
+	size      int
+	ranges    []string
+	interrupt bool
+}
+
+func (server *fakeFileServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	if r.URL.Path == fmt.Sprintf(telegraph.EndpointGetFile, "token") {
+		path := server.paths[len(server.paths)-1]
+		if server.getFile < len(server.paths) {
+			path = server.paths[server.getFile]
+		}
+		server.getFile++
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"ok": true, "result": {"file_id": "file_1", "file_size": %d, "file_path": %q}}`, server.size, path)
+		return
+	}
+
+	server.ranges = append(server.ranges, r.Header.Get("Range"))
+	if r.URL.Path != fmt.Sprintf(telegraph.EndpointGetContent, "token", server.paths[len(server.paths)-1]) {
+		http.NotFound(w, r)
+		return
+	}
+	if server.interrupt {
+		server.interrupt = false
+		w.Header().Set("Content-Length", fmt.Sprint(len(downloadContent)))
+		w.Write(downloadContent[:len(downloadContent)/2])
+		w.(http.Flusher).Flush()
+		panic(http.ErrAbortHandler)
+	}
+
+	http.ServeContent(w, r, "file", time.Time{}, bytes.NewReader(downloadContent))
+}
+
+func newDownloadClient(server *fakeFileServer) (*telegraph.Client, func()) {
+	ts := httptest.NewServer(server)
+	retry := telegraph.RetryPolicyFunc(func(attempt int, elapsed time.Duration, err error) (time.Duration, bool) {
+		return time.Millisecond, attempt < 3
+	})
+	return telegraph.NewClient("token", telegraph.WithBaseURL(ts.URL), telegraph.WithRetryPolicy(retry)), ts.Close
+}
+
+func TestDownloadFile_Success(t *testing.T) {
+	server := &fakeFileServer{paths: []string{"documents/file_1.txt"}, size: len(downloadContent)}
+	client, closeServer := newDownloadClient(server)
+	defer closeServer()
+
+	sum := sha256.Sum256(downloadContent)
+	var received, total int64
+	buffer := &bytes.Buffer{}
+	file, res, err := client.DownloadFile("file_1", buffer).SetChecksum(sha256.New(), sum[:]).
+		SetProgress(func(r, t int64) { received, total = r, t }).Commit()
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "documents/file_1.txt", file.FilePath)
+	assert.Equal(t, downloadContent, buffer.Bytes())
+	assert.Equal(t, int64(len(downloadContent)), received)
+	assert.Equal(t, int64(len(downloadContent)), total)
+}
+
+func TestDownloadFile_ResumeInterrupted(t *testing.T) {
+	server := &fakeFileServer{paths: []string{"documents/file_1.txt"}, size: len(downloadContent), interrupt: true}
+	client, closeServer := newDownloadClient(server)
+	defer closeServer()
+
+	buffer := &bytes.Buffer{}
+	_, res, err := client.DownloadFile("file_1", buffer).Commit()
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusPartialContent, res.StatusCode)
+	assert.Equal(t, downloadContent, buffer.Bytes())
+	assert.Equal(t, []string{"", fmt.Sprintf("bytes=%d-", len(downloadContent)/2)}, server.ranges)
+}
+
+func TestDownloadFile_RefreshExpiredLink(t *testing.T) {
+	server := &fakeFileServer{paths: []string{"documents/expired.txt", "documents/file_1.txt"}, size: len(downloadContent)}
+	client, closeServer := newDownloadClient(server)
+	defer closeServer()
+
+	buffer := &bytes.Buffer{}
+	file, _, err := client.DownloadFile("file_1", buffer).SetRetryPolicy(telegraph.NoRetry).Commit()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "documents/file_1.txt", file.FilePath)
+	assert.Equal(t, 2, server.getFile)
+	assert.Equal(t, downloadContent, buffer.Bytes())
+}
+
+func TestDownloadFileToPath_Resume(t *testing.T) {
+	server := &fakeFileServer{paths: []string{"documents/file_1.txt"}, size: len(downloadContent)}
+	client, closeServer := newDownloadClient(server)
+	defer closeServer()
+
+	path := filepath.Join(t.TempDir(), "file_1.txt")
+	assert.NoError(t, ioutil.WriteFile(path, downloadContent[:1000], 0644))
+
+	sum := sha256.Sum256(downloadContent)
+	_, _, err := client.DownloadFileToPath("file_1", path).SetChecksum(sha256.New(), sum[:]).Commit()
+	assert.NoError(t, err)
+
+	content, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, downloadContent, content)
+	assert.Equal(t, []string{"bytes=1000-"}, server.ranges)
+}
+
+func TestDownloadFileToPath_CommitAgain(t *testing.T) {
+	server := &fakeFileServer{paths: []string{"documents/file_1.txt"}, size: len(downloadContent), interrupt: true}
+	client, closeServer := newDownloadClient(server)
+	defer closeServer()
+
+	path := filepath.Join(t.TempDir(), "file_1.txt")
+	sum := sha256.Sum256(downloadContent)
+	download := client.DownloadFileToPath("file_1", path).SetChecksum(sha256.New(), sum[:]).SetRetryPolicy(telegraph.NoRetry)
+
+	_, _, err := download.Commit()
+	assert.Error(t, err)
+
+	_, _, err = download.Commit()
+	assert.NoError(t, err)
+
+	content, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, downloadContent, content)
+}
+
+func TestDownloadFile_WriterKeptOpen(t *testing.T) {
+	server := &fakeFileServer{paths: []string{"documents/file_1.txt"}, size: len(downloadContent)}
+	client, closeServer := newDownloadClient(server)
+	defer closeServer()
+
+	file, err := os.Create(filepath.Join(t.TempDir(), "file_1.txt"))
+	assert.NoError(t, err)
+
+	_, _, err = client.DownloadFile("file_1", file).Commit()
+	assert.NoError(t, err)
+
+	_, err = file.WriteString("trailer")
+	assert.NoError(t, err)
+	assert.NoError(t, file.Close())
+}
+
+func TestDownloadFile_Verification(t *testing.T) {
+	server := &fakeFileServer{paths: []string{"documents/file_1.txt"}, size: len(downloadContent) + 1}
+	client, closeServer := newDownloadClient(server)
+	defer closeServer()
+
+	_, _, err := client.DownloadFile("file_1", ioutil.Discard).SetRetryPolicy(telegraph.NoRetry).Commit()
+	assert.True(t, errors.Is(err, telegraph.ErrDownloadSizeMismatch))
+
+	server.size = len(downloadContent)
+	_, _, err = client.DownloadFile("file_1", ioutil.Discard).SetChecksum(sha256.New(), []byte("invalid")).Commit()
+	assert.Equal(t, telegraph.ErrChecksumMismatch, err)
+}
+
+func TestDownloadFile_NotFound(t *testing.T) {
+	// Content is only served from the last path, refreshed link keep expiring
+	server := &fakeFileServer{paths: []string{"documents/a.txt", "documents/b.txt", "documents/c.txt", "documents/d.txt"}}
+	client, closeServer := newDownloadClient(server)
+	defer closeServer()
+
+	_, res, err := client.DownloadFile("file_1", ioutil.Discard).SetRetryPolicy(telegraph.NoRetry).Commit()
+
+	assert.True(t, telegraph.IsNotFound(err))
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	assert.Equal(t, 3, server.getFile)
+}
@@ -0,0 +1,181 @@
+package telegraph
+
+import (
+	"strings"
+	"unicode/utf16"
+)
+
+type (
+	// EntityValue entity of message text or caption with the text it covers
+	EntityValue struct {
+		MessageEntity
+		Text string
+		// Caption true when the entity belongs to the caption
+		Caption bool
+	}
+
+	// BotCommand bot command with its arguments, e.g. /start@cubebot payload
+	BotCommand struct {
+		Name    string
+		BotName string
+		Args    string
+	}
+)
+
+// Extract text covered by entity, offset and length of entity are counted in UTF-16 code units.
+// Entity out of text range is cut to the text
+func (entity MessageEntity) Extract(text string) string {
+	return string(utf16.Decode(sliceUTF16(utf16.Encode([]rune(text)), entity.Offset, entity.Offset+entity.Length)))
+}
+
+// AllEntities entities of message text followed by entities of caption, with the text they cover
+func (message *Message) AllEntities() []EntityValue {
+	values := entityValues(message.Text, message.Entities, false)
+	return append(values, entityValues(message.Caption, message.CaptionEntities, true)...)
+}
+
+// EntitiesOfType entities of message text and caption with one of types
+func (message *Message) EntitiesOfType(types ...string) []EntityValue {
+	values := []EntityValue{}
+	for _, value := range message.AllEntities() {
+		for _, entityType := range types {
+			if value.Type == entityType {
+				values = append(values, value)
+				break
+			}
+		}
+	}
+
+	return values
+}
+
+// Commands bot commands in message text and caption, arguments of a command is the text until the next command
+func (message *Message) Commands() []BotCommand {
+	return append(botCommands(message.Text, message.Entities, false), botCommands(message.Caption, message.CaptionEntities, false)...)
+}
+
+// Command bot command at the beginning of message text, or of caption when message has no text
+func (message *Message) Command() (BotCommand, bool) {
+	text, entities := message.Text, message.Entities
+	if text == "" {
+		text, entities = message.Caption, message.CaptionEntities
+	}
+
+	commands := botCommands(text, entities, true)
+	if len(commands) == 0 {
+		return BotCommand{}, false
+	}
+	return commands[0], true
+}
+
+// botCommands parse bot_command entities of text, only the command at offset 0 when leading is true.
+// Entity out of text range or without command name is skipped
+func botCommands(text string, entities []MessageEntity, leading bool) []BotCommand {
+	commands := []BotCommand{}
+	encoded := utf16.Encode([]rune(text))
+	for i, entity := range entities {
+		if entity.Type != EntityTypeBotCommand || (leading && entity.Offset != 0) {
+			continue
+		}
+		if entity.Offset < 0 || entity.Length < 2 || entity.Offset+entity.Length > len(encoded) {
+			continue
+		}
+
+		end := len(encoded)
+		for _, next := range entities[i+1:] {
+			if next.Type == EntityTypeBotCommand && next.Offset >= entity.Offset+entity.Length {
+				end = next.Offset
+				break
+			}
+		}
+
+		command := strings.TrimPrefix(string(utf16.Decode(sliceUTF16(encoded, entity.Offset, entity.Offset+entity.Length))), "/")
+		args := string(utf16.Decode(sliceUTF16(encoded, entity.Offset+entity.Length, end)))
+
+		name, botName := command, ""
+		if at := strings.Index(command, "@"); at >= 0 {
+			name, botName = command[:at], command[at+1:]
+		}
+		if name == "" {
+			continue
+		}
+		commands = append(commands, BotCommand{Name: name, BotName: botName, Args: strings.TrimSpace(args)})
+	}
+
+	return commands
+}
+
+// Mentions usernames mentioned in message text and caption, including @
+func (message *Message) Mentions() []string {
+	return message.entityTexts(EntityTypeMention)
+}
+
+// Hashtags hashtags in message text and caption, including #
+func (message *Message) Hashtags() []string {
+	return message.entityTexts(EntityTypeHashtag)
+}
+
+// Cashtags cashtags in message text and caption, including $
+func (message *Message) Cashtags() []string {
+	return message.entityTexts(EntityTypeCashtag)
+}
+
+// URLs urls written in message text and caption, see TextLinks for urls behind a text
+func (message *Message) URLs() []string {
+	return message.entityTexts(EntityTypeURL)
+}
+
+// TextLinks clickable texts of message text and caption, url of the link is in URL of the entity
+func (message *Message) TextLinks() []EntityValue {
+	return message.EntitiesOfType(EntityTypeTextLink)
+}
+
+// TextMentions users mentioned in message text and caption without username
+func (message *Message) TextMentions() []User {
+	users := []User{}
+	for _, value := range message.EntitiesOfType(EntityTypeTextMention) {
+		if value.User != nil {
+			users = append(users, *value.User)
+		}
+	}
+
+	return users
+}
+
+func (message *Message) entityTexts(entityType string) []string {
+	texts := []string{}
+	for _, value := range message.EntitiesOfType(entityType) {
+		texts = append(texts, value.Text)
+	}
+
+	return texts
+}
+
+func entityValues(text string, entities []MessageEntity, caption bool) []EntityValue {
+	encoded := utf16.Encode([]rune(text))
+	values := make([]EntityValue, 0, len(entities))
+	for _, entity := range entities {
+		values = append(values, EntityValue{
+			MessageEntity: entity,
+			Text:          string(utf16.Decode(sliceUTF16(encoded, entity.Offset, entity.Offset+entity.Length))),
+			Caption:       caption,
+		})
+	}
+
+	return values
+}
+
+// sliceUTF16 code units between start and end, cut to the range of units
+func sliceUTF16(units []uint16, start, end int) []uint16 {
+	if start < 0 {
+		start = 0
+	}
+	if end > len(units) {
+		end = len(units)
+	}
+	if start >= end {
+		return nil
+	}
+
+	return units[start:end]
+}
@@ -0,0 +1,97 @@
+package telegraph_test
+
+import (
+	"telegraph"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessageEntity_Extract(t *testing.T) {
+	// 👍 and 😀 are two UTF-16 code units
+	text := "👍 hi 😀 #go"
+
+	assert.Equal(t, "hi", telegraph.MessageEntity{Offset: 3, Length: 2}.Extract(text))
+	assert.Equal(t, "😀", telegraph.MessageEntity{Offset: 6, Length: 2}.Extract(text))
+	assert.Equal(t, "#go", telegraph.MessageEntity{Offset: 9, Length: 10}.Extract(text))
+	assert.Equal(t, "", telegraph.MessageEntity{Offset: 20, Length: 2}.Extract(text))
+}
+
+func TestMessage_Entities(t *testing.T) {
+	jane := &telegraph.User{ID: 42, FirstName: "Jane"}
+	message := &telegraph.Message{
+		Text: "😀 @cube #go $IDR https://example.com docs Jane",
+		Entities: []telegraph.MessageEntity{
+			{Type: telegraph.EntityTypeMention, Offset: 3, Length: 5},
+			{Type: telegraph.EntityTypeHashtag, Offset: 9, Length: 3},
+			{Type: telegraph.EntityTypeCashtag, Offset: 13, Length: 4},
+			{Type: telegraph.EntityTypeURL, Offset: 18, Length: 19},
+			{Type: telegraph.EntityTypeTextLink, Offset: 38, Length: 4, URL: "https://core.telegram.org"},
+			{Type: telegraph.EntityTypeTextMention, Offset: 43, Length: 4, User: jane},
+		},
+		Caption:         "🎉 #party",
+		CaptionEntities: []telegraph.MessageEntity{{Type: telegraph.EntityTypeHashtag, Offset: 3, Length: 6}},
+	}
+
+	assert.Equal(t, []string{"@cube"}, message.Mentions())
+	assert.Equal(t, []string{"#go", "#party"}, message.Hashtags())
+	assert.Equal(t, []string{"$IDR"}, message.Cashtags())
+	assert.Equal(t, []string{"https://example.com"}, message.URLs())
+	assert.Equal(t, []telegraph.User{*jane}, message.TextMentions())
+
+	links := message.TextLinks()
+	assert.Len(t, links, 1)
+	assert.Equal(t, "docs", links[0].Text)
+	assert.Equal(t, "https://core.telegram.org", links[0].URL)
+
+	all := message.AllEntities()
+	assert.Len(t, all, 7)
+	assert.True(t, all[6].Caption)
+	assert.False(t, all[0].Caption)
+}
+
+func TestMessage_Commands(t *testing.T) {
+	message := &telegraph.Message{
+		Text: "/start@cubebot 😀 payload /help me",
+		Entities: []telegraph.MessageEntity{
+			{Type: telegraph.EntityTypeBotCommand, Offset: 0, Length: 14},
+			{Type: telegraph.EntityTypeBotCommand, Offset: 26, Length: 5},
+		},
+	}
+
+	assert.Equal(t, []telegraph.BotCommand{
+		{Name: "start", BotName: "cubebot", Args: "😀 payload"},
+		{Name: "help", Args: "me"},
+	}, message.Commands())
+
+	caption := &telegraph.Message{
+		Caption:         "/photo",
+		CaptionEntities: []telegraph.MessageEntity{{Type: telegraph.EntityTypeBotCommand, Offset: 0, Length: 6}},
+	}
+	assert.Equal(t, []telegraph.BotCommand{{Name: "photo"}}, caption.Commands())
+	assert.Empty(t, (&telegraph.Message{Text: "hi"}).Commands())
+}
+
+func TestMessage_Command(t *testing.T) {
+	message := &telegraph.Message{
+		Text: "hi /help me",
+		Entities: []telegraph.MessageEntity{
+			{Type: telegraph.EntityTypeBotCommand, Offset: 3, Length: 5},
+		},
+	}
+	_, ok := message.Command()
+	assert.False(t, ok)
+	assert.Len(t, message.Commands(), 1)
+
+	message.Text = "/help me"
+	message.Entities[0].Offset = 0
+	command, ok := message.Command()
+	assert.True(t, ok)
+	assert.Equal(t, telegraph.BotCommand{Name: "help", Args: "me"}, command)
+
+	for _, entity := range []telegraph.MessageEntity{{Offset: 0, Length: 0}, {Offset: 0, Length: 1}, {Offset: -1, Length: 5}, {Offset: 4, Length: 9}} {
+		entity.Type = telegraph.EntityTypeBotCommand
+		invalid := &telegraph.Message{Text: "/help me", Entities: []telegraph.MessageEntity{entity}}
+		assert.Empty(t, invalid.Commands())
+	}
+}
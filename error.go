@@ -0,0 +1,112 @@
+package telegraph
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// APIError error returned by telegram api when request is unsuccessful,
+// use errors.As to get it from the error returned by Commit
+type APIError struct {
+	ErrorCode   int
+	Description string
+	Parameters  *ResponseParameters
+}
+
+// DecodeError successful response from telegram which can't be decoded. Telegram has already processed the request,
+// so it's never retried by BackOffRetryPolicy to avoid e.g. sending the same message twice
+type DecodeError struct {
+	Body []byte
+	Err  error
+}
+
+// newAPIError decode error response body from telegram,
+// http status is used as error code if body is not a valid telegram error response
+func newAPIError(res *http.Response, body []byte) *APIError {
+	model := ErrorResponse{}
+	if err := json.Unmarshal(body, &model); err != nil || model.ErrorCode == 0 {
+		model.ErrorCode = res.StatusCode
+		model.Description = http.StatusText(res.StatusCode)
+	}
+
+	return &APIError{
+		ErrorCode:   model.ErrorCode,
+		Description: model.Description,
+		Parameters:  model.Parameters,
+	}
+}
+
+// Error format error code and description from telegram
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%v %v", e.ErrorCode, e.Description)
+}
+
+// Error describe why response can't be decoded
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("decode response: %v", e.Err)
+}
+
+// Unwrap return the json error
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// RetryAfter duration to wait before the request can be repeated when flood control exceeded, zero if not given
+func (e *APIError) RetryAfter() time.Duration {
+	if e.Parameters == nil {
+		return 0
+	}
+	return time.Duration(e.Parameters.RetryAfter) * time.Second
+}
+
+// MigrateToChatID identifier of supergroup the group has been migrated to, zero if not migrated
+func (e *APIError) MigrateToChatID() int64 {
+	if e.Parameters == nil {
+		return 0
+	}
+	return e.Parameters.MigrateToChatID
+}
+
+// IsBadRequest report whether err is telegram error with code 400
+func IsBadRequest(err error) bool {
+	return hasErrorCode(err, http.StatusBadRequest)
+}
+
+// IsUnauthorized report whether err is telegram error with code 401, usually the access token is invalid
+func IsUnauthorized(err error) bool {
+	return hasErrorCode(err, http.StatusUnauthorized)
+}
+
+// IsForbidden report whether err is telegram error with code 403, e.g. the bot was blocked by the user or kicked from the chat
+func IsForbidden(err error) bool {
+	return hasErrorCode(err, http.StatusForbidden)
+}
+
+// IsNotFound report whether err is telegram error with code 404
+func IsNotFound(err error) bool {
+	return hasErrorCode(err, http.StatusNotFound)
+}
+
+// IsConflict report whether err is telegram error with code 409, e.g. getUpdates while webhook is set or another poller is running
+func IsConflict(err error) bool {
+	return hasErrorCode(err, http.StatusConflict)
+}
+
+// IsTooManyRequests report whether err is telegram error with code 429, wait APIError.RetryAfter before repeating the request
+func IsTooManyRequests(err error) bool {
+	return hasErrorCode(err, http.StatusTooManyRequests)
+}
+
+// IsChatMigrated report whether err is caused by group migrated to supergroup, new chat id is in APIError.MigrateToChatID
+func IsChatMigrated(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.MigrateToChatID() != 0
+}
+
+func hasErrorCode(err error, code int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.ErrorCode == code
+}
@@ -0,0 +1,115 @@
+package telegraph_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"telegraph"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestAPIError_VoidResponse(t *testing.T) {
+	gock.New(telegraph.BaseURL).Post(fmt.Sprintf(telegraph.EndpointSendChatAction, "token")).Reply(http.StatusForbidden).JSON(`{
+		"ok": false,
+		"error_code": 403,
+		"description": "Forbidden: bot was blocked by the user"
+	}`)
+	defer gock.Off()
+
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
+
+	body, res, err := client.SendChatAction(2434234, "typing").Commit()
+
+	var apiErr *telegraph.APIError
+	assert.Nil(t, body)
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, 403, apiErr.ErrorCode)
+	assert.Equal(t, "Forbidden: bot was blocked by the user", apiErr.Description)
+	assert.EqualError(t, err, "403 Forbidden: bot was blocked by the user")
+	assert.True(t, telegraph.IsForbidden(err))
+	assert.False(t, telegraph.IsTooManyRequests(err))
+	assert.False(t, telegraph.IsChatMigrated(err))
+}
+
+func TestAPIError_TooManyRequests(t *testing.T) {
+	gock.New(telegraph.BaseURL).Post(fmt.Sprintf(telegraph.EndpointSendMessage, "token")).Reply(http.StatusTooManyRequests).JSON(`{
+		"ok": false,
+		"error_code": 429,
+		"description": "Too Many Requests: retry after 5",
+		"parameters": {
+			"retry_after": 5
+		}
+	}`)
+	defer gock.Off()
+
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
+
+	message, res, err := client.SendMessage(2434234, "test").Commit()
+
+	var apiErr *telegraph.APIError
+	assert.Nil(t, message)
+	assert.Equal(t, http.StatusTooManyRequests, res.StatusCode)
+	assert.True(t, telegraph.IsTooManyRequests(err))
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, 5*time.Second, apiErr.RetryAfter())
+	assert.Equal(t, int64(0), apiErr.MigrateToChatID())
+}
+
+func TestAPIError_ChatMigrated(t *testing.T) {
+	gock.New(telegraph.BaseURL).Post(fmt.Sprintf(telegraph.EndpointSendMessage, "token")).Reply(http.StatusBadRequest).JSON(`{
+		"ok": false,
+		"error_code": 400,
+		"description": "Bad Request: group chat was upgraded to a supergroup chat",
+		"parameters": {
+			"migrate_to_chat_id": -1001234567890
+		}
+	}`)
+	defer gock.Off()
+
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
+
+	message, res, err := client.SendMessage(-2434234, "test").Commit()
+
+	var apiErr *telegraph.APIError
+	assert.Nil(t, message)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	assert.True(t, telegraph.IsBadRequest(err))
+	assert.True(t, telegraph.IsChatMigrated(err))
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, int64(-1001234567890), apiErr.MigrateToChatID())
+	assert.Equal(t, time.Duration(0), apiErr.RetryAfter())
+}
+
+func TestAPIError_InvalidBody(t *testing.T) {
+	gock.New(telegraph.BaseURL).Get(fmt.Sprintf(telegraph.EndpointGetMe, "token")).Reply(http.StatusBadGateway).BodyString("<html></html>")
+	defer gock.Off()
+
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
+
+	user, res, err := client.GetMe().Commit()
+
+	var apiErr *telegraph.APIError
+	assert.Nil(t, user)
+	assert.Equal(t, http.StatusBadGateway, res.StatusCode)
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusBadGateway, apiErr.ErrorCode)
+	assert.Equal(t, http.StatusText(http.StatusBadGateway), apiErr.Description)
+	assert.Nil(t, apiErr.Parameters)
+}
+
+func TestAPIError_NotAPIError(t *testing.T) {
+	err := errors.New("connection refused")
+
+	assert.False(t, telegraph.IsBadRequest(err))
+	assert.False(t, telegraph.IsUnauthorized(err))
+	assert.False(t, telegraph.IsForbidden(err))
+	assert.False(t, telegraph.IsNotFound(err))
+	assert.False(t, telegraph.IsConflict(err))
+	assert.False(t, telegraph.IsTooManyRequests(err))
+	assert.False(t, telegraph.IsChatMigrated(err))
+}
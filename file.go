@@ -1,19 +1,20 @@
 package telegraph
 
 import (
+	"context"
 	"fmt"
 
 	"net/http"
 
-	"github.com/cenkalti/backoff"
 	"github.com/parnurzeal/gorequest"
 )
 
 type (
 	// FileResponse struct to handle request and response telegram api
 	FileResponse struct {
-		Client  *Client
-		Request *gorequest.SuperAgent
+		Client      *Client
+		Request     *gorequest.SuperAgent
+		retryPolicy RetryPolicy
 	}
 )
 
@@ -28,7 +29,7 @@ This function only return json value not file, download file use function GetCon
 */
 func (client *Client) GetFile(fileId string) *FileResponse {
 	url := client.baseURL + fmt.Sprintf(EndpointGetFile, client.accessToken)
-	request := gorequest.New().Get(url).Set(UserAgentHeader, UserAgent+"/"+Version).Query(fmt.Sprintf("file_id=%v", fileId))
+	request := gorequest.New().Get(url).Set(UserAgentHeader, client.userAgent).Query(fmt.Sprintf("file_id=%v", fileId))
 
 	return &FileResponse{
 		Client:  client,
@@ -41,14 +42,15 @@ UploadStickerFile Use this method to upload a .png file with a sticker for later
 Returns the uploaded File on success.
 + userId - User identifier of sticker file owner
 + pngSticker - Png image with the sticker, must be up to 512 kilobytes in size, dimensions must not exceed 512px, and either width or height must be exactly 512px.
+  Upload it with FilePath, FileReader or FileBytes.
 */
-func (client *Client) UploadStickerFile(userId int64, pngSticker string) *FileResponse {
+func (client *Client) UploadStickerFile(userId int64, pngSticker *InputFile) *FileResponse {
 	body := JSON{
 		"user_id": userId,
 	}
 	url := client.baseURL + fmt.Sprintf(EndpointUploadStickerFile, client.accessToken)
-	request := gorequest.New().Post(url).Set(UserAgentHeader, UserAgent+"/"+Version).Send(body).Type(gorequest.TypeMultipart).
-		SendFile(pngSticker, "", "png_sticker")
+	request := gorequest.New().Post(url).Set(UserAgentHeader, client.userAgent).Send(body).Type(gorequest.TypeMultipart)
+	request = pngSticker.attach(request, "png_sticker")
 
 	return &FileResponse{
 		Client:  client,
@@ -56,28 +58,27 @@ func (client *Client) UploadStickerFile(userId int64, pngSticker string) *FileRe
 	}
 }
 
+// SetRetryPolicy decide how this request is retried when it fails, override retry policy of the client
+func (user *FileResponse) SetRetryPolicy(policy RetryPolicy) *FileResponse {
+	user.retryPolicy = policy
+
+	return user
+}
+
 // Commit execute request to telegram
 func (user *FileResponse) Commit() (*File, *http.Response, error) {
-	var errs []error
-	res := &http.Response{}
+	return user.CommitContext(context.Background())
+}
+
+// CommitContext execute request to telegram, the request and its back off retry are aborted when ctx is done
+func (user *FileResponse) CommitContext(ctx context.Context) (*File, *http.Response, error) {
 	model := struct {
-		ErrorResponse
 		Result *File `json:"result,omitempty"`
 	}{}
 
-	operation := func() error {
-		res, _, errs = user.Request.EndStruct(&model)
-		if len(errs) > 0 {
-			return errs[0]
-		}
-		return nil
-	}
-
-	if err := backoff.Retry(operation, user.Client.expBackOff); err != nil {
-		return nil, MakeHTTPResponse(user.Request), err
-	}
-	if res.StatusCode != http.StatusOK {
-		return nil, res, fmt.Errorf("%v %v", model.ErrorCode, model.Description)
+	_, res, err := user.Client.commit(ctx, user.Request, user.retryPolicy, &model)
+	if err != nil {
+		return nil, res, err
 	}
 
 	return model.Result, res, nil
@@ -34,7 +34,7 @@ func TestGetFile_Error(t *testing.T) {
 	gock.New(telegraph.BaseURL).Head(fmt.Sprintf(telegraph.EndpointGetFile, "token")).Reply(http.StatusInternalServerError).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 
 	model, res, err := client.GetFile("33242342").Commit()
 
@@ -51,7 +51,7 @@ func TestGetFile_Failed(t *testing.T) {
 	}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 
 	model, res, err := client.GetFile("33242342").Commit()
 
@@ -73,7 +73,7 @@ func TestUploadStickerFile_Success(t *testing.T) {
 
 	client := telegraph.NewClient("token")
 
-	model, res, err := client.UploadStickerFile(33242342, "./LICENSE").Commit()
+	model, res, err := client.UploadStickerFile(33242342, telegraph.FilePath("./LICENSE")).Commit()
 
 	assert.NotNil(t, model)
 	assert.Equal(t, http.StatusOK, res.StatusCode)
@@ -84,9 +84,9 @@ func TestUploadStickerFile_Error(t *testing.T) {
 	gock.New(telegraph.BaseURL).Head(fmt.Sprintf(telegraph.EndpointUploadStickerFile, "token")).Reply(http.StatusInternalServerError).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 
-	model, res, err := client.UploadStickerFile(33242342, "./LICENSE").Commit()
+	model, res, err := client.UploadStickerFile(33242342, telegraph.FilePath("./LICENSE")).Commit()
 
 	assert.Nil(t, model)
 	assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
@@ -101,9 +101,9 @@ func TestUploadStickerFile_Failed(t *testing.T) {
 	}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 
-	model, res, err := client.UploadStickerFile(33242342, "./LICENSE").Commit()
+	model, res, err := client.UploadStickerFile(33242342, telegraph.FilePath("./LICENSE")).Commit()
 
 	assert.Nil(t, model)
 	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
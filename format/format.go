@@ -0,0 +1,364 @@
+/*
+Package format build message text for HTML, Markdown and MarkdownV2 parse mode with user input escaped,
+and render received text with its entities back into the parse mode.
+
+	text := format.New(format.HTML).Text("Hello ").Bold(user.FirstName).Text(", see ").Link("docs", "https://core.telegram.org/bots/api")
+	message, res, err := client.SendMessage(<chat_id>, text.String()).SetParseMode(text.Mode().String()).Commit()
+*/
+package format
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"telegraph"
+	"unicode/utf16"
+)
+
+// Mode parse mode of message text
+type Mode string
+
+const (
+	// HTML parse mode, entities can be nested
+	HTML Mode = "HTML"
+	// Markdown legacy parse mode, entities can't be nested and underline or strikethrough aren't supported
+	Markdown Mode = "Markdown"
+	// MarkdownV2 parse mode, entities can be nested
+	MarkdownV2 Mode = "MarkdownV2"
+)
+
+// Entity types of MessageEntity
+const (
+	EntityMention       = telegraph.EntityTypeMention
+	EntityHashtag       = telegraph.EntityTypeHashtag
+	EntityCashtag       = telegraph.EntityTypeCashtag
+	EntityBotCommand    = telegraph.EntityTypeBotCommand
+	EntityURL           = telegraph.EntityTypeURL
+	EntityEmail         = telegraph.EntityTypeEmail
+	EntityPhoneNumber   = telegraph.EntityTypePhoneNumber
+	EntityBold          = telegraph.EntityTypeBold
+	EntityItalic        = telegraph.EntityTypeItalic
+	EntityUnderline     = telegraph.EntityTypeUnderline
+	EntityStrikethrough = telegraph.EntityTypeStrikethrough
+	EntityCode          = telegraph.EntityTypeCode
+	EntityPre           = telegraph.EntityTypePre
+	EntityTextLink      = telegraph.EntityTypeTextLink
+	EntityTextMention   = telegraph.EntityTypeTextMention
+)
+
+var (
+	htmlEscaper       = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+	markdownEscaper   = strings.NewReplacer(`_`, `\_`, `*`, `\*`, "`", "\\`", `[`, `\[`)
+	markdownV2Escaper = strings.NewReplacer(
+		`\`, `\\`, `_`, `\_`, `*`, `\*`, `[`, `\[`, `]`, `\]`, `(`, `\(`, `)`, `\)`, `~`, `\~`, "`", "\\`", `>`, `\>`,
+		`#`, `\#`, `+`, `\+`, `-`, `\-`, `=`, `\=`, `|`, `\|`, `{`, `\{`, `}`, `\}`, `.`, `\.`, `!`, `\!`,
+	)
+	markdownV2CodeEscaper = strings.NewReplacer(`\`, `\\`, "`", "\\`")
+	markdownV2URLEscaper  = strings.NewReplacer(`\`, `\\`, `)`, `\)`)
+)
+
+// String parse mode sent to telegram with SetParseMode()
+func (mode Mode) String() string {
+	return string(mode)
+}
+
+// Escape text so it's shown as is
+func (mode Mode) Escape(text string) string {
+	switch mode {
+	case HTML:
+		return htmlEscaper.Replace(text)
+	case Markdown:
+		return markdownEscaper.Replace(text)
+	case MarkdownV2:
+		return markdownV2Escaper.Replace(text)
+	}
+
+	return text
+}
+
+// Bold bold text
+func (mode Mode) Bold(text string) string {
+	return mode.format(telegraph.MessageEntity{Type: EntityBold}, text, mode.Escape(text))
+}
+
+// Italic italic text
+func (mode Mode) Italic(text string) string {
+	return mode.format(telegraph.MessageEntity{Type: EntityItalic}, text, mode.Escape(text))
+}
+
+// Underline underlined text, shown as plain text in Markdown mode
+func (mode Mode) Underline(text string) string {
+	return mode.format(telegraph.MessageEntity{Type: EntityUnderline}, text, mode.Escape(text))
+}
+
+// Strikethrough strikethrough text, shown as plain text in Markdown mode
+func (mode Mode) Strikethrough(text string) string {
+	return mode.format(telegraph.MessageEntity{Type: EntityStrikethrough}, text, mode.Escape(text))
+}
+
+// Code inline fixed-width code
+func (mode Mode) Code(text string) string {
+	return mode.format(telegraph.MessageEntity{Type: EntityCode}, text, mode.Escape(text))
+}
+
+// Pre pre-formatted fixed-width code block, language is optional
+func (mode Mode) Pre(text, language string) string {
+	return mode.format(telegraph.MessageEntity{Type: EntityPre, Language: language}, text, mode.Escape(text))
+}
+
+// Link text linked to url, in Markdown mode ] can't be escaped in link text so it's kept unlinked between linked parts
+func (mode Mode) Link(text, url string) string {
+	return mode.format(telegraph.MessageEntity{Type: EntityTextLink, URL: url}, text, mode.Escape(text))
+}
+
+// Mention text mentioning user, works for users without username
+func (mode Mode) Mention(text string, userId int64) string {
+	return mode.format(telegraph.MessageEntity{Type: EntityTextMention, User: &telegraph.User{ID: userId}}, text, mode.Escape(text))
+}
+
+/*
+Render text and its entities, e.g. Message.Text and Message.Entities, into formatted text of the mode.
+Entity offset and length are counted in UTF-16 code units as sent by telegram.
+Nested entities are rendered in HTML and MarkdownV2 mode, Markdown mode render the outer entity only
+*/
+func (mode Mode) Render(text string, entities []telegraph.MessageEntity) string {
+	units := utf16.Encode([]rune(text))
+	sorted := append([]telegraph.MessageEntity{}, entities...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Offset != sorted[j].Offset {
+			return sorted[i].Offset < sorted[j].Offset
+		}
+		return sorted[i].Length > sorted[j].Length
+	})
+
+	return mode.render(units, 0, len(units), sorted)
+}
+
+// render units between start and end, entities are sorted by offset
+func (mode Mode) render(units []uint16, start, end int, entities []telegraph.MessageEntity) string {
+	var builder strings.Builder
+	position := start
+
+	for i := 0; i < len(entities); {
+		entity := entities[i]
+		entityStart := clamp(entity.Offset, start, end)
+		entityEnd := clamp(entity.Offset+entity.Length, start, end)
+
+		children := i + 1
+		for children < len(entities) && entities[children].Offset < entityEnd {
+			children++
+		}
+		if entityStart < position || entityEnd <= entityStart {
+			i++
+			continue
+		}
+
+		builder.WriteString(mode.Escape(decode(units[position:entityStart])))
+		raw := decode(units[entityStart:entityEnd])
+		inner := mode.Escape(raw)
+		if mode != Markdown {
+			inner = mode.render(units, entityStart, entityEnd, entities[i+1:children])
+		}
+		builder.WriteString(mode.format(entity, raw, inner))
+
+		position = entityEnd
+		i = children
+	}
+	builder.WriteString(mode.Escape(decode(units[position:end])))
+
+	return builder.String()
+}
+
+// format wrap entity, raw is the plain text of entity and inner is the text already escaped or rendered with nested entities
+func (mode Mode) format(entity telegraph.MessageEntity, raw, inner string) string {
+	url := entity.URL
+	if entity.Type == EntityTextMention && entity.User != nil {
+		url = fmt.Sprintf("tg://user?id=%d", entity.User.ID)
+	}
+
+	switch mode {
+	case HTML:
+		switch entity.Type {
+		case EntityBold:
+			return "<b>" + inner + "</b>"
+		case EntityItalic:
+			return "<i>" + inner + "</i>"
+		case EntityUnderline:
+			return "<u>" + inner + "</u>"
+		case EntityStrikethrough:
+			return "<s>" + inner + "</s>"
+		case EntityCode:
+			return "<code>" + htmlEscaper.Replace(raw) + "</code>"
+		case EntityPre:
+			if entity.Language != "" {
+				return `<pre><code class="language-` + htmlEscaper.Replace(entity.Language) + `">` + htmlEscaper.Replace(raw) + "</code></pre>"
+			}
+			return "<pre>" + htmlEscaper.Replace(raw) + "</pre>"
+		case EntityTextLink, EntityTextMention:
+			return `<a href="` + htmlEscaper.Replace(url) + `">` + inner + "</a>"
+		}
+
+	case Markdown:
+		switch entity.Type {
+		case EntityBold:
+			return splitWrap(raw, "*", "*", "*")
+		case EntityItalic:
+			return splitWrap(raw, "_", "_", "_")
+		case EntityCode:
+			return splitWrap(raw, "`", "`", "`")
+		case EntityPre:
+			return splitWrap(raw, "`", "```"+entity.Language+"\n", "```")
+		case EntityTextLink, EntityTextMention:
+			return linkParts(raw, strings.ReplaceAll(url, ")", "%29"))
+		}
+
+	case MarkdownV2:
+		switch entity.Type {
+		case EntityBold:
+			return "*" + inner + "*"
+		case EntityItalic:
+			return "_" + separate(inner) + "_"
+		case EntityUnderline:
+			return "__" + separate(inner) + "__"
+		case EntityStrikethrough:
+			return "~" + inner + "~"
+		case EntityCode:
+			return "`" + markdownV2CodeEscaper.Replace(raw) + "`"
+		case EntityPre:
+			return "```" + entity.Language + "\n" + markdownV2CodeEscaper.Replace(raw) + "```"
+		case EntityTextLink, EntityTextMention:
+			return "[" + inner + "](" + markdownV2URLEscaper.Replace(url) + ")"
+		}
+	}
+
+	return inner
+}
+
+// splitWrap wrap parts of text between delimiter in Markdown mode, delimiter can't be escaped inside entity so it's escaped between entities
+func splitWrap(text, delimiter, open, close string) string {
+	var builder strings.Builder
+	for i, part := range strings.Split(text, delimiter) {
+		if i > 0 {
+			builder.WriteString(`\` + delimiter)
+		}
+		if part != "" {
+			builder.WriteString(open + part + close)
+		}
+	}
+
+	return builder.String()
+}
+
+// linkParts link parts of text between ] in Markdown mode, ] is shown as plain text between the links
+func linkParts(text, url string) string {
+	var builder strings.Builder
+	for i, part := range strings.Split(text, "]") {
+		if i > 0 {
+			builder.WriteString("]")
+		}
+		if part != "" {
+			builder.WriteString("[" + part + "](" + url + ")")
+		}
+	}
+
+	return builder.String()
+}
+
+// separate inner text from adjacent underscore with \r ignored by telegram, e.g. ___italic underline_\r__
+func separate(inner string) string {
+	if strings.HasPrefix(inner, "_") {
+		inner = "\r" + inner
+	}
+	if strings.HasSuffix(inner, "_") && !strings.HasSuffix(inner, `\_`) {
+		inner += "\r"
+	}
+
+	return inner
+}
+
+func decode(units []uint16) string {
+	return string(utf16.Decode(units))
+}
+
+func clamp(value, min, max int) int {
+	if value < min {
+		return min
+	}
+	if value > max {
+		return max
+	}
+	return value
+}
+
+// Builder build message text fragment by fragment, every fragment is escaped for the mode
+type Builder struct {
+	mode Mode
+	text strings.Builder
+}
+
+// New create empty builder of text formatted in mode
+func New(mode Mode) *Builder {
+	return &Builder{mode: mode}
+}
+
+// Mode parse mode of the text, send it with SetParseMode()
+func (builder *Builder) Mode() Mode {
+	return builder.mode
+}
+
+// Text append plain text
+func (builder *Builder) Text(text string) *Builder {
+	builder.text.WriteString(builder.mode.Escape(text))
+	return builder
+}
+
+// Raw append text already formatted in the mode, it isn't escaped
+func (builder *Builder) Raw(text string) *Builder {
+	builder.text.WriteString(text)
+	return builder
+}
+
+// Bold append bold text
+func (builder *Builder) Bold(text string) *Builder {
+	return builder.Raw(builder.mode.Bold(text))
+}
+
+// Italic append italic text
+func (builder *Builder) Italic(text string) *Builder {
+	return builder.Raw(builder.mode.Italic(text))
+}
+
+// Underline append underlined text
+func (builder *Builder) Underline(text string) *Builder {
+	return builder.Raw(builder.mode.Underline(text))
+}
+
+// Strikethrough append strikethrough text
+func (builder *Builder) Strikethrough(text string) *Builder {
+	return builder.Raw(builder.mode.Strikethrough(text))
+}
+
+// Code append inline fixed-width code
+func (builder *Builder) Code(text string) *Builder {
+	return builder.Raw(builder.mode.Code(text))
+}
+
+// Pre append pre-formatted code block
+func (builder *Builder) Pre(text, language string) *Builder {
+	return builder.Raw(builder.mode.Pre(text, language))
+}
+
+// Link append text linked to url
+func (builder *Builder) Link(text, url string) *Builder {
+	return builder.Raw(builder.mode.Link(text, url))
+}
+
+// Mention append text mentioning user
+func (builder *Builder) Mention(text string, userId int64) *Builder {
+	return builder.Raw(builder.mode.Mention(text, userId))
+}
+
+// String formatted text
+func (builder *Builder) String() string {
+	return builder.text.String()
+}
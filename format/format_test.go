@@ -0,0 +1,69 @@
+package format_test
+
+import (
+	"telegraph"
+	"telegraph/format"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEscape(t *testing.T) {
+	assert.Equal(t, "a &lt;b&gt; &amp; &quot;c&quot;", format.HTML.Escape(`a <b> & "c"`))
+	assert.Equal(t, "snake\\_case 2\\*2 \\`x\\` \\[link]", format.Markdown.Escape("snake_case 2*2 `x` [link]"))
+	assert.Equal(t, `1\+1\=2\. \(ok\)\! a\\b`, format.MarkdownV2.Escape(`1+1=2. (ok)! a\b`))
+}
+
+func TestFragments(t *testing.T) {
+	assert.Equal(t, "<b>a&lt;b</b>", format.HTML.Bold("a<b"))
+	assert.Equal(t, `<pre><code class="language-go">x &lt; y</code></pre>`, format.HTML.Pre("x < y", "go"))
+	assert.Equal(t, `<a href="tg://user?id=42">Jane</a>`, format.HTML.Mention("Jane", 42))
+	assert.Equal(t, `<a href="https://example.com/?a=1&amp;b=2">docs</a>`, format.HTML.Link("docs", "https://example.com/?a=1&b=2"))
+
+	assert.Equal(t, "*2*\\**2=4*", format.Markdown.Bold("2*2=4"))
+	assert.Equal(t, "_snake_\\__case_", format.Markdown.Italic("snake_case"))
+	assert.Equal(t, "under", format.Markdown.Underline("under"))
+	assert.Equal(t, "[docs](https://example.com/a%29)", format.Markdown.Link("docs", "https://example.com/a)"))
+	assert.Equal(t, "[a](https://example.com)][b](https://example.com)]", format.Markdown.Link("a]b]", "https://example.com"))
+
+	assert.Equal(t, `*1\+1*`, format.MarkdownV2.Bold("1+1"))
+	assert.Equal(t, "`a\\`b\\\\`", format.MarkdownV2.Code("a`b\\"))
+	assert.Equal(t, "```go\nfmt.Println(\"*\")```", format.MarkdownV2.Pre("fmt.Println(\"*\")", "go"))
+	assert.Equal(t, `[docs\.](https://example.com/\))`, format.MarkdownV2.Link("docs.", "https://example.com/)"))
+}
+
+func TestBuilder(t *testing.T) {
+	text := format.New(format.HTML).Text("Hi <").Bold("Jane").Text("> ").Code("/start").Raw("<i>!</i>")
+
+	assert.Equal(t, format.HTML, text.Mode())
+	assert.Equal(t, "HTML", text.Mode().String())
+	assert.Equal(t, "Hi &lt;<b>Jane</b>&gt; <code>/start</code><i>!</i>", text.String())
+}
+
+func TestRender(t *testing.T) {
+	// 😀 is two UTF-16 code units
+	text := "😀 bold italic link <x>"
+	entities := []telegraph.MessageEntity{
+		{Type: format.EntityItalic, Offset: 8, Length: 6},
+		{Type: format.EntityBold, Offset: 3, Length: 11},
+		{Type: format.EntityTextLink, Offset: 15, Length: 4, URL: "https://example.com"},
+		{Type: format.EntityHashtag, Offset: 0, Length: 0},
+	}
+
+	assert.Equal(t, `😀 <b>bold <i>italic</i></b> <a href="https://example.com">link</a> &lt;x&gt;`, format.HTML.Render(text, entities))
+	assert.Equal(t, `😀 *bold _italic_* [link](https://example.com) <x\>`, format.MarkdownV2.Render(text, entities))
+	assert.Equal(t, `😀 *bold italic* [link](https://example.com) <x>`, format.Markdown.Render(text, entities))
+
+	assert.Equal(t, "a_b", format.HTML.Render("a_b", nil))
+	assert.Equal(t, "<b>ab</b>", format.HTML.Render("ab", []telegraph.MessageEntity{{Type: format.EntityBold, Offset: 0, Length: 10}}))
+}
+
+func TestRenderUnderlineItalic(t *testing.T) {
+	entities := []telegraph.MessageEntity{
+		{Type: format.EntityItalic, Offset: 0, Length: 1},
+		{Type: format.EntityUnderline, Offset: 0, Length: 1},
+	}
+
+	assert.Equal(t, "_\r__x__\r_", format.MarkdownV2.Render("x", entities))
+	assert.Equal(t, "<i><u>x</u></i>", format.HTML.Render("x", entities))
+}
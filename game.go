@@ -0,0 +1,168 @@
+package telegraph
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/parnurzeal/gorequest"
+)
+
+type (
+	// ArrayGameHighScoreResponse struct to handle request and array of game high score response telegram api
+	ArrayGameHighScoreResponse struct {
+		Client      *Client
+		Request     *gorequest.SuperAgent
+		retryPolicy RetryPolicy
+	}
+)
+
+/*
+SendGame Use this method to send a game. On success, the sent Message is returned.
++ chatId - Unique identifier for the target chat
++ gameShortName - Short name of the game, serves as the unique identifier for the game. Set up your games via Botfather.
+
+Available method can used with this method
++ SetDisableNotification()
++ SetReplyToMessageID()
++ SetReplyMarkup()
+*/
+func (client *Client) SendGame(chatId int64, gameShortName string) *MessageResponse {
+	body := JSON{
+		"chat_id":         chatId,
+		"game_short_name": gameShortName,
+	}
+	endpoint := client.baseURL + fmt.Sprintf(EndpointSendGame, client.accessToken)
+	request := gorequest.New().Post(endpoint).Type(gorequest.TypeJSON).Set(UserAgentHeader, client.userAgent).Send(body)
+
+	return &MessageResponse{
+		Client:  client,
+		Request: request,
+	}
+}
+
+/*
+SetGameScore Use this method to set the score of the specified user in a game. On success, if the message was sent by the bot,
+returns the edited Message, otherwise returns True. Returns an error, if the new score is not greater than the user's current score in the chat and force is False.
++ userId - User identifier
++ score - New score, must be non-negative
+
+Available method can used with this method
++ SetChatID()
++ SetMessageID()
++ SetInlineMessageID()
++ SetForce()
++ SetDisableEditMessage()
+*/
+func (client *Client) SetGameScore(userId int64, score int) *VoidResponse {
+	body := JSON{
+		"user_id": userId,
+		"score":   score,
+	}
+	endpoint := client.baseURL + fmt.Sprintf(EndpointSetGameScore, client.accessToken)
+	request := gorequest.New().Post(endpoint).Type(gorequest.TypeJSON).Set(UserAgentHeader, client.userAgent).Send(body)
+
+	return &VoidResponse{
+		Client:  client,
+		Request: request,
+	}
+}
+
+// SetForce Pass True, if the high score is allowed to decrease. This can be useful when fixing mistakes or banning cheaters
+func (void *VoidResponse) SetForce(force bool) *VoidResponse {
+	body := JSON{
+		"force": force,
+	}
+	void.Request = void.Request.Send(body)
+
+	return void
+}
+
+// SetDisableEditMessage Pass True, if the game message should not be automatically edited to include the current scoreboard
+func (void *VoidResponse) SetDisableEditMessage(disable bool) *VoidResponse {
+	body := JSON{
+		"disable_edit_message": disable,
+	}
+	void.Request = void.Request.Send(body)
+
+	return void
+}
+
+/*
+GetGameHighScores Use this method to get data for high score tables. Will return the score of the specified user and several of his neighbors in a game.
+On success, returns an Array of GameHighScore objects.
++ userId - Target user id
+
+Available method can used with this method
++ SetChatID()
++ SetMessageID()
++ SetInlineMessageID()
+*/
+func (client *Client) GetGameHighScores(userId int64) *ArrayGameHighScoreResponse {
+	body := JSON{
+		"user_id": userId,
+	}
+	endpoint := client.baseURL + fmt.Sprintf(EndpointGetGameHighScores, client.accessToken)
+	request := gorequest.New().Post(endpoint).Type(gorequest.TypeJSON).Set(UserAgentHeader, client.userAgent).Send(body)
+
+	return &ArrayGameHighScoreResponse{
+		Client:  client,
+		Request: request,
+	}
+}
+
+// SetChatID Required if inline_message_id is not specified. Unique identifier for the target chat
+func (score *ArrayGameHighScoreResponse) SetChatID(chatId int64) *ArrayGameHighScoreResponse {
+	body := JSON{
+		"chat_id": chatId,
+	}
+	score.Request = score.Request.Send(body)
+
+	return score
+}
+
+// SetMessageID Required if inline_message_id is not specified. Identifier of the sent message
+func (score *ArrayGameHighScoreResponse) SetMessageID(messageId int64) *ArrayGameHighScoreResponse {
+	body := JSON{
+		"message_id": messageId,
+	}
+	score.Request = score.Request.Send(body)
+
+	return score
+}
+
+// SetInlineMessageID Required if chat_id and message_id are not specified. Identifier of the inline message
+func (score *ArrayGameHighScoreResponse) SetInlineMessageID(inlineMessage string) *ArrayGameHighScoreResponse {
+	body := JSON{
+		"inline_message_id": inlineMessage,
+	}
+	score.Request = score.Request.Send(body)
+
+	return score
+}
+
+// SetRetryPolicy decide how this request is retried when it fails, override retry policy of the client
+func (score *ArrayGameHighScoreResponse) SetRetryPolicy(policy RetryPolicy) *ArrayGameHighScoreResponse {
+	score.retryPolicy = policy
+
+	return score
+}
+
+// Commit execute request to telegram
+func (score *ArrayGameHighScoreResponse) Commit() ([]GameHighScore, *http.Response, error) {
+	return score.CommitContext(context.Background())
+}
+
+// CommitContext execute request to telegram, the request and its back off retry are aborted when ctx is done
+func (score *ArrayGameHighScoreResponse) CommitContext(ctx context.Context) ([]GameHighScore, *http.Response, error) {
+	model := struct {
+		Result []GameHighScore `json:"result,omitempty"`
+	}{}
+
+	_, res, err := score.Client.commit(ctx, score.Request, score.retryPolicy, &model)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return model.Result, res, nil
+}
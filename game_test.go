@@ -0,0 +1,124 @@
+package telegraph_test
+
+import (
+	"fmt"
+	"net/http"
+	"telegraph"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestSendGame_Success(t *testing.T) {
+	gock.New(telegraph.BaseURL).Post(fmt.Sprintf(telegraph.EndpointSendGame, "token")).
+		MatchType("json").JSON(map[string]interface{}{
+		"chat_id":              2434234,
+		"game_short_name":      "cube",
+		"disable_notification": true,
+	}).Reply(http.StatusOK).JSON(`{
+		"ok": true,
+		"result": {
+			"message_id": 100,
+			"chat": {
+				"id": 2434234,
+				"type": "private"
+			},
+			"date": 1510125931,
+			"game": {
+				"title": "Cube",
+				"description": "Solve the cube",
+				"photo": []
+			}
+		}
+	}`)
+	defer gock.Off()
+
+	client := telegraph.NewClient("token")
+	message, res, err := client.SendGame(2434234, "cube").SetDisableNotification(true).Commit()
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "Cube", message.Game.Title)
+}
+
+func TestSetGameScore_Success(t *testing.T) {
+	gock.New(telegraph.BaseURL).Post(fmt.Sprintf(telegraph.EndpointSetGameScore, "token")).
+		MatchType("json").JSON(map[string]interface{}{
+		"user_id":              1234567890,
+		"score":                100,
+		"inline_message_id":    "inline_1",
+		"force":                true,
+		"disable_edit_message": true,
+	}).Reply(http.StatusOK).JSON(`{
+		"ok": true,
+		"result": true
+	}`)
+	defer gock.Off()
+
+	client := telegraph.NewClient("token")
+	body, res, err := client.SetGameScore(1234567890, 100).SetInlineMessageID("inline_1").
+		SetForce(true).SetDisableEditMessage(true).Commit()
+
+	assert.NotNil(t, body)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.NoError(t, err)
+}
+
+func TestSetGameScore_Failed(t *testing.T) {
+	gock.New(telegraph.BaseURL).Post(fmt.Sprintf(telegraph.EndpointSetGameScore, "token")).Reply(http.StatusBadRequest).JSON(`{
+		"ok": false,
+		"error_code": 400,
+		"description": "Bad Request: BOT_SCORE_NOT_MODIFIED"
+	}`)
+	defer gock.Off()
+
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
+	body, res, err := client.SetGameScore(1234567890, 1).SetChatID(2434234).SetMessageID(100).Commit()
+
+	assert.Nil(t, body)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	assert.True(t, telegraph.IsBadRequest(err))
+}
+
+func TestGetGameHighScores_Success(t *testing.T) {
+	gock.New(telegraph.BaseURL).Post(fmt.Sprintf(telegraph.EndpointGetGameHighScores, "token")).
+		MatchType("json").JSON(map[string]interface{}{
+		"user_id":    1234567890,
+		"chat_id":    2434234,
+		"message_id": 100,
+	}).Reply(http.StatusOK).JSON(`{
+		"ok": true,
+		"result": [
+			{
+				"position": 1,
+				"user": {
+					"id": 1234567890,
+					"is_bot": false,
+					"first_name": "cube"
+				},
+				"score": 100
+			}
+		]
+	}`)
+	defer gock.Off()
+
+	client := telegraph.NewClient("token")
+	scores, res, err := client.GetGameHighScores(1234567890).SetChatID(2434234).SetMessageID(100).Commit()
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, []telegraph.GameHighScore{{Position: 1, User: telegraph.User{ID: 1234567890, FirstName: "cube"}, Score: 100}}, scores)
+}
+
+func TestGetGameHighScores_Error(t *testing.T) {
+	gock.New(telegraph.BaseURL).Head(fmt.Sprintf(telegraph.EndpointGetGameHighScores, "token")).Reply(http.StatusInternalServerError).JSON("")
+	defer gock.Off()
+
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
+	scores, res, err := client.GetGameHighScores(1234567890).SetInlineMessageID("inline_1").Commit()
+
+	assert.Nil(t, scores)
+	assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+	assert.Error(t, err)
+}
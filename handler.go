@@ -0,0 +1,25 @@
+package telegraph
+
+import "context"
+
+type (
+	// Handler respond to an update received from telegram, by long polling or web hook.
+	// ctx is done when the update source is stopped or the web hook request is canceled
+	Handler interface {
+		HandleUpdate(ctx context.Context, update *Update)
+	}
+
+	// HandlerFunc adapter to use ordinary function as Handler
+	HandlerFunc func(ctx context.Context, update *Update)
+
+	// Submitter handler which can refuse an update, e.g. WorkerPool after it is shut down.
+	// Poller and WebHookHandler submit update to Submitter, so refused update isn't acknowledged to telegram
+	Submitter interface {
+		Submit(ctx context.Context, update *Update) error
+	}
+)
+
+// HandleUpdate call fn(ctx, update)
+func (fn HandlerFunc) HandleUpdate(ctx context.Context, update *Update) {
+	fn(ctx, update)
+}
@@ -0,0 +1,611 @@
+package telegraph
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type (
+	// InlineQueryResult one result of an inline query answered with AnswerInlineQuery.
+	// Result type is added to the request by AnswerInlineQuery, JSON can be used for result which isn't supported
+	InlineQueryResult interface {
+		// ResultType value of type field of the result, e.g. article
+		ResultType() string
+		// Validate return error when required field of the result is missing
+		Validate() error
+	}
+
+	// InputMessageContent content of a message to be sent as a result of an inline query
+	InputMessageContent interface {
+		// Validate return error when required field of the content is missing
+		Validate() error
+	}
+
+	// InlineQueryResultArticle Represents a link to an article or web page.
+	InlineQueryResultArticle struct {
+		ID                  string                `json:"id"`
+		Title               string                `json:"title"`
+		InputMessageContent InputMessageContent   `json:"input_message_content"`
+		ReplyMarkup         *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
+		URL                 string                `json:"url,omitempty"`
+		HideURL             bool                  `json:"hide_url,omitempty"`
+		Description         string                `json:"description,omitempty"`
+		ThumbURL            string                `json:"thumb_url,omitempty"`
+		ThumbWidth          int                   `json:"thumb_width,omitempty"`
+		ThumbHeight         int                   `json:"thumb_height,omitempty"`
+	}
+
+	// InlineQueryResultPhoto Represents a link to a photo. By default, this photo will be sent by the user with optional caption.
+	// Alternatively, you can use input_message_content to send a message with the specified content instead of the photo.
+	InlineQueryResultPhoto struct {
+		ID                  string                `json:"id"`
+		PhotoURL            string                `json:"photo_url"`
+		ThumbURL            string                `json:"thumb_url"`
+		PhotoWidth          int                   `json:"photo_width,omitempty"`
+		PhotoHeight         int                   `json:"photo_height,omitempty"`
+		Title               string                `json:"title,omitempty"`
+		Description         string                `json:"description,omitempty"`
+		Caption             string                `json:"caption,omitempty"`
+		ParseMode           string                `json:"parse_mode,omitempty"`
+		ReplyMarkup         *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
+		InputMessageContent InputMessageContent   `json:"input_message_content,omitempty"`
+	}
+
+	// InlineQueryResultGif Represents a link to an animated GIF file.
+	InlineQueryResultGif struct {
+		ID                  string                `json:"id"`
+		GifURL              string                `json:"gif_url"`
+		GifWidth            int                   `json:"gif_width,omitempty"`
+		GifHeight           int                   `json:"gif_height,omitempty"`
+		GifDuration         int                   `json:"gif_duration,omitempty"`
+		ThumbURL            string                `json:"thumb_url"`
+		Title               string                `json:"title,omitempty"`
+		Caption             string                `json:"caption,omitempty"`
+		ParseMode           string                `json:"parse_mode,omitempty"`
+		ReplyMarkup         *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
+		InputMessageContent InputMessageContent   `json:"input_message_content,omitempty"`
+	}
+
+	// InlineQueryResultMpeg4Gif Represents a link to a video animation (H.264/MPEG-4 AVC video without sound).
+	InlineQueryResultMpeg4Gif struct {
+		ID                  string                `json:"id"`
+		Mpeg4URL            string                `json:"mpeg4_url"`
+		Mpeg4Width          int                   `json:"mpeg4_width,omitempty"`
+		Mpeg4Height         int                   `json:"mpeg4_height,omitempty"`
+		Mpeg4Duration       int                   `json:"mpeg4_duration,omitempty"`
+		ThumbURL            string                `json:"thumb_url"`
+		Title               string                `json:"title,omitempty"`
+		Caption             string                `json:"caption,omitempty"`
+		ParseMode           string                `json:"parse_mode,omitempty"`
+		ReplyMarkup         *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
+		InputMessageContent InputMessageContent   `json:"input_message_content,omitempty"`
+	}
+
+	// InlineQueryResultVideo Represents a link to a page containing an embedded video player or a video file.
+	InlineQueryResultVideo struct {
+		ID                  string                `json:"id"`
+		VideoURL            string                `json:"video_url"`
+		MimeType            string                `json:"mime_type"`
+		ThumbURL            string                `json:"thumb_url"`
+		Title               string                `json:"title"`
+		Caption             string                `json:"caption,omitempty"`
+		ParseMode           string                `json:"parse_mode,omitempty"`
+		VideoWidth          int                   `json:"video_width,omitempty"`
+		VideoHeight         int                   `json:"video_height,omitempty"`
+		VideoDuration       int                   `json:"video_duration,omitempty"`
+		Description         string                `json:"description,omitempty"`
+		ReplyMarkup         *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
+		InputMessageContent InputMessageContent   `json:"input_message_content,omitempty"`
+	}
+
+	// InlineQueryResultAudio Represents a link to an mp3 audio file.
+	InlineQueryResultAudio struct {
+		ID                  string                `json:"id"`
+		AudioURL            string                `json:"audio_url"`
+		Title               string                `json:"title"`
+		Caption             string                `json:"caption,omitempty"`
+		ParseMode           string                `json:"parse_mode,omitempty"`
+		Performer           string                `json:"performer,omitempty"`
+		AudioDuration       int                   `json:"audio_duration,omitempty"`
+		ReplyMarkup         *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
+		InputMessageContent InputMessageContent   `json:"input_message_content,omitempty"`
+	}
+
+	// InlineQueryResultVoice Represents a link to a voice recording in an .ogg container encoded with OPUS.
+	InlineQueryResultVoice struct {
+		ID                  string                `json:"id"`
+		VoiceURL            string                `json:"voice_url"`
+		Title               string                `json:"title"`
+		Caption             string                `json:"caption,omitempty"`
+		ParseMode           string                `json:"parse_mode,omitempty"`
+		VoiceDuration       int                   `json:"voice_duration,omitempty"`
+		ReplyMarkup         *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
+		InputMessageContent InputMessageContent   `json:"input_message_content,omitempty"`
+	}
+
+	// InlineQueryResultDocument Represents a link to a file, currently only .PDF and .ZIP files can be sent.
+	InlineQueryResultDocument struct {
+		ID                  string                `json:"id"`
+		Title               string                `json:"title"`
+		Caption             string                `json:"caption,omitempty"`
+		ParseMode           string                `json:"parse_mode,omitempty"`
+		DocumentURL         string                `json:"document_url"`
+		MimeType            string                `json:"mime_type"`
+		Description         string                `json:"description,omitempty"`
+		ReplyMarkup         *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
+		InputMessageContent InputMessageContent   `json:"input_message_content,omitempty"`
+		ThumbURL            string                `json:"thumb_url,omitempty"`
+		ThumbWidth          int                   `json:"thumb_width,omitempty"`
+		ThumbHeight         int                   `json:"thumb_height,omitempty"`
+	}
+
+	// InlineQueryResultLocation Represents a location on a map.
+	InlineQueryResultLocation struct {
+		ID                  string                `json:"id"`
+		Latitude            float64               `json:"latitude"`
+		Longitude           float64               `json:"longitude"`
+		Title               string                `json:"title"`
+		LivePeriod          int                   `json:"live_period,omitempty"`
+		ReplyMarkup         *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
+		InputMessageContent InputMessageContent   `json:"input_message_content,omitempty"`
+		ThumbURL            string                `json:"thumb_url,omitempty"`
+		ThumbWidth          int                   `json:"thumb_width,omitempty"`
+		ThumbHeight         int                   `json:"thumb_height,omitempty"`
+	}
+
+	// InlineQueryResultVenue Represents a venue.
+	InlineQueryResultVenue struct {
+		ID                  string                `json:"id"`
+		Latitude            float64               `json:"latitude"`
+		Longitude           float64               `json:"longitude"`
+		Title               string                `json:"title"`
+		Address             string                `json:"address"`
+		FoursquareID        string                `json:"foursquare_id,omitempty"`
+		FoursquareType      string                `json:"foursquare_type,omitempty"`
+		ReplyMarkup         *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
+		InputMessageContent InputMessageContent   `json:"input_message_content,omitempty"`
+		ThumbURL            string                `json:"thumb_url,omitempty"`
+		ThumbWidth          int                   `json:"thumb_width,omitempty"`
+		ThumbHeight         int                   `json:"thumb_height,omitempty"`
+	}
+
+	// InlineQueryResultContact Represents a contact with a phone number.
+	InlineQueryResultContact struct {
+		ID                  string                `json:"id"`
+		PhoneNumber         string                `json:"phone_number"`
+		FirstName           string                `json:"first_name"`
+		LastName            string                `json:"last_name,omitempty"`
+		Vcard               string                `json:"vcard,omitempty"`
+		ReplyMarkup         *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
+		InputMessageContent InputMessageContent   `json:"input_message_content,omitempty"`
+		ThumbURL            string                `json:"thumb_url,omitempty"`
+		ThumbWidth          int                   `json:"thumb_width,omitempty"`
+		ThumbHeight         int                   `json:"thumb_height,omitempty"`
+	}
+
+	// InlineQueryResultGame Represents a Game.
+	InlineQueryResultGame struct {
+		ID            string                `json:"id"`
+		GameShortName string                `json:"game_short_name"`
+		ReplyMarkup   *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
+	}
+
+	// InlineQueryResultCachedPhoto Represents a link to a photo stored on the Telegram servers.
+	InlineQueryResultCachedPhoto struct {
+		ID                  string                `json:"id"`
+		PhotoFileID         string                `json:"photo_file_id"`
+		Title               string                `json:"title,omitempty"`
+		Description         string                `json:"description,omitempty"`
+		Caption             string                `json:"caption,omitempty"`
+		ParseMode           string                `json:"parse_mode,omitempty"`
+		ReplyMarkup         *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
+		InputMessageContent InputMessageContent   `json:"input_message_content,omitempty"`
+	}
+
+	// InlineQueryResultCachedGif Represents a link to an animated GIF file stored on the Telegram servers.
+	InlineQueryResultCachedGif struct {
+		ID                  string                `json:"id"`
+		GifFileID           string                `json:"gif_file_id"`
+		Title               string                `json:"title,omitempty"`
+		Caption             string                `json:"caption,omitempty"`
+		ParseMode           string                `json:"parse_mode,omitempty"`
+		ReplyMarkup         *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
+		InputMessageContent InputMessageContent   `json:"input_message_content,omitempty"`
+	}
+
+	// InlineQueryResultCachedMpeg4Gif Represents a link to a video animation (H.264/MPEG-4 AVC video without sound) stored on the Telegram servers.
+	InlineQueryResultCachedMpeg4Gif struct {
+		ID                  string                `json:"id"`
+		Mpeg4FileID         string                `json:"mpeg4_file_id"`
+		Title               string                `json:"title,omitempty"`
+		Caption             string                `json:"caption,omitempty"`
+		ParseMode           string                `json:"parse_mode,omitempty"`
+		ReplyMarkup         *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
+		InputMessageContent InputMessageContent   `json:"input_message_content,omitempty"`
+	}
+
+	// InlineQueryResultCachedSticker Represents a link to a sticker stored on the Telegram servers.
+	InlineQueryResultCachedSticker struct {
+		ID                  string                `json:"id"`
+		StickerFileID       string                `json:"sticker_file_id"`
+		ReplyMarkup         *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
+		InputMessageContent InputMessageContent   `json:"input_message_content,omitempty"`
+	}
+
+	// InlineQueryResultCachedDocument Represents a link to a file stored on the Telegram servers.
+	InlineQueryResultCachedDocument struct {
+		ID                  string                `json:"id"`
+		Title               string                `json:"title"`
+		DocumentFileID      string                `json:"document_file_id"`
+		Description         string                `json:"description,omitempty"`
+		Caption             string                `json:"caption,omitempty"`
+		ParseMode           string                `json:"parse_mode,omitempty"`
+		ReplyMarkup         *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
+		InputMessageContent InputMessageContent   `json:"input_message_content,omitempty"`
+	}
+
+	// InlineQueryResultCachedVideo Represents a link to a video file stored on the Telegram servers.
+	InlineQueryResultCachedVideo struct {
+		ID                  string                `json:"id"`
+		VideoFileID         string                `json:"video_file_id"`
+		Title               string                `json:"title"`
+		Description         string                `json:"description,omitempty"`
+		Caption             string                `json:"caption,omitempty"`
+		ParseMode           string                `json:"parse_mode,omitempty"`
+		ReplyMarkup         *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
+		InputMessageContent InputMessageContent   `json:"input_message_content,omitempty"`
+	}
+
+	// InlineQueryResultCachedVoice Represents a link to a voice message stored on the Telegram servers.
+	InlineQueryResultCachedVoice struct {
+		ID                  string                `json:"id"`
+		VoiceFileID         string                `json:"voice_file_id"`
+		Title               string                `json:"title"`
+		Caption             string                `json:"caption,omitempty"`
+		ParseMode           string                `json:"parse_mode,omitempty"`
+		ReplyMarkup         *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
+		InputMessageContent InputMessageContent   `json:"input_message_content,omitempty"`
+	}
+
+	// InlineQueryResultCachedAudio Represents a link to an mp3 audio file stored on the Telegram servers.
+	InlineQueryResultCachedAudio struct {
+		ID                  string                `json:"id"`
+		AudioFileID         string                `json:"audio_file_id"`
+		Caption             string                `json:"caption,omitempty"`
+		ParseMode           string                `json:"parse_mode,omitempty"`
+		ReplyMarkup         *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
+		InputMessageContent InputMessageContent   `json:"input_message_content,omitempty"`
+	}
+
+	// InputTextMessageContent Represents the content of a text message to be sent as the result of an inline query.
+	InputTextMessageContent struct {
+		MessageText           string `json:"message_text"`
+		ParseMode             string `json:"parse_mode,omitempty"`
+		DisableWebPagePreview bool   `json:"disable_web_page_preview,omitempty"`
+	}
+
+	// InputLocationMessageContent Represents the content of a location message to be sent as the result of an inline query.
+	InputLocationMessageContent struct {
+		Latitude   float64 `json:"latitude"`
+		Longitude  float64 `json:"longitude"`
+		LivePeriod int     `json:"live_period,omitempty"`
+	}
+
+	// InputVenueMessageContent Represents the content of a venue message to be sent as the result of an inline query.
+	InputVenueMessageContent struct {
+		Latitude       float64 `json:"latitude"`
+		Longitude      float64 `json:"longitude"`
+		Title          string  `json:"title"`
+		Address        string  `json:"address"`
+		FoursquareID   string  `json:"foursquare_id,omitempty"`
+		FoursquareType string  `json:"foursquare_type,omitempty"`
+	}
+
+	// InputContactMessageContent Represents the content of a contact message to be sent as the result of an inline query.
+	InputContactMessageContent struct {
+		PhoneNumber string `json:"phone_number"`
+		FirstName   string `json:"first_name"`
+		LastName    string `json:"last_name,omitempty"`
+		Vcard       string `json:"vcard,omitempty"`
+	}
+)
+
+// ResultType type of result in the type field, empty if it isn't set
+func (body JSON) ResultType() string {
+	resultType, _ := body["type"].(string)
+	return resultType
+}
+
+// Validate JSON result isn't validated
+func (body JSON) Validate() error {
+	return nil
+}
+
+// ResultType article
+func (result InlineQueryResultArticle) ResultType() string {
+	return "article"
+}
+
+// Validate require id, title and input_message_content
+func (result InlineQueryResultArticle) Validate() error {
+	return requireResultFields(result, result.ID, result.InputMessageContent, "title", result.Title, "input_message_content", result.InputMessageContent)
+}
+
+// ResultType photo
+func (result InlineQueryResultPhoto) ResultType() string {
+	return "photo"
+}
+
+// Validate require id, photo_url and thumb_url
+func (result InlineQueryResultPhoto) Validate() error {
+	return requireResultFields(result, result.ID, result.InputMessageContent, "photo_url", result.PhotoURL, "thumb_url", result.ThumbURL)
+}
+
+// ResultType gif
+func (result InlineQueryResultGif) ResultType() string {
+	return "gif"
+}
+
+// Validate require id, gif_url and thumb_url
+func (result InlineQueryResultGif) Validate() error {
+	return requireResultFields(result, result.ID, result.InputMessageContent, "gif_url", result.GifURL, "thumb_url", result.ThumbURL)
+}
+
+// ResultType mpeg4_gif
+func (result InlineQueryResultMpeg4Gif) ResultType() string {
+	return "mpeg4_gif"
+}
+
+// Validate require id, mpeg4_url and thumb_url
+func (result InlineQueryResultMpeg4Gif) Validate() error {
+	return requireResultFields(result, result.ID, result.InputMessageContent, "mpeg4_url", result.Mpeg4URL, "thumb_url", result.ThumbURL)
+}
+
+// ResultType video
+func (result InlineQueryResultVideo) ResultType() string {
+	return "video"
+}
+
+// Validate require id, video_url, mime_type, thumb_url and title
+func (result InlineQueryResultVideo) Validate() error {
+	return requireResultFields(result, result.ID, result.InputMessageContent, "video_url", result.VideoURL,
+		"mime_type", result.MimeType, "thumb_url", result.ThumbURL, "title", result.Title)
+}
+
+// ResultType audio
+func (result InlineQueryResultAudio) ResultType() string {
+	return "audio"
+}
+
+// Validate require id, audio_url and title
+func (result InlineQueryResultAudio) Validate() error {
+	return requireResultFields(result, result.ID, result.InputMessageContent, "audio_url", result.AudioURL, "title", result.Title)
+}
+
+// ResultType voice
+func (result InlineQueryResultVoice) ResultType() string {
+	return "voice"
+}
+
+// Validate require id, voice_url and title
+func (result InlineQueryResultVoice) Validate() error {
+	return requireResultFields(result, result.ID, result.InputMessageContent, "voice_url", result.VoiceURL, "title", result.Title)
+}
+
+// ResultType document
+func (result InlineQueryResultDocument) ResultType() string {
+	return "document"
+}
+
+// Validate require id, title, document_url and mime_type
+func (result InlineQueryResultDocument) Validate() error {
+	return requireResultFields(result, result.ID, result.InputMessageContent, "title", result.Title,
+		"document_url", result.DocumentURL, "mime_type", result.MimeType)
+}
+
+// ResultType location
+func (result InlineQueryResultLocation) ResultType() string {
+	return "location"
+}
+
+// Validate require id and title
+func (result InlineQueryResultLocation) Validate() error {
+	return requireResultFields(result, result.ID, result.InputMessageContent, "title", result.Title)
+}
+
+// ResultType venue
+func (result InlineQueryResultVenue) ResultType() string {
+	return "venue"
+}
+
+// Validate require id, title and address
+func (result InlineQueryResultVenue) Validate() error {
+	return requireResultFields(result, result.ID, result.InputMessageContent, "title", result.Title, "address", result.Address)
+}
+
+// ResultType contact
+func (result InlineQueryResultContact) ResultType() string {
+	return "contact"
+}
+
+// Validate require id, phone_number and first_name
+func (result InlineQueryResultContact) Validate() error {
+	return requireResultFields(result, result.ID, result.InputMessageContent, "phone_number", result.PhoneNumber, "first_name", result.FirstName)
+}
+
+// ResultType game
+func (result InlineQueryResultGame) ResultType() string {
+	return "game"
+}
+
+// Validate require id and game_short_name
+func (result InlineQueryResultGame) Validate() error {
+	return requireResultFields(result, result.ID, nil, "game_short_name", result.GameShortName)
+}
+
+// ResultType photo
+func (result InlineQueryResultCachedPhoto) ResultType() string {
+	return "photo"
+}
+
+// Validate require id and photo_file_id
+func (result InlineQueryResultCachedPhoto) Validate() error {
+	return requireResultFields(result, result.ID, result.InputMessageContent, "photo_file_id", result.PhotoFileID)
+}
+
+// ResultType gif
+func (result InlineQueryResultCachedGif) ResultType() string {
+	return "gif"
+}
+
+// Validate require id and gif_file_id
+func (result InlineQueryResultCachedGif) Validate() error {
+	return requireResultFields(result, result.ID, result.InputMessageContent, "gif_file_id", result.GifFileID)
+}
+
+// ResultType mpeg4_gif
+func (result InlineQueryResultCachedMpeg4Gif) ResultType() string {
+	return "mpeg4_gif"
+}
+
+// Validate require id and mpeg4_file_id
+func (result InlineQueryResultCachedMpeg4Gif) Validate() error {
+	return requireResultFields(result, result.ID, result.InputMessageContent, "mpeg4_file_id", result.Mpeg4FileID)
+}
+
+// ResultType sticker
+func (result InlineQueryResultCachedSticker) ResultType() string {
+	return "sticker"
+}
+
+// Validate require id and sticker_file_id
+func (result InlineQueryResultCachedSticker) Validate() error {
+	return requireResultFields(result, result.ID, result.InputMessageContent, "sticker_file_id", result.StickerFileID)
+}
+
+// ResultType document
+func (result InlineQueryResultCachedDocument) ResultType() string {
+	return "document"
+}
+
+// Validate require id, title and document_file_id
+func (result InlineQueryResultCachedDocument) Validate() error {
+	return requireResultFields(result, result.ID, result.InputMessageContent, "title", result.Title, "document_file_id", result.DocumentFileID)
+}
+
+// ResultType video
+func (result InlineQueryResultCachedVideo) ResultType() string {
+	return "video"
+}
+
+// Validate require id, video_file_id and title
+func (result InlineQueryResultCachedVideo) Validate() error {
+	return requireResultFields(result, result.ID, result.InputMessageContent, "video_file_id", result.VideoFileID, "title", result.Title)
+}
+
+// ResultType voice
+func (result InlineQueryResultCachedVoice) ResultType() string {
+	return "voice"
+}
+
+// Validate require id, voice_file_id and title
+func (result InlineQueryResultCachedVoice) Validate() error {
+	return requireResultFields(result, result.ID, result.InputMessageContent, "voice_file_id", result.VoiceFileID, "title", result.Title)
+}
+
+// ResultType audio
+func (result InlineQueryResultCachedAudio) ResultType() string {
+	return "audio"
+}
+
+// Validate require id and audio_file_id
+func (result InlineQueryResultCachedAudio) Validate() error {
+	return requireResultFields(result, result.ID, result.InputMessageContent, "audio_file_id", result.AudioFileID)
+}
+
+// Validate require message_text up to 4096 characters
+func (content InputTextMessageContent) Validate() error {
+	if content.MessageText == "" {
+		return fmt.Errorf("telegraph: input text message content: message_text is required")
+	}
+	if length := len([]rune(content.MessageText)); length > 4096 {
+		return fmt.Errorf("telegraph: input text message content: message_text is %d characters, maximum is 4096", length)
+	}
+	return nil
+}
+
+// Validate location content has no required string field
+func (content InputLocationMessageContent) Validate() error {
+	return nil
+}
+
+// Validate require title and address
+func (content InputVenueMessageContent) Validate() error {
+	return requireContentFields("input venue message content", "title", content.Title, "address", content.Address)
+}
+
+// Validate require phone_number and first_name
+func (content InputContactMessageContent) Validate() error {
+	return requireContentFields("input contact message content", "phone_number", content.PhoneNumber, "first_name", content.FirstName)
+}
+
+// requireResultFields validate id, input message content and required fields, given as pairs of field name and value, are not empty
+func requireResultFields(result InlineQueryResult, id string, content InputMessageContent, fields ...interface{}) error {
+	if id == "" {
+		return fmt.Errorf("telegraph: inline query result %v: id is required", result.ResultType())
+	}
+	if len(id) > 64 {
+		return fmt.Errorf("telegraph: inline query result %v %q: id is %d bytes, maximum is 64", result.ResultType(), id, len(id))
+	}
+	if err := requireContentFields(fmt.Sprintf("inline query result %v %q", result.ResultType(), id), fields...); err != nil {
+		return err
+	}
+	if content != nil {
+		return content.Validate()
+	}
+
+	return nil
+}
+
+// requireContentFields return error for the first empty field, fields are pairs of field name and value
+func requireContentFields(name string, fields ...interface{}) error {
+	for i := 0; i+1 < len(fields); i += 2 {
+		switch value := fields[i+1].(type) {
+		case nil:
+			return fmt.Errorf("telegraph: %v: %v is required", name, fields[i])
+		case string:
+			if value == "" {
+				return fmt.Errorf("telegraph: %v: %v is required", name, fields[i])
+			}
+		}
+	}
+
+	return nil
+}
+
+// inlineQueryResultsJSON validate results and encode them with their result type
+func inlineQueryResultsJSON(results []InlineQueryResult) ([]JSON, error) {
+	bodies := make([]JSON, 0, len(results))
+	for _, result := range results {
+		if err := result.Validate(); err != nil {
+			return nil, err
+		}
+		if body, ok := result.(JSON); ok {
+			bodies = append(bodies, body)
+			continue
+		}
+
+		data, err := json.Marshal(result)
+		if err != nil {
+			return nil, err
+		}
+		body := JSON{}
+		if err := json.Unmarshal(data, &body); err != nil {
+			return nil, err
+		}
+		body["type"] = result.ResultType()
+		bodies = append(bodies, body)
+	}
+
+	return bodies, nil
+}
@@ -0,0 +1,84 @@
+package telegraph_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"telegraph"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnswerInlineQuery_TypedResults(t *testing.T) {
+	var req *http.Request
+	var body []byte
+	client := telegraph.NewClient("token", telegraph.WithTransport(captureTransport(&req, &body)))
+
+	_, _, err := client.AnswerInlineQuery("123123123",
+		telegraph.InlineQueryResultArticle{
+			ID:                  "1",
+			Title:               "Cubesoft",
+			InputMessageContent: telegraph.InputTextMessageContent{MessageText: "*Cubesoft*", ParseMode: "Markdown"},
+			ReplyMarkup: &telegraph.InlineKeyboardMarkup{InlineKeyboard: [][]telegraph.InlineKeyboardButton{
+				{{Text: "Open", URL: "https://www.cubesoft.co.id"}},
+			}},
+		},
+		telegraph.InlineQueryResultCachedSticker{ID: "2", StickerFileID: "CAADBQADBgADkvulAumgmwOAjdfYAg"},
+		telegraph.JSON{"type": "game", "id": "3", "game_short_name": "cube"},
+	).SetCacheTime(10).Commit()
+	assert.NoError(t, err)
+
+	model := struct {
+		Results []map[string]interface{} `json:"results"`
+	}{}
+	assert.NoError(t, json.Unmarshal(body, &model))
+	assert.Len(t, model.Results, 3)
+	assert.Equal(t, "article", model.Results[0]["type"])
+	assert.Equal(t, map[string]interface{}{"message_text": "*Cubesoft*", "parse_mode": "Markdown"}, model.Results[0]["input_message_content"])
+	assert.NotContains(t, model.Results[0], "url")
+	assert.Equal(t, "sticker", model.Results[1]["type"])
+	assert.Equal(t, "CAADBQADBgADkvulAumgmwOAjdfYAg", model.Results[1]["sticker_file_id"])
+	assert.Equal(t, "game", model.Results[2]["type"])
+}
+
+func TestAnswerInlineQuery_ValidationError(t *testing.T) {
+	var req *http.Request
+	var body []byte
+	client := telegraph.NewClient("token", telegraph.WithTransport(captureTransport(&req, &body)))
+
+	_, _, err := client.AnswerInlineQuery("123123123",
+		telegraph.InlineQueryResultPhoto{ID: "1", PhotoURL: "https://www.cubesoft.co.id/photo.jpg"},
+	).Commit()
+
+	assert.EqualError(t, err, `telegraph: inline query result photo "1": thumb_url is required`)
+	assert.Nil(t, req)
+}
+
+func TestInlineQueryResult_Validate(t *testing.T) {
+	cases := []struct {
+		result telegraph.InlineQueryResult
+		err    string
+	}{
+		{telegraph.InlineQueryResultArticle{Title: "title"}, "id is required"},
+		{telegraph.InlineQueryResultArticle{ID: strings.Repeat("a", 65)}, "maximum is 64"},
+		{telegraph.InlineQueryResultArticle{ID: "1", Title: "title"}, "input_message_content is required"},
+		{telegraph.InlineQueryResultArticle{ID: "1", Title: "title", InputMessageContent: telegraph.InputTextMessageContent{}}, "message_text is required"},
+		{telegraph.InlineQueryResultVenue{ID: "1", Title: "Cubesoft"}, "address is required"},
+		{telegraph.InlineQueryResultContact{ID: "1", PhoneNumber: "+62", FirstName: "Cube",
+			InputMessageContent: telegraph.InputContactMessageContent{PhoneNumber: "+62"}}, "first_name is required"},
+		{telegraph.InlineQueryResultVideo{ID: "1", VideoURL: "https://www.cubesoft.co.id/video.mp4", ThumbURL: "https://www.cubesoft.co.id/thumb.jpg", Title: "video"}, "mime_type is required"},
+		{telegraph.InlineQueryResultCachedAudio{ID: "1", AudioFileID: "file_1"}, ""},
+		{telegraph.InlineQueryResultLocation{ID: "1", Title: "Cubesoft", InputMessageContent: telegraph.InputLocationMessageContent{Latitude: -6.2, Longitude: 106.8}}, ""},
+		{telegraph.InlineQueryResultGame{ID: "1", GameShortName: "cube"}, ""},
+	}
+
+	for _, c := range cases {
+		err := c.result.Validate()
+		if c.err == "" {
+			assert.NoError(t, err)
+		} else if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), c.err)
+		}
+	}
+}
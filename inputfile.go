@@ -0,0 +1,184 @@
+package telegraph
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+
+	"github.com/parnurzeal/gorequest"
+)
+
+// ErrFileTooLarge returned by Commit when uploaded file exceeds the upload limit, the file is not sent
+var ErrFileTooLarge = errors.New("telegraph: file too large")
+
+// errUploadConsumed returned when reader of the file is already sent by previous attempt
+var errUploadConsumed = errors.New("telegraph: upload reader can't be sent twice")
+
+const (
+	fileRemote fileKind = iota
+	filePath
+	fileReader
+	fileBytes
+)
+
+// fileKind how the content of InputFile is sent
+type fileKind int
+
+// InputFile file sent to telegram, create it with FileID, FileURL, FilePath, FileReader or FileBytes
+// so the way the file is sent is chosen explicitly. Uploaded file is streamed to telegram without being buffered in memory
+type InputFile struct {
+	kind     fileKind
+	value    string
+	name     string
+	reader   io.Reader
+	data     []byte
+	size     int64
+	consumed int32
+	progress func(sent, total int64)
+}
+
+// FileID file that already exists on the telegram servers
+func FileID(fileID string) *InputFile {
+	return &InputFile{kind: fileRemote, value: fileID}
+}
+
+// FileURL file telegram download from the internet
+func FileURL(url string) *InputFile {
+	return &InputFile{kind: fileRemote, value: url}
+}
+
+// FilePath local file uploaded using multipart/form-data
+func FilePath(path string) *InputFile {
+	return &InputFile{kind: filePath, value: path, name: filepath.Base(path), size: -1}
+}
+
+// FileReader file read from reader and uploaded using multipart/form-data with name as file name.
+// The reader can be sent only once, so the request isn't retried once the reader is read.
+// Size is detected from *os.File and readers with Len method such as *bytes.Reader, use SetSize for other readers
+func FileReader(name string, reader io.Reader) *InputFile {
+	return &InputFile{kind: fileReader, name: name, reader: reader, size: readerSize(reader)}
+}
+
+// FileBytes file content uploaded using multipart/form-data with name as file name, nil data is uploaded as empty file
+func FileBytes(name string, data []byte) *InputFile {
+	return &InputFile{kind: fileBytes, name: name, data: data, size: int64(len(data))}
+}
+
+// SetSize size of the file read from reader in bytes, used to check upload limit before the upload start
+// and as total of upload progress
+func (file *InputFile) SetSize(size int64) *InputFile {
+	file.size = size
+	return file
+}
+
+// SetProgress call fn while the file is uploaded with bytes sent so far and total size, total is -1 when size is unknown
+func (file *InputFile) SetProgress(fn func(sent, total int64)) *InputFile {
+	file.progress = fn
+	return file
+}
+
+// IsUpload report whether the file content is uploaded instead of passing file id or url
+func (file *InputFile) IsUpload() bool {
+	return file.kind != fileRemote
+}
+
+// attach add file to request as field, uploaded file is kept in request data and streamed when request is sent
+func (file *InputFile) attach(request *gorequest.SuperAgent, field string) *gorequest.SuperAgent {
+	if !file.IsUpload() {
+		return request.Send(JSON{field: file.value})
+	}
+
+	request = request.Type(gorequest.TypeMultipart)
+	if request.Data == nil {
+		request.Data = map[string]interface{}{}
+	}
+	request.Data[field] = file
+
+	return request
+}
+
+// stat size of the file in bytes, -1 if unknown
+func (file *InputFile) stat() (int64, error) {
+	if file.kind != filePath {
+		return file.size, nil
+	}
+
+	info, err := os.Stat(file.value)
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+// check return ErrFileTooLarge when file is larger than limit, zero limit means unlimited
+func (file *InputFile) check(field string, limit int64) error {
+	size, err := file.stat()
+	if err != nil {
+		return err
+	}
+	if limit > 0 && size > limit {
+		return fmt.Errorf("%w: %v is %d bytes, limit is %d bytes", ErrFileTooLarge, field, size, limit)
+	}
+
+	return nil
+}
+
+// open return content of the file to upload, reader is marked consumed once it is read
+func (file *InputFile) open() (io.ReadCloser, int64, error) {
+	switch file.kind {
+	case fileReader:
+		if atomic.LoadInt32(&file.consumed) != 0 {
+			return nil, 0, errUploadConsumed
+		}
+		return ioutil.NopCloser(&consumingReader{file: file}), file.size, nil
+	case fileBytes:
+		return ioutil.NopCloser(bytes.NewReader(file.data)), file.size, nil
+	}
+
+	f, err := os.Open(file.value)
+	if err != nil {
+		return nil, 0, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, 0, err
+	}
+	return f, info.Size(), nil
+}
+
+// repeatable report whether the file can be sent again
+func (file *InputFile) repeatable() bool {
+	return file.kind != fileReader || atomic.LoadInt32(&file.consumed) == 0
+}
+
+// consumingReader mark the file consumed on the first read, request failed before the upload started can be retried
+type consumingReader struct {
+	file *InputFile
+}
+
+func (r *consumingReader) Read(p []byte) (int, error) {
+	atomic.StoreInt32(&r.file.consumed, 1)
+	return r.file.reader.Read(p)
+}
+
+func readerSize(reader io.Reader) int64 {
+	switch r := reader.(type) {
+	case interface{ Len() int }:
+		return int64(r.Len())
+	case *os.File:
+		if info, err := r.Stat(); err == nil && info.Mode().IsRegular() {
+			offset, err := r.Seek(0, io.SeekCurrent)
+			if err == nil {
+				return info.Size() - offset
+			}
+		}
+	}
+
+	return -1
+}
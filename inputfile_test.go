@@ -0,0 +1,151 @@
+package telegraph_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"telegraph"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func captureTransport(captured **http.Request, body *[]byte) roundTripFunc {
+	return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		*captured = req
+		var err error
+		if *body, err = ioutil.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       ioutil.NopCloser(strings.NewReader(`{"ok": true, "result": {"message_id": 1}}`)),
+			Request:    req,
+		}, nil
+	})
+}
+
+func multipartFiles(t *testing.T, req *http.Request, body []byte) (map[string]string, map[string]string) {
+	_, params, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	assert.NoError(t, err)
+
+	fields, files := map[string]string{}, map[string]string{}
+	reader := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			break
+		}
+		content, _ := ioutil.ReadAll(part)
+		if part.FileName() != "" {
+			files[part.FormName()] = part.FileName() + ":" + string(content)
+		} else {
+			fields[part.FormName()] = string(content)
+		}
+	}
+
+	return fields, files
+}
+
+func TestInputFile_Bytes(t *testing.T) {
+	var req *http.Request
+	var body []byte
+	client := telegraph.NewClient("token", telegraph.WithTransport(captureTransport(&req, &body)))
+
+	_, _, err := client.SendPhoto(2434234, telegraph.FileBytes("chart.png", []byte("png content"))).SetCaption("chart").Commit()
+	assert.NoError(t, err)
+
+	fields, files := multipartFiles(t, req, body)
+	assert.Equal(t, "chart.png:png content", files["photo"])
+	assert.Equal(t, "chart", fields["caption"])
+	assert.NotContains(t, fields, "photo")
+}
+
+func TestInputFile_EmptyBytes(t *testing.T) {
+	var req *http.Request
+	var body []byte
+	client := telegraph.NewClient("token", telegraph.WithTransport(captureTransport(&req, &body)))
+
+	_, _, err := client.SendDocument(2434234, telegraph.FileBytes("empty.txt", nil)).Commit()
+	assert.NoError(t, err)
+
+	_, files := multipartFiles(t, req, body)
+	assert.Equal(t, "empty.txt:", files["document"])
+}
+
+func TestInputFile_Reader(t *testing.T) {
+	var req *http.Request
+	var body []byte
+	client := telegraph.NewClient("token", telegraph.WithTransport(captureTransport(&req, &body)))
+
+	_, _, err := client.SendDocument(2434234, telegraph.FileReader("report.csv", strings.NewReader("a,b"))).Commit()
+	assert.NoError(t, err)
+
+	_, files := multipartFiles(t, req, body)
+	assert.Equal(t, "report.csv:a,b", files["document"])
+}
+
+func TestInputFile_ReaderError(t *testing.T) {
+	var req *http.Request
+	var body []byte
+	client := telegraph.NewClient("token", telegraph.WithTransport(captureTransport(&req, &body)), telegraph.WithRetryPolicy(telegraph.NoRetry))
+
+	readErr := errors.New("storage unavailable")
+	_, _, err := client.SendDocument(2434234, telegraph.FileReader("report.csv", iotest.ErrReader(readErr))).Commit()
+
+	assert.True(t, errors.Is(err, readErr))
+}
+
+func TestInputFile_ReaderRetryBeforeRead(t *testing.T) {
+	var req *http.Request
+	var body []byte
+	capture := captureTransport(&req, &body)
+	attempts := 0
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		attempts++
+		if attempts == 1 {
+			return nil, errors.New("dial tcp: connection refused")
+		}
+		return capture(r)
+	})
+	retry := telegraph.RetryPolicyFunc(func(attempt int, elapsed time.Duration, err error) (time.Duration, bool) {
+		return 0, attempt < 3
+	})
+	client := telegraph.NewClient("token", telegraph.WithTransport(transport), telegraph.WithRetryPolicy(retry))
+
+	_, _, err := client.SendDocument(2434234, telegraph.FileReader("report.csv", strings.NewReader("a,b"))).Commit()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+
+	_, files := multipartFiles(t, req, body)
+	assert.Equal(t, "report.csv:a,b", files["document"])
+}
+
+func TestInputFile_FileIDAndURL(t *testing.T) {
+	var req *http.Request
+	var body []byte
+	client := telegraph.NewClient("token", telegraph.WithTransport(captureTransport(&req, &body)))
+
+	_, _, err := client.SendSticker(2434234, telegraph.FileID("CAADBQADBgADkvulAumgmwOAjdfYAg")).Commit()
+	assert.NoError(t, err)
+
+	model := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal(body, &model))
+	assert.Equal(t, "CAADBQADBgADkvulAumgmwOAjdfYAg", model["sticker"])
+
+	_, _, err = client.SendPhoto(2434234, telegraph.FileURL("https://www.cubesoft.co.id/logo.png")).Commit()
+	assert.NoError(t, err)
+
+	assert.NoError(t, json.Unmarshal(body, &model))
+	assert.Equal(t, "https://www.cubesoft.co.id/logo.png", model["photo"])
+	assert.False(t, telegraph.FileURL("https://www.cubesoft.co.id/logo.png").IsUpload())
+	assert.True(t, telegraph.FilePath("./LICENSE").IsUpload())
+}
@@ -0,0 +1,328 @@
+/*
+Package keyboard build inline and reply keyboard of telegram message with automatic wrapping,
+pagination and validation of telegram limits.
+
+	markup, err := keyboard.NewInline().SetColumns(3).
+		Add(keyboard.Callback("1", "vote:1"), keyboard.Callback("2", "vote:2"), keyboard.Callback("3", "vote:3")).
+		Row(keyboard.URL("Website", "https://www.cubesoft.co.id")).
+		Build()
+*/
+package keyboard
+
+import (
+	"errors"
+	"fmt"
+	"telegraph"
+	"unicode/utf8"
+)
+
+const (
+	// MaxCallbackData maximum size of callback data in bytes
+	MaxCallbackData = 64
+	// MaxRowButtons maximum number of buttons in a row
+	MaxRowButtons = 8
+	// MaxButtons maximum number of buttons in a keyboard
+	MaxButtons = 100
+)
+
+// ErrInvalidButton returned by Build when button or keyboard doesn't follow telegram limits
+var ErrInvalidButton = errors.New("keyboard: invalid button")
+
+type (
+	// Inline builder of telegraph.InlineKeyboardMarkup
+	Inline struct {
+		rows    [][]telegraph.InlineKeyboardButton
+		columns int
+		wrap    bool
+	}
+
+	// Reply builder of telegraph.ReplyKeyboardMarkup
+	Reply struct {
+		rows    [][]telegraph.KeyboardButton
+		columns int
+		wrap    bool
+		markup  telegraph.ReplyKeyboardMarkup
+	}
+)
+
+// URL inline button opening url
+func URL(text, url string) telegraph.InlineKeyboardButton {
+	return telegraph.InlineKeyboardButton{Text: text, URL: url}
+}
+
+// Callback inline button sending callback query with data, up to 64 bytes
+func Callback(text, data string) telegraph.InlineKeyboardButton {
+	return telegraph.InlineKeyboardButton{Text: text, CallbackData: data}
+}
+
+// SwitchInline inline button prompting the user to select a chat and insert bot username and query in the input field,
+// empty query insert only the bot username
+func SwitchInline(text, query string) telegraph.InlineKeyboardButton {
+	return telegraph.InlineKeyboardButton{Text: text, SwitchInlineQuery: &query}
+}
+
+// SwitchInlineCurrentChat inline button inserting bot username and query in the input field of the current chat,
+// empty query insert only the bot username
+func SwitchInlineCurrentChat(text, query string) telegraph.InlineKeyboardButton {
+	return telegraph.InlineKeyboardButton{Text: text, SwitchInlineQueryCurrentChat: &query}
+}
+
+// Game inline button launching the game, must be the first button in the first row
+func Game(text string) telegraph.InlineKeyboardButton {
+	return telegraph.InlineKeyboardButton{Text: text, CallbackGame: &telegraph.CallbackGame{}}
+}
+
+// Pay inline button paying the invoice, must be the first button in the first row
+func Pay(text string) telegraph.InlineKeyboardButton {
+	return telegraph.InlineKeyboardButton{Text: text, Pay: true}
+}
+
+// Text reply button sending its text
+func Text(text string) telegraph.KeyboardButton {
+	return telegraph.KeyboardButton{Text: text}
+}
+
+// RequestContact reply button sending the user phone number
+func RequestContact(text string) telegraph.KeyboardButton {
+	return telegraph.KeyboardButton{Text: text, RequestContact: true}
+}
+
+// RequestLocation reply button sending the user location
+func RequestLocation(text string) telegraph.KeyboardButton {
+	return telegraph.KeyboardButton{Text: text, RequestLocation: true}
+}
+
+// NewInline create empty inline keyboard
+func NewInline() *Inline {
+	return &Inline{}
+}
+
+// SetColumns wrap buttons added with Add into a new row after columns buttons, zero means no wrapping
+func (inline *Inline) SetColumns(columns int) *Inline {
+	inline.columns = columns
+	return inline
+}
+
+// Add append buttons to the last row, starting a new row when the row has reached the columns
+func (inline *Inline) Add(buttons ...telegraph.InlineKeyboardButton) *Inline {
+	for _, button := range buttons {
+		last := len(inline.rows) - 1
+		if last < 0 || inline.wrap || (inline.columns > 0 && len(inline.rows[last]) >= inline.columns) {
+			inline.rows = append(inline.rows, nil)
+			inline.wrap = false
+			last++
+		}
+		inline.rows[last] = append(inline.rows[last], button)
+	}
+
+	return inline
+}
+
+// Row append buttons as a new row, buttons added later with Add start another row
+func (inline *Inline) Row(buttons ...telegraph.InlineKeyboardButton) *Inline {
+	if len(buttons) > 0 {
+		inline.rows = append(inline.rows, buttons)
+		inline.wrap = true
+	}
+
+	return inline
+}
+
+// Pagination append a row navigating from current page to other pages, see Pagination
+func (inline *Inline) Pagination(current, total int, data func(page int) string) *Inline {
+	return inline.Row(Pagination(current, total, data)...)
+}
+
+// Build validate the keyboard and return the markup
+func (inline *Inline) Build() (telegraph.InlineKeyboardMarkup, error) {
+	count := 0
+	for i, row := range inline.rows {
+		if len(row) > MaxRowButtons {
+			return telegraph.InlineKeyboardMarkup{}, fmt.Errorf("%w: row %d has %d buttons, maximum is %d", ErrInvalidButton, i+1, len(row), MaxRowButtons)
+		}
+		for j, button := range row {
+			count++
+			if err := validateInline(button, i == 0 && j == 0); err != nil {
+				return telegraph.InlineKeyboardMarkup{}, fmt.Errorf("%w: row %d column %d: %v", ErrInvalidButton, i+1, j+1, err)
+			}
+		}
+	}
+	if count > MaxButtons {
+		return telegraph.InlineKeyboardMarkup{}, fmt.Errorf("%w: keyboard has %d buttons, maximum is %d", ErrInvalidButton, count, MaxButtons)
+	}
+
+	return telegraph.InlineKeyboardMarkup{InlineKeyboard: inline.rows}, nil
+}
+
+// NewReply create empty reply keyboard
+func NewReply() *Reply {
+	return &Reply{}
+}
+
+// SetColumns wrap buttons added with Add into a new row after columns buttons, zero means no wrapping
+func (reply *Reply) SetColumns(columns int) *Reply {
+	reply.columns = columns
+	return reply
+}
+
+// SetResize request clients to resize the keyboard vertically for optimal fit
+func (reply *Reply) SetResize(resize bool) *Reply {
+	reply.markup.ResizeKeyboard = resize
+	return reply
+}
+
+// SetOneTime request clients to hide the keyboard as soon as it's been used
+func (reply *Reply) SetOneTime(oneTime bool) *Reply {
+	reply.markup.OneTimeKeyboard = oneTime
+	return reply
+}
+
+// SetSelective show the keyboard to specific users only, mentioned users and sender of the replied message
+func (reply *Reply) SetSelective(selective bool) *Reply {
+	reply.markup.Selective = selective
+	return reply
+}
+
+// Add append buttons to the last row, starting a new row when the row has reached the columns
+func (reply *Reply) Add(buttons ...telegraph.KeyboardButton) *Reply {
+	for _, button := range buttons {
+		last := len(reply.rows) - 1
+		if last < 0 || reply.wrap || (reply.columns > 0 && len(reply.rows[last]) >= reply.columns) {
+			reply.rows = append(reply.rows, nil)
+			reply.wrap = false
+			last++
+		}
+		reply.rows[last] = append(reply.rows[last], button)
+	}
+
+	return reply
+}
+
+// Row append buttons as a new row, buttons added later with Add start another row
+func (reply *Reply) Row(buttons ...telegraph.KeyboardButton) *Reply {
+	if len(buttons) > 0 {
+		reply.rows = append(reply.rows, buttons)
+		reply.wrap = true
+	}
+
+	return reply
+}
+
+// Build validate the keyboard and return the markup
+func (reply *Reply) Build() (telegraph.ReplyKeyboardMarkup, error) {
+	markup := reply.markup
+	count := 0
+	for i, row := range reply.rows {
+		if len(row) > MaxRowButtons {
+			return telegraph.ReplyKeyboardMarkup{}, fmt.Errorf("%w: row %d has %d buttons, maximum is %d", ErrInvalidButton, i+1, len(row), MaxRowButtons)
+		}
+		for j, button := range row {
+			count++
+			if button.Text == "" {
+				return telegraph.ReplyKeyboardMarkup{}, fmt.Errorf("%w: row %d column %d: text is required", ErrInvalidButton, i+1, j+1)
+			}
+			if button.RequestContact && button.RequestLocation {
+				return telegraph.ReplyKeyboardMarkup{}, fmt.Errorf("%w: row %d column %d: request_contact and request_location are exclusive", ErrInvalidButton, i+1, j+1)
+			}
+		}
+	}
+	if count > MaxButtons {
+		return telegraph.ReplyKeyboardMarkup{}, fmt.Errorf("%w: keyboard has %d buttons, maximum is %d", ErrInvalidButton, count, MaxButtons)
+	}
+	markup.Keyboard = reply.rows
+
+	return markup, nil
+}
+
+// validateInline check button has text and exactly one optional field, pay and game button must be the first button
+func validateInline(button telegraph.InlineKeyboardButton, first bool) error {
+	if button.Text == "" {
+		return errors.New("text is required")
+	}
+
+	fields := 0
+	for _, set := range []bool{
+		button.URL != "",
+		button.CallbackData != "",
+		button.SwitchInlineQuery != nil,
+		button.SwitchInlineQueryCurrentChat != nil,
+		button.CallbackGame != nil,
+		button.Pay,
+	} {
+		if set {
+			fields++
+		}
+	}
+	if fields != 1 {
+		return fmt.Errorf("button %q must have exactly one of url, callback_data, switch_inline_query, "+
+			"switch_inline_query_current_chat, callback_game or pay, it has %d", button.Text, fields)
+	}
+
+	if len(button.CallbackData) > MaxCallbackData {
+		return fmt.Errorf("callback_data of button %q is %d bytes, maximum is %d", button.Text, len(button.CallbackData), MaxCallbackData)
+	}
+	if !utf8.ValidString(button.CallbackData) {
+		return fmt.Errorf("callback_data of button %q is not valid UTF-8", button.Text)
+	}
+	if (button.Pay || button.CallbackGame != nil) && !first {
+		return fmt.Errorf("pay or game button %q must be the first button in the first row", button.Text)
+	}
+
+	return nil
+}
+
+/*
+Pagination navigation buttons from current page to the other pages, data return callback data of a page.
+Up to five pages are shown as is, more pages are shown relative to current page with the first and last page, e.g.
+
+	« 1   ‹ 4   · 5 ·   6 ›   10 »
+*/
+func Pagination(current, total int, data func(page int) string) []telegraph.InlineKeyboardButton {
+	if total <= 1 {
+		return nil
+	}
+	if current < 1 {
+		current = 1
+	}
+	if current > total {
+		current = total
+	}
+
+	button := func(page int, label string) telegraph.InlineKeyboardButton {
+		return Callback(label, data(page))
+	}
+	label := func(page int) string {
+		if page == current {
+			return fmt.Sprintf("· %d ·", page)
+		}
+		return fmt.Sprint(page)
+	}
+
+	buttons := []telegraph.InlineKeyboardButton{}
+	switch {
+	case total <= 5:
+		for page := 1; page <= total; page++ {
+			buttons = append(buttons, button(page, label(page)))
+		}
+	case current <= 3:
+		for page := 1; page <= 3; page++ {
+			buttons = append(buttons, button(page, label(page)))
+		}
+		buttons = append(buttons, button(4, "4 ›"), button(total, fmt.Sprintf("%d »", total)))
+	case current > total-3:
+		buttons = append(buttons, button(1, "« 1"), button(total-3, fmt.Sprintf("‹ %d", total-3)))
+		for page := total - 2; page <= total; page++ {
+			buttons = append(buttons, button(page, label(page)))
+		}
+	default:
+		buttons = append(buttons,
+			button(1, "« 1"),
+			button(current-1, fmt.Sprintf("‹ %d", current-1)),
+			button(current, label(current)),
+			button(current+1, fmt.Sprintf("%d ›", current+1)),
+			button(total, fmt.Sprintf("%d »", total)),
+		)
+	}
+
+	return buttons
+}
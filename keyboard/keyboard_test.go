@@ -0,0 +1,121 @@
+package keyboard_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"telegraph"
+	"telegraph/keyboard"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInlineColumns(t *testing.T) {
+	markup, err := keyboard.NewInline().SetColumns(2).
+		Add(keyboard.Callback("1", "1"), keyboard.Callback("2", "2"), keyboard.Callback("3", "3")).
+		Row(keyboard.URL("Website", "https://www.cubesoft.co.id")).
+		Add(keyboard.SwitchInline("Share", "query"), keyboard.SwitchInlineCurrentChat("Search", "query")).
+		Build()
+
+	query := "query"
+	assert.NoError(t, err)
+	assert.Equal(t, [][]telegraph.InlineKeyboardButton{
+		{{Text: "1", CallbackData: "1"}, {Text: "2", CallbackData: "2"}},
+		{{Text: "3", CallbackData: "3"}},
+		{{Text: "Website", URL: "https://www.cubesoft.co.id"}},
+		{{Text: "Share", SwitchInlineQuery: &query}, {Text: "Search", SwitchInlineQueryCurrentChat: &query}},
+	}, markup.InlineKeyboard)
+}
+
+func TestInlineEmptySwitchQuery(t *testing.T) {
+	markup, err := keyboard.NewInline().Add(keyboard.SwitchInline("Share", ""), keyboard.Callback("Help", "help")).Build()
+	assert.NoError(t, err)
+
+	content, err := json.Marshal(markup)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"inline_keyboard":[[{"text":"Share","switch_inline_query":""},{"text":"Help","callback_data":"help"}]]}`, string(content))
+}
+
+func TestInlineValidation(t *testing.T) {
+	cases := map[string]*keyboard.Inline{
+		"no text":        keyboard.NewInline().Add(keyboard.Callback("", "data")),
+		"no field":       keyboard.NewInline().Add(telegraph.InlineKeyboardButton{Text: "text"}),
+		"two fields":     keyboard.NewInline().Add(telegraph.InlineKeyboardButton{Text: "text", URL: "https://example.com", CallbackData: "data"}),
+		"long data":      keyboard.NewInline().Add(keyboard.Callback("text", strings.Repeat("a", keyboard.MaxCallbackData+1))),
+		"pay not first":  keyboard.NewInline().Add(keyboard.Callback("text", "data"), keyboard.Pay("Pay")),
+		"game not first": keyboard.NewInline().Add(keyboard.Callback("text", "data")).Row(keyboard.Game("Play")),
+		"wide row":       keyboard.NewInline().Add(make9()...),
+	}
+
+	for name, inline := range cases {
+		_, err := inline.Build()
+		assert.True(t, errors.Is(err, keyboard.ErrInvalidButton), name)
+	}
+
+	_, err := keyboard.NewInline().Add(keyboard.Pay("Pay"), keyboard.Callback("text", strings.Repeat("a", keyboard.MaxCallbackData))).Build()
+	assert.NoError(t, err)
+}
+
+func TestReply(t *testing.T) {
+	markup, err := keyboard.NewReply().SetColumns(2).SetResize(true).SetOneTime(true).
+		Add(keyboard.Text("Yes"), keyboard.Text("No"), keyboard.Text("Maybe")).
+		Row(keyboard.RequestContact("Contact"), keyboard.RequestLocation("Location")).
+		Build()
+
+	assert.NoError(t, err)
+	assert.True(t, markup.ResizeKeyboard)
+	assert.True(t, markup.OneTimeKeyboard)
+	assert.False(t, markup.Selective)
+	assert.Equal(t, [][]telegraph.KeyboardButton{
+		{{Text: "Yes"}, {Text: "No"}},
+		{{Text: "Maybe"}},
+		{{Text: "Contact", RequestContact: true}, {Text: "Location", RequestLocation: true}},
+	}, markup.Keyboard)
+
+	_, err = keyboard.NewReply().Add(telegraph.KeyboardButton{Text: "both", RequestContact: true, RequestLocation: true}).Build()
+	assert.True(t, errors.Is(err, keyboard.ErrInvalidButton))
+
+	_, err = keyboard.NewReply().Add(keyboard.Text("")).Build()
+	assert.True(t, errors.Is(err, keyboard.ErrInvalidButton))
+
+	large := keyboard.NewReply()
+	for i := 0; i <= keyboard.MaxButtons; i++ {
+		large.Row(keyboard.Text("button"))
+	}
+	_, err = large.Build()
+	assert.True(t, errors.Is(err, keyboard.ErrInvalidButton))
+}
+
+func TestPagination(t *testing.T) {
+	data := func(page int) string {
+		return fmt.Sprintf("page:%d", page)
+	}
+	labels := func(buttons []telegraph.InlineKeyboardButton) []string {
+		result := []string{}
+		for _, button := range buttons {
+			result = append(result, button.Text+"="+button.CallbackData)
+		}
+		return result
+	}
+
+	assert.Empty(t, keyboard.Pagination(1, 1, data))
+	assert.Equal(t, []string{"1=page:1", "· 2 ·=page:2", "3=page:3"}, labels(keyboard.Pagination(2, 3, data)))
+	assert.Equal(t, []string{"1=page:1", "· 2 ·=page:2", "3=page:3", "4 ›=page:4", "10 »=page:10"}, labels(keyboard.Pagination(2, 10, data)))
+	assert.Equal(t, []string{"« 1=page:1", "‹ 4=page:4", "· 5 ·=page:5", "6 ›=page:6", "10 »=page:10"}, labels(keyboard.Pagination(5, 10, data)))
+	assert.Equal(t, []string{"« 1=page:1", "‹ 7=page:7", "8=page:8", "9=page:9", "· 10 ·=page:10"}, labels(keyboard.Pagination(12, 10, data)))
+
+	markup, err := keyboard.NewInline().Add(keyboard.Callback("Item", "item")).Pagination(5, 10, data).Build()
+	assert.NoError(t, err)
+	assert.Len(t, markup.InlineKeyboard, 2)
+	assert.Len(t, markup.InlineKeyboard[1], 5)
+}
+
+func make9() []telegraph.InlineKeyboardButton {
+	buttons := []telegraph.InlineKeyboardButton{}
+	for i := 0; i < keyboard.MaxRowButtons+1; i++ {
+		buttons = append(buttons, keyboard.Callback(fmt.Sprint(i), fmt.Sprint(i)))
+	}
+	return buttons
+}
@@ -0,0 +1,37 @@
+package telegraph
+
+import (
+	"errors"
+
+	"github.com/parnurzeal/gorequest"
+)
+
+// ErrConflictingReplyMarkup returned by Commit when more than one reply markup is set to a request
+var ErrConflictingReplyMarkup = errors.New("telegraph: reply markup is set more than once")
+
+// ReplyMarkup additional interface options of a message, one of InlineKeyboardMarkup, ReplyKeyboardMarkup,
+// ReplyKeyboardRemove or ForceReply. A message can have only one reply markup
+type ReplyMarkup interface {
+	replyMarkup()
+}
+
+func (InlineKeyboardMarkup) replyMarkup() {}
+
+func (ReplyKeyboardMarkup) replyMarkup() {}
+
+func (ReplyKeyboardRemove) replyMarkup() {}
+
+func (ForceReply) replyMarkup() {}
+
+// sendReplyMarkup set reply_markup of request, ErrConflictingReplyMarkup is returned at commit when it is already set
+func sendReplyMarkup(request *gorequest.SuperAgent, markup ReplyMarkup) *gorequest.SuperAgent {
+	if markup == nil {
+		return request
+	}
+	if _, ok := request.Data["reply_markup"]; ok {
+		request.Errors = append(request.Errors, ErrConflictingReplyMarkup)
+		return request
+	}
+
+	return request.Send(JSON{"reply_markup": markup})
+}
@@ -0,0 +1,58 @@
+package telegraph_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"telegraph"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetReplyMarkup_Kinds(t *testing.T) {
+	var req *http.Request
+	var body []byte
+	client := telegraph.NewClient("token", telegraph.WithTransport(captureTransport(&req, &body)))
+
+	keyboard := [][]telegraph.InlineKeyboardButton{{{Text: "Vote", CallbackData: "vote:1"}}}
+	cases := []struct {
+		message  *telegraph.MessageResponse
+		expected string
+	}{
+		{client.SendMessage(2434234, "test").SetReplyMarkup(telegraph.ForceReply{ForceReply: true}), `{"force_reply": true}`},
+		{client.SendMessage(2434234, "test").SetForceReply(telegraph.ForceReply{ForceReply: true}), `{"force_reply": true}`},
+		{client.SendMessage(2434234, "test").SetInlineKeyboardMarkup(keyboard),
+			`{"inline_keyboard": [[{"text": "Vote", "callback_data": "vote:1"}]]}`},
+		{client.SendMessage(2434234, "test").SetReplyKeyboardMarkup(telegraph.ReplyKeyboardMarkup{
+			Keyboard: [][]telegraph.KeyboardButton{{{Text: "Yes"}}}, OneTimeKeyboard: true,
+		}), `{"keyboard": [[{"text": "Yes"}]], "one_time_keyboard": true}`},
+		{client.SendMessage(2434234, "test").SetReplyKeyboardRemove(telegraph.ReplyKeyboardRemove{RemoveKeyboard: true}),
+			`{"remove_keyboard": true}`},
+	}
+
+	for _, c := range cases {
+		_, _, err := c.message.Commit()
+		assert.NoError(t, err)
+
+		model := struct {
+			ReplyMarkup json.RawMessage `json:"reply_markup"`
+		}{}
+		assert.NoError(t, json.Unmarshal(body, &model))
+		assert.JSONEq(t, c.expected, string(model.ReplyMarkup))
+	}
+}
+
+func TestSetReplyMarkup_Conflict(t *testing.T) {
+	var req *http.Request
+	var body []byte
+	client := telegraph.NewClient("token", telegraph.WithTransport(captureTransport(&req, &body)))
+
+	_, _, err := client.SendMessage(2434234, "test").SetReplyKeyboardRemove(telegraph.ReplyKeyboardRemove{RemoveKeyboard: true}).
+		SetInlineKeyboardMarkup([][]telegraph.InlineKeyboardButton{}).Commit()
+	assert.Equal(t, telegraph.ErrConflictingReplyMarkup, err)
+
+	_, _, err = client.EditMessageReplyMarkup().SetChatID(2434234).SetMessageID(100).
+		SetReplyMarkup(telegraph.InlineKeyboardMarkup{}).SetReplyMarkup(telegraph.InlineKeyboardMarkup{}).Commit()
+	assert.Equal(t, telegraph.ErrConflictingReplyMarkup, err)
+	assert.Nil(t, req)
+}
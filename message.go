@@ -1,27 +1,27 @@
 package telegraph
 
 import (
+	"context"
 	"fmt"
 
 	"net/http"
 
-	"net/url"
-
-	"github.com/cenkalti/backoff"
 	"github.com/parnurzeal/gorequest"
 )
 
 type (
 	// MessageResponse struct to handle request and response telegram api
 	MessageResponse struct {
-		Client  *Client
-		Request *gorequest.SuperAgent
+		Client      *Client
+		Request     *gorequest.SuperAgent
+		retryPolicy RetryPolicy
 	}
 
 	// ArrayMessageResponse struct to handle request and array response telegram api
 	ArrayMessageResponse struct {
-		Client  *Client
-		Request *gorequest.SuperAgent
+		Client      *Client
+		Request     *gorequest.SuperAgent
+		retryPolicy RetryPolicy
 	}
 )
 
@@ -35,10 +35,7 @@ Available method can used with this method
 + SetDisableWebPagePreview()
 + SetDisableNotification()
 + SetReplyToMessageID()
-+ SetForceReply()
-+ SetInlineKeyboardMarkup()
-+ SetReplyKeyboardMarkup()
-+ SetReplyKeyboardRemove()
++ SetReplyMarkup()
 */
 func (client *Client) SendMessage(chatId interface{}, text string) *MessageResponse {
 	body := JSON{
@@ -46,7 +43,7 @@ func (client *Client) SendMessage(chatId interface{}, text string) *MessageRespo
 		"text":    text,
 	}
 	endpoint := client.baseURL + fmt.Sprintf(EndpointSendMessage, client.accessToken)
-	request := gorequest.New().Post(endpoint).Type(gorequest.TypeJSON).Set(UserAgentHeader, UserAgent+"/"+Version).Send(body)
+	request := gorequest.New().Post(endpoint).Type(gorequest.TypeJSON).Set(UserAgentHeader, client.userAgent).Send(body)
 
 	return &MessageResponse{
 		Client:  client,
@@ -90,7 +87,7 @@ func (client *Client) ForwardMessage(chatId, fromChatId interface{}, messageId i
 		"message_id":   messageId,
 	}
 	endpoint := client.baseURL + fmt.Sprintf(EndpointForwardMessage, client.accessToken)
-	request := gorequest.New().Post(endpoint).Type(gorequest.TypeJSON).Set(UserAgentHeader, UserAgent+"/"+Version).Send(body)
+	request := gorequest.New().Post(endpoint).Type(gorequest.TypeJSON).Set(UserAgentHeader, client.userAgent).Send(body)
 
 	return &MessageResponse{
 		Client:  client,
@@ -101,29 +98,22 @@ func (client *Client) ForwardMessage(chatId, fromChatId interface{}, messageId i
 /*
 SendPhoto Use this method to send photos. On success, the sent Message is returned.
 + chatId - Unique identifier for the target chat or username of the target channel (in the format @channelusername)
-+ photo - Photo to send. Pass a file_id as String to send a photo that exists on the Telegram servers (recommended),
-  pass an HTTP URL as a String for Telegram to get a photo from the Internet, or upload a new photo using multipart/form-data
++ photo - Photo to send. Pass FileID to send a photo that exists on the Telegram servers (recommended),
+  pass FileURL for Telegram to get a photo from the Internet, or upload a new photo with FilePath, FileReader or FileBytes
 
 Available method can used with this method
 + SetCaption()
 + SetDisableNotification()
 + SetReplyToMessageID()
-+ SetForceReply()
-+ SetInlineKeyboardMarkup()
-+ SetReplyKeyboardMarkup()
-+ SetReplyKeyboardRemove()
++ SetReplyMarkup()
 */
-func (client *Client) SendPhoto(chatId interface{}, photo string) *MessageResponse {
+func (client *Client) SendPhoto(chatId interface{}, photo *InputFile) *MessageResponse {
 	body := JSON{
 		"chat_id": chatId,
-		"photo":   photo,
 	}
 	endpoint := client.baseURL + fmt.Sprintf(EndpointSendPhoto, client.accessToken)
-	request := gorequest.New().Post(endpoint).Set(UserAgentHeader, UserAgent+"/"+Version).Send(body)
-
-	if _, err := url.ParseRequestURI(photo); err != nil {
-		request.Type(gorequest.TypeMultipart).SendFile(photo, "", "photo")
-	}
+	request := gorequest.New().Post(endpoint).Set(UserAgentHeader, client.userAgent).Send(body)
+	request = photo.attach(request, "photo")
 
 	return &MessageResponse{
 		Client:  client,
@@ -137,8 +127,8 @@ Your audio must be in the .mp3 format. On success, the sent Message is returned.
 Bots can currently send audio files of up to 50 MB in size, this limit may be changed in the future.
 For sending voice messages, use the sendVoice method instead.
 + chatId - Unique identifier for the target chat or username of the target channel (in the format @channelusername)
-+ audio - Audio file to send. Pass a file_id as String to send an audio file that exists on the Telegram servers (recommended),
-  pass an HTTP URL as a String for Telegram to get an audio file from the Internet, or upload a new one using multipart/form-data.
++ audio - Audio file to send. Pass FileID to send an audio file that exists on the Telegram servers (recommended),
+  pass FileURL for Telegram to get an audio file from the Internet, or upload a new one with FilePath, FileReader or FileBytes.
 
 Available method can used with this method
 + SetCaption()
@@ -147,22 +137,15 @@ Available method can used with this method
 + SetTitle()
 + SetDisableNotification()
 + SetReplyToMessageID()
-+ SetForceReply()
-+ SetInlineKeyboardMarkup()
-+ SetReplyKeyboardMarkup()
-+ SetReplyKeyboardRemove()
++ SetReplyMarkup()
 */
-func (client *Client) SendAudio(chatId interface{}, audio string) *MessageResponse {
+func (client *Client) SendAudio(chatId interface{}, audio *InputFile) *MessageResponse {
 	body := JSON{
 		"chat_id": chatId,
-		"audio":   audio,
 	}
 	endpoint := client.baseURL + fmt.Sprintf(EndpointSendAudio, client.accessToken)
-	request := gorequest.New().Post(endpoint).Set(UserAgentHeader, UserAgent+"/"+Version).Send(body)
-
-	if _, err := url.ParseRequestURI(audio); err != nil {
-		request.Type(gorequest.TypeMultipart).SendFile(audio, "", "audio")
-	}
+	request := gorequest.New().Post(endpoint).Set(UserAgentHeader, client.userAgent).Send(body)
+	request = audio.attach(request, "audio")
 
 	return &MessageResponse{
 		Client:  client,
@@ -194,29 +177,22 @@ func (message *MessageResponse) SetTitle(title string) *MessageResponse {
 SendDocument Use this method to send general files. On success, the sent Message is returned.
 Bots can currently send files of any type of up to 50 MB in size, this limit may be changed in the future.
 + chatId - Unique identifier for the target chat or username of the target channel (in the format @channelusername)
-+ document - File to send. Pass a file_id as String to send a file that exists on the Telegram servers (recommended),
-  pass an HTTP URL as a String for Telegram to get a file from the Internet, or upload a new one using multipart/form-data.
++ document - File to send. Pass FileID to send a file that exists on the Telegram servers (recommended),
+  pass FileURL for Telegram to get a file from the Internet, or upload a new one with FilePath, FileReader or FileBytes.
 
 Available method can used with this method
 + SetCaption()
 + SetDisableNotification()
 + SetReplyToMessageID()
-+ SetForceReply()
-+ SetInlineKeyboardMarkup()
-+ SetReplyKeyboardMarkup()
-+ SetReplyKeyboardRemove()
++ SetReplyMarkup()
 */
-func (client *Client) SendDocument(chatId interface{}, document string) *MessageResponse {
+func (client *Client) SendDocument(chatId interface{}, document *InputFile) *MessageResponse {
 	body := JSON{
-		"chat_id":  chatId,
-		"document": document,
+		"chat_id": chatId,
 	}
 	endpoint := client.baseURL + fmt.Sprintf(EndpointSendDocument, client.accessToken)
-	request := gorequest.New().Post(endpoint).Set(UserAgentHeader, UserAgent+"/"+Version).Send(body)
-
-	if _, err := url.ParseRequestURI(document); err != nil {
-		request.Type(gorequest.TypeMultipart).SendFile(document, "", "document")
-	}
+	request := gorequest.New().Post(endpoint).Set(UserAgentHeader, client.userAgent).Send(body)
+	request = document.attach(request, "document")
 
 	return &MessageResponse{
 		Client:  client,
@@ -229,8 +205,8 @@ SendVideo Use this method to send video files, Telegram clients support mp4 vide
 On success, the sent Message is returned.
 Bots can currently send video files of up to 50 MB in size, this limit may be changed in the future.
 + chatId - Unique identifier for the target chat or username of the target channel (in the format @channelusername)
-+ video - Video to send. Pass a file_id as String to send a video that exists on the Telegram servers (recommended),
-  pass an HTTP URL as a String for Telegram to get a video from the Internet, or upload a new video using multipart/form-data.
++ video - Video to send. Pass FileID to send a video that exists on the Telegram servers (recommended),
+  pass FileURL for Telegram to get a video from the Internet, or upload a new video with FilePath, FileReader or FileBytes.
 
 Available method can used with this method
 + SetDuration()
@@ -239,22 +215,15 @@ Available method can used with this method
 + SetCaption()
 + SetDisableNotification()
 + SetReplyToMessageID()
-+ SetForceReply()
-+ SetInlineKeyboardMarkup()
-+ SetReplyKeyboardMarkup()
-+ SetReplyKeyboardRemove()
++ SetReplyMarkup()
 */
-func (client *Client) SendVideo(chatId interface{}, video string) *MessageResponse {
+func (client *Client) SendVideo(chatId interface{}, video *InputFile) *MessageResponse {
 	body := JSON{
 		"chat_id": chatId,
-		"video":   video,
 	}
 	endpoint := client.baseURL + fmt.Sprintf(EndpointSendVideo, client.accessToken)
-	request := gorequest.New().Post(endpoint).Set(UserAgentHeader, UserAgent+"/"+Version).Send(body)
-
-	if _, err := url.ParseRequestURI(video); err != nil {
-		request.Type(gorequest.TypeMultipart).SendFile(video, "", "video")
-	}
+	request := gorequest.New().Post(endpoint).Set(UserAgentHeader, client.userAgent).Send(body)
+	request = video.attach(request, "video")
 
 	return &MessageResponse{
 		Client:  client,
@@ -288,30 +257,23 @@ For this to work, your audio must be in an .ogg file encoded with OPUS (other fo
 On success, the sent Message is returned.
 Bots can currently send voice messages of up to 50 MB in size, this limit may be changed in the future.
 + chatId - Unique identifier for the target chat or username of the target channel (in the format @channelusername)
-+ voice - Audio file to send. Pass a file_id as String to send a file that exists on the Telegram servers (recommended),
-  pass an HTTP URL as a String for Telegram to get a file from the Internet, or upload a new one using multipart/form-data.
++ voice - Audio file to send. Pass FileID to send a file that exists on the Telegram servers (recommended),
+  pass FileURL for Telegram to get a file from the Internet, or upload a new one with FilePath, FileReader or FileBytes.
 
 Available method can used with this method
 + SetCaption()
 + SetDuration()
 + SetDisableNotification()
 + SetReplyToMessageID()
-+ SetForceReply()
-+ SetInlineKeyboardMarkup()
-+ SetReplyKeyboardMarkup()
-+ SetReplyKeyboardRemove()
++ SetReplyMarkup()
 */
-func (client *Client) SendVoice(chatId interface{}, voice string) *MessageResponse {
+func (client *Client) SendVoice(chatId interface{}, voice *InputFile) *MessageResponse {
 	body := JSON{
 		"chat_id": chatId,
-		"voice":   voice,
 	}
 	endpoint := client.baseURL + fmt.Sprintf(EndpointSendVoice, client.accessToken)
-	request := gorequest.New().Post(endpoint).Set(UserAgentHeader, UserAgent+"/"+Version).Send(body)
-
-	if _, err := url.ParseRequestURI(voice); err != nil {
-		request.Type(gorequest.TypeMultipart).SendFile(voice, "", "voice")
-	}
+	request := gorequest.New().Post(endpoint).Set(UserAgentHeader, client.userAgent).Send(body)
+	request = voice.attach(request, "voice")
 
 	return &MessageResponse{
 		Client:  client,
@@ -333,7 +295,7 @@ func (message *MessageResponse) SetCaption(caption string) *MessageResponse {
 SendVideoNote As of v.4.0, Telegram clients support rounded square mp4 videos of up to 1 minute long.
 Use this method to send video messages. On success, the sent Message is returned.
 + chatId - Unique identifier for the target chat or username of the target channel (in the format @channelusername)
-+ videoNote - Video note to send. Pass a file_id as String to send a video note that exists on the Telegram servers (recommended) or upload a new video using multipart/form-data.
++ videoNote - Video note to send. Pass FileID to send a video note that exists on the Telegram servers (recommended) or upload a new video with FilePath, FileReader or FileBytes.
   Sending video notes by a URL is currently unsupported
 
 Available method can used with this method
@@ -341,22 +303,15 @@ Available method can used with this method
 + SetLength()
 + SetDisableNotification()
 + SetReplyToMessageID()
-+ SetForceReply()
-+ SetInlineKeyboardMarkup()
-+ SetReplyKeyboardMarkup()
-+ SetReplyKeyboardRemove()
++ SetReplyMarkup()
 */
-func (client *Client) SendVideoNote(chatId interface{}, videoNote string) *MessageResponse {
+func (client *Client) SendVideoNote(chatId interface{}, videoNote *InputFile) *MessageResponse {
 	body := JSON{
-		"chat_id":    chatId,
-		"video_note": videoNote,
+		"chat_id": chatId,
 	}
 	endpoint := client.baseURL + fmt.Sprintf(EndpointSendVideoNote, client.accessToken)
-	request := gorequest.New().Post(endpoint).Set(UserAgentHeader, UserAgent+"/"+Version).Send(body)
-
-	if _, err := url.ParseRequestURI(videoNote); err != nil {
-		request.Type(gorequest.TypeMultipart).SendFile(videoNote, "", "video_note")
-	}
+	request := gorequest.New().Post(endpoint).Set(UserAgentHeader, client.userAgent).Send(body)
+	request = videoNote.attach(request, "video_note")
 
 	return &MessageResponse{
 		Client:  client,
@@ -394,10 +349,7 @@ Available method can used with this method
 + SetLivePeriod()
 + SetDisableNotification()
 + SetReplyToMessageID()
-+ SetForceReply()
-+ SetInlineKeyboardMarkup()
-+ SetReplyKeyboardMarkup()
-+ SetReplyKeyboardRemove()
++ SetReplyMarkup()
 */
 func (client *Client) SendLocation(chatId interface{}, latitude, longitude float64) *MessageResponse {
 	body := JSON{
@@ -406,7 +358,7 @@ func (client *Client) SendLocation(chatId interface{}, latitude, longitude float
 		"longitude": longitude,
 	}
 	endpoint := client.baseURL + fmt.Sprintf(EndpointSendLocation, client.accessToken)
-	request := gorequest.New().Type(gorequest.TypeJSON).Post(endpoint).Set(UserAgentHeader, UserAgent+"/"+Version).Send(body)
+	request := gorequest.New().Type(gorequest.TypeJSON).Post(endpoint).Set(UserAgentHeader, client.userAgent).Send(body)
 
 	return &MessageResponse{
 		Client:  client,
@@ -436,10 +388,7 @@ Available method can used with this method
 + SetFoursquareID()
 + SetDisableNotification()
 + SetReplyToMessageID()
-+ SetForceReply()
-+ SetInlineKeyboardMarkup()
-+ SetReplyKeyboardMarkup()
-+ SetReplyKeyboardRemove()
++ SetReplyMarkup()
 */
 func (client *Client) SendVenue(chatId interface{}, latitude, longitude float64, title, address string) *MessageResponse {
 	body := JSON{
@@ -450,7 +399,7 @@ func (client *Client) SendVenue(chatId interface{}, latitude, longitude float64,
 		"address":   address,
 	}
 	endpoint := client.baseURL + fmt.Sprintf(EndpointSendVenue, client.accessToken)
-	request := gorequest.New().Type(gorequest.TypeJSON).Post(endpoint).Set(UserAgentHeader, UserAgent+"/"+Version).Send(body)
+	request := gorequest.New().Type(gorequest.TypeJSON).Post(endpoint).Set(UserAgentHeader, client.userAgent).Send(body)
 
 	return &MessageResponse{
 		Client:  client,
@@ -478,10 +427,7 @@ Available method can used with this method
 + SetLastName()
 + SetDisableNotification()
 + SetReplyToMessageID()
-+ SetForceReply()
-+ SetInlineKeyboardMarkup()
-+ SetReplyKeyboardMarkup()
-+ SetReplyKeyboardRemove()
++ SetReplyMarkup()
 */
 func (client *Client) SendContact(chatId interface{}, phoneNumber, firstName string) *MessageResponse {
 	body := JSON{
@@ -490,7 +436,7 @@ func (client *Client) SendContact(chatId interface{}, phoneNumber, firstName str
 		"first_name":   firstName,
 	}
 	endpoint := client.baseURL + fmt.Sprintf(EndpointSendContact, client.accessToken)
-	request := gorequest.New().Type(gorequest.TypeJSON).Post(endpoint).Set(UserAgentHeader, UserAgent+"/"+Version).Send(body)
+	request := gorequest.New().Type(gorequest.TypeJSON).Post(endpoint).Set(UserAgentHeader, client.userAgent).Send(body)
 
 	return &MessageResponse{
 		Client:  client,
@@ -511,28 +457,21 @@ func (message *MessageResponse) SetLastName(lastName string) *MessageResponse {
 /*
 SendSticker Use this method to send .webp stickers. On success, the sent Message is returned.
 + chatId - Unique identifier for the target chat or username of the target channel (in the format @channelusername)
-+ sticker - Sticker to send. Pass a file_id as String to send a file that exists on the Telegram servers (recommended),
-  pass an HTTP URL as a String for Telegram to get a .webp file from the Internet, or upload a new one using multipart/form-data.
++ sticker - Sticker to send. Pass FileID to send a file that exists on the Telegram servers (recommended),
+  pass FileURL for Telegram to get a .webp file from the Internet, or upload a new one with FilePath, FileReader or FileBytes.
 
 Available method can used with this method
 + SetDisableNotification()
 + SetReplyToMessageID()
-+ SetForceReply()
-+ SetInlineKeyboardMarkup()
-+ SetReplyKeyboardMarkup()
-+ SetReplyKeyboardRemove()
++ SetReplyMarkup()
 */
-func (client *Client) SendSticker(chatId interface{}, sticker string) *MessageResponse {
+func (client *Client) SendSticker(chatId interface{}, sticker *InputFile) *MessageResponse {
 	body := JSON{
 		"chat_id": chatId,
-		"sticker": sticker,
 	}
 	endpoint := client.baseURL + fmt.Sprintf(EndpointSendSticker, client.accessToken)
-	request := gorequest.New().Post(endpoint).Set(UserAgentHeader, UserAgent+"/"+Version).Send(body)
-
-	if _, err := url.ParseRequestURI(sticker); err != nil {
-		request.Type(gorequest.TypeMultipart).SendFile(sticker, "", "video")
-	}
+	request := gorequest.New().Post(endpoint).Set(UserAgentHeader, client.userAgent).Send(body)
+	request = sticker.attach(request, "sticker")
 
 	return &MessageResponse{
 		Client:  client,
@@ -560,74 +499,55 @@ func (message *MessageResponse) SetReplyToMessageID(id int64) *MessageResponse {
 	return message
 }
 
-// SetForceReply Additional interface options. A JSON-serialized object for an inline keyboard,
-// custom reply keyboard, instructions to remove reply keyboard or to force a reply from the user.
-func (message *MessageResponse) SetForceReply(reply ForceReply) *MessageResponse {
-	body := JSON{
-		"reply_markup": reply,
-	}
-	message.Request = message.Request.Send(body)
+// SetReplyMarkup Additional interface options. InlineKeyboardMarkup, ReplyKeyboardMarkup, ReplyKeyboardRemove or ForceReply.
+// Only one reply markup can be set, Commit return ErrConflictingReplyMarkup otherwise
+func (message *MessageResponse) SetReplyMarkup(markup ReplyMarkup) *MessageResponse {
+	message.Request = sendReplyMarkup(message.Request, markup)
 
 	return message
 }
 
-// SetInlineKeyboardMarkup Additional interface options. A JSON-serialized object for an inline keyboard,
-// custom reply keyboard, instructions to remove reply keyboard or to force a reply from the user.
-func (message *MessageResponse) SetInlineKeyboardMarkup(inline [][]InlineKeyboardButton) *MessageResponse {
-	body := JSON{
-		"reply_markup": JSON{
-			"inline_keyboard": inline,
-		},
-	}
-	message.Request = message.Request.Send(body)
+// SetForceReply Instructions to force a reply from the user, the same as SetReplyMarkup(reply)
+func (message *MessageResponse) SetForceReply(reply ForceReply) *MessageResponse {
+	return message.SetReplyMarkup(reply)
+}
 
-	return message
+// SetInlineKeyboardMarkup Inline keyboard, the same as SetReplyMarkup(InlineKeyboardMarkup{InlineKeyboard: inline})
+func (message *MessageResponse) SetInlineKeyboardMarkup(inline [][]InlineKeyboardButton) *MessageResponse {
+	return message.SetReplyMarkup(InlineKeyboardMarkup{InlineKeyboard: inline})
 }
 
-// SetReplyKeyboardMarkup Additional interface options. A JSON-serialized object for an inline keyboard,
-// custom reply keyboard, instructions to remove reply keyboard or to force a reply from the user.
+// SetReplyKeyboardMarkup Custom reply keyboard, the same as SetReplyMarkup(reply)
 func (message *MessageResponse) SetReplyKeyboardMarkup(reply ReplyKeyboardMarkup) *MessageResponse {
-	body := JSON{
-		"reply_markup": reply,
-	}
-	message.Request = message.Request.Send(body)
-
-	return message
+	return message.SetReplyMarkup(reply)
 }
 
-// SetReplyKeyboardRemove Additional interface options. A JSON-serialized object for an inline keyboard,
-// custom reply keyboard, instructions to remove reply keyboard or to force a reply from the user.
+// SetReplyKeyboardRemove Instructions to remove reply keyboard, the same as SetReplyMarkup(remove)
 func (message *MessageResponse) SetReplyKeyboardRemove(remove ReplyKeyboardRemove) *MessageResponse {
-	body := JSON{
-		"reply_markup": remove,
-	}
-	message.Request = message.Request.Send(body)
+	return message.SetReplyMarkup(remove)
+}
+
+// SetRetryPolicy decide how this request is retried when it fails, override retry policy of the client
+func (message *MessageResponse) SetRetryPolicy(policy RetryPolicy) *MessageResponse {
+	message.retryPolicy = policy
 
 	return message
 }
 
 // Commit execute request to telegram
 func (message *MessageResponse) Commit() (*Message, *http.Response, error) {
-	var errs []error
-	res := &http.Response{}
+	return message.CommitContext(context.Background())
+}
+
+// CommitContext execute request to telegram, the request and its back off retry are aborted when ctx is done
+func (message *MessageResponse) CommitContext(ctx context.Context) (*Message, *http.Response, error) {
 	model := struct {
-		ErrorResponse
 		Result *Message `json:"result,omitempty"`
 	}{}
 
-	operation := func() error {
-		res, _, errs = message.Request.EndStruct(&model)
-		if len(errs) > 0 {
-			return errs[0]
-		}
-		return nil
-	}
-
-	if err := backoff.Retry(operation, message.Client.expBackOff); err != nil {
-		return nil, MakeHTTPResponse(message.Request), err
-	}
-	if res.StatusCode != http.StatusOK {
-		return nil, res, fmt.Errorf("%v %v", model.ErrorCode, model.Description)
+	_, res, err := message.Client.commit(ctx, message.Request, message.retryPolicy, &model)
+	if err != nil {
+		return nil, res, err
 	}
 
 	return model.Result, res, nil
@@ -648,7 +568,7 @@ func (client *Client) SendMediaGroup(chatId interface{}, media []InputMedia) *Ar
 		"media":   media,
 	}
 	endpoint := client.baseURL + fmt.Sprintf(EndpointSendMediaGroup, client.accessToken)
-	request := gorequest.New().Type(gorequest.TypeJSON).Post(endpoint).Set(UserAgentHeader, UserAgent+"/"+Version).
+	request := gorequest.New().Type(gorequest.TypeJSON).Post(endpoint).Set(UserAgentHeader, client.userAgent).
 		Send(body)
 
 	return &ArrayMessageResponse{
@@ -677,29 +597,27 @@ func (message *ArrayMessageResponse) SetReplyToMessageID(id int64) *ArrayMessage
 	return message
 }
 
+// SetRetryPolicy decide how this request is retried when it fails, override retry policy of the client
+func (message *ArrayMessageResponse) SetRetryPolicy(policy RetryPolicy) *ArrayMessageResponse {
+	message.retryPolicy = policy
+
+	return message
+}
+
 // Commit execute request to telegram
 func (message *ArrayMessageResponse) Commit() ([]Message, *http.Response, error) {
-	var errs []error
+	return message.CommitContext(context.Background())
+}
 
-	res := &http.Response{}
+// CommitContext execute request to telegram, the request and its back off retry are aborted when ctx is done
+func (message *ArrayMessageResponse) CommitContext(ctx context.Context) ([]Message, *http.Response, error) {
 	model := struct {
-		ErrorResponse
 		Result []Message `json:"result,omitempty"`
 	}{}
 
-	operation := func() error {
-		res, _, errs = message.Request.EndStruct(&model)
-		if len(errs) > 0 {
-			return errs[0]
-		}
-		return nil
-	}
-
-	if err := backoff.Retry(operation, message.Client.expBackOff); err != nil {
-		return nil, MakeHTTPResponse(message.Request), err
-	}
-	if res.StatusCode != http.StatusOK {
-		return nil, res, fmt.Errorf("%v %v", model.ErrorCode, model.Description)
+	_, res, err := message.Client.commit(ctx, message.Request, message.retryPolicy, &model)
+	if err != nil {
+		return nil, res, err
 	}
 
 	return model.Result, res, nil
@@ -37,9 +37,8 @@ func TestSendMessage_Success(t *testing.T) {
 	client := telegraph.NewClient("token")
 
 	message, res, err := client.SendMessage(2434234, "test").SetDisableNotification(false).
-		SetDisableWebPagePreview(false).SetParseMode("HTML").SetForceReply(telegraph.ForceReply{}).
-		SetReplyKeyboardMarkup(telegraph.ReplyKeyboardMarkup{}).SetReplyKeyboardRemove(telegraph.ReplyKeyboardRemove{}).
-		SetInlineKeyboardMarkup([][]telegraph.InlineKeyboardButton{}).SetReplyToMessageID(234324234).Commit()
+		SetDisableWebPagePreview(false).SetParseMode("HTML").SetReplyMarkup(telegraph.ForceReply{}).
+		SetReplyToMessageID(234324234).Commit()
 
 	assert.NotNil(t, message)
 	assert.Equal(t, http.StatusOK, res.StatusCode)
@@ -50,12 +49,11 @@ func TestSendMessage_Error(t *testing.T) {
 	gock.New(telegraph.BaseURL).Post(fmt.Sprintf(telegraph.EndpointSendMessage, "token")).Reply(http.StatusInternalServerError).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 
 	message, res, err := client.SendMessage(2434234, "test").SetDisableNotification(false).
-		SetDisableWebPagePreview(false).SetParseMode("HTML").SetForceReply(telegraph.ForceReply{}).
-		SetReplyKeyboardMarkup(telegraph.ReplyKeyboardMarkup{}).SetReplyKeyboardRemove(telegraph.ReplyKeyboardRemove{}).
-		SetInlineKeyboardMarkup([][]telegraph.InlineKeyboardButton{}).SetReplyToMessageID(234324234).Commit()
+		SetDisableWebPagePreview(false).SetParseMode("HTML").SetReplyMarkup(telegraph.ForceReply{}).
+		SetReplyToMessageID(234324234).Commit()
 
 	assert.Nil(t, message)
 	assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
@@ -70,12 +68,11 @@ func TestSendMessage_Failed(t *testing.T) {
 	}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 
 	message, res, err := client.SendMessage(2434234, "test").SetDisableNotification(false).
-		SetDisableWebPagePreview(false).SetParseMode("HTML").SetForceReply(telegraph.ForceReply{}).
-		SetReplyKeyboardMarkup(telegraph.ReplyKeyboardMarkup{}).SetReplyKeyboardRemove(telegraph.ReplyKeyboardRemove{}).
-		SetInlineKeyboardMarkup([][]telegraph.InlineKeyboardButton{}).SetReplyToMessageID(234324234).Commit()
+		SetDisableWebPagePreview(false).SetParseMode("HTML").SetReplyMarkup(telegraph.ForceReply{}).
+		SetReplyToMessageID(234324234).Commit()
 
 	assert.Nil(t, message)
 	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
@@ -120,9 +117,8 @@ func TestSendPhoto_Success(t *testing.T) {
 	defer gock.Off()
 
 	client := telegraph.NewClient("token")
-	message, res, err := client.SendPhoto(2434234, "./LICENSE").SetDisableNotification(false).
-		SetForceReply(telegraph.ForceReply{}).SetReplyKeyboardMarkup(telegraph.ReplyKeyboardMarkup{}).
-		SetReplyKeyboardRemove(telegraph.ReplyKeyboardRemove{}).SetInlineKeyboardMarkup([][]telegraph.InlineKeyboardButton{}).
+	message, res, err := client.SendPhoto(2434234, telegraph.FilePath("./LICENSE")).SetDisableNotification(false).
+		SetReplyMarkup(telegraph.ForceReply{}).
 		SetReplyToMessageID(234324234).SetCaption("caption").Commit()
 
 	assert.NotNil(t, message)
@@ -134,11 +130,10 @@ func TestSendPhoto_Error(t *testing.T) {
 	gock.New(telegraph.BaseURL).Post(fmt.Sprintf(telegraph.EndpointSendPhoto, "token")).Reply(http.StatusInternalServerError).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 
-	message, res, err := client.SendPhoto(2434234, "./LICENSE").SetDisableNotification(false).
-		SetForceReply(telegraph.ForceReply{}).SetReplyKeyboardMarkup(telegraph.ReplyKeyboardMarkup{}).
-		SetReplyKeyboardRemove(telegraph.ReplyKeyboardRemove{}).SetInlineKeyboardMarkup([][]telegraph.InlineKeyboardButton{}).
+	message, res, err := client.SendPhoto(2434234, telegraph.FilePath("./LICENSE")).SetDisableNotification(false).
+		SetReplyMarkup(telegraph.ForceReply{}).
 		SetReplyToMessageID(234324234).SetCaption("caption").Commit()
 
 	assert.Nil(t, message)
@@ -154,11 +149,10 @@ func TestSendPhoto_Failed(t *testing.T) {
 	}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 
-	message, res, err := client.SendPhoto(2434234, "./LICENSE").SetDisableNotification(false).
-		SetForceReply(telegraph.ForceReply{}).SetReplyKeyboardMarkup(telegraph.ReplyKeyboardMarkup{}).
-		SetReplyKeyboardRemove(telegraph.ReplyKeyboardRemove{}).SetInlineKeyboardMarkup([][]telegraph.InlineKeyboardButton{}).
+	message, res, err := client.SendPhoto(2434234, telegraph.FilePath("./LICENSE")).SetDisableNotification(false).
+		SetReplyMarkup(telegraph.ForceReply{}).
 		SetReplyToMessageID(234324234).SetCaption("caption").Commit()
 
 	assert.Nil(t, message)
@@ -203,7 +197,7 @@ func TestForwardMessage_Error(t *testing.T) {
 	gock.New(telegraph.BaseURL).Post(fmt.Sprintf(telegraph.EndpointForwardMessage, "token")).Reply(http.StatusInternalServerError).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 
 	message, res, err := client.ForwardMessage(2434234, 2434234, 2434234).
 		SetDisableNotification(false).Commit()
@@ -221,7 +215,7 @@ func TestForwardMessage_Failed(t *testing.T) {
 	}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 
 	message, res, err := client.ForwardMessage(2434234, 2434234, 2434234).
 		SetDisableNotification(false).Commit()
@@ -263,9 +257,8 @@ func TestSendAudio_Success(t *testing.T) {
 	defer gock.Off()
 
 	client := telegraph.NewClient("token")
-	message, res, err := client.SendAudio(2434234, "./LICENSE").SetDisableNotification(false).
-		SetForceReply(telegraph.ForceReply{}).SetReplyKeyboardMarkup(telegraph.ReplyKeyboardMarkup{}).
-		SetReplyKeyboardRemove(telegraph.ReplyKeyboardRemove{}).SetInlineKeyboardMarkup([][]telegraph.InlineKeyboardButton{}).
+	message, res, err := client.SendAudio(2434234, telegraph.FilePath("./LICENSE")).SetDisableNotification(false).
+		SetReplyMarkup(telegraph.ForceReply{}).
 		SetReplyToMessageID(234324234).SetCaption("caption").SetDuration(1000).
 		SetPerformer("performer").SetTitle("title").Commit()
 
@@ -278,10 +271,9 @@ func TestSendAudio_Error(t *testing.T) {
 	gock.New(telegraph.BaseURL).Post(fmt.Sprintf(telegraph.EndpointSendAudio, "token")).Reply(http.StatusInternalServerError).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
-	message, res, err := client.SendAudio(2434234, "./LICENSE").SetDisableNotification(false).
-		SetForceReply(telegraph.ForceReply{}).SetReplyKeyboardMarkup(telegraph.ReplyKeyboardMarkup{}).
-		SetReplyKeyboardRemove(telegraph.ReplyKeyboardRemove{}).SetInlineKeyboardMarkup([][]telegraph.InlineKeyboardButton{}).
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
+	message, res, err := client.SendAudio(2434234, telegraph.FilePath("./LICENSE")).SetDisableNotification(false).
+		SetReplyMarkup(telegraph.ForceReply{}).
 		SetReplyToMessageID(234324234).SetCaption("caption").SetDuration(1000).
 		SetPerformer("performer").SetTitle("title").Commit()
 
@@ -298,11 +290,10 @@ func TestSendAudio_Failed(t *testing.T) {
 	}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 
-	message, res, err := client.SendAudio(2434234, "./LICENSE").SetDisableNotification(false).
-		SetForceReply(telegraph.ForceReply{}).SetReplyKeyboardMarkup(telegraph.ReplyKeyboardMarkup{}).
-		SetReplyKeyboardRemove(telegraph.ReplyKeyboardRemove{}).SetInlineKeyboardMarkup([][]telegraph.InlineKeyboardButton{}).
+	message, res, err := client.SendAudio(2434234, telegraph.FilePath("./LICENSE")).SetDisableNotification(false).
+		SetReplyMarkup(telegraph.ForceReply{}).
 		SetReplyToMessageID(234324234).SetCaption("caption").SetDuration(1000).
 		SetPerformer("performer").SetTitle("title").Commit()
 
@@ -341,9 +332,8 @@ func TestSendDocument_Success(t *testing.T) {
 	defer gock.Off()
 
 	client := telegraph.NewClient("token")
-	message, res, err := client.SendDocument(2434234, "./LICENSE").SetDisableNotification(false).
-		SetForceReply(telegraph.ForceReply{}).SetReplyKeyboardMarkup(telegraph.ReplyKeyboardMarkup{}).
-		SetReplyKeyboardRemove(telegraph.ReplyKeyboardRemove{}).SetInlineKeyboardMarkup([][]telegraph.InlineKeyboardButton{}).
+	message, res, err := client.SendDocument(2434234, telegraph.FilePath("./LICENSE")).SetDisableNotification(false).
+		SetReplyMarkup(telegraph.ForceReply{}).
 		SetReplyToMessageID(234324234).SetCaption("caption").Commit()
 
 	assert.NotNil(t, message)
@@ -355,10 +345,9 @@ func TestSendDocument_Error(t *testing.T) {
 	gock.New(telegraph.BaseURL).Post(fmt.Sprintf(telegraph.EndpointSendDocument, "token")).Reply(http.StatusInternalServerError).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
-	message, res, err := client.SendDocument(2434234, "./LICENSE").SetDisableNotification(false).
-		SetForceReply(telegraph.ForceReply{}).SetReplyKeyboardMarkup(telegraph.ReplyKeyboardMarkup{}).
-		SetReplyKeyboardRemove(telegraph.ReplyKeyboardRemove{}).SetInlineKeyboardMarkup([][]telegraph.InlineKeyboardButton{}).
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
+	message, res, err := client.SendDocument(2434234, telegraph.FilePath("./LICENSE")).SetDisableNotification(false).
+		SetReplyMarkup(telegraph.ForceReply{}).
 		SetReplyToMessageID(234324234).SetCaption("caption").Commit()
 
 	assert.Nil(t, message)
@@ -374,11 +363,10 @@ func TestSendDocument_Failed(t *testing.T) {
 	}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 
-	message, res, err := client.SendDocument(2434234, "./LICENSE").SetDisableNotification(false).
-		SetForceReply(telegraph.ForceReply{}).SetReplyKeyboardMarkup(telegraph.ReplyKeyboardMarkup{}).
-		SetReplyKeyboardRemove(telegraph.ReplyKeyboardRemove{}).SetInlineKeyboardMarkup([][]telegraph.InlineKeyboardButton{}).
+	message, res, err := client.SendDocument(2434234, telegraph.FilePath("./LICENSE")).SetDisableNotification(false).
+		SetReplyMarkup(telegraph.ForceReply{}).
 		SetReplyToMessageID(234324234).SetCaption("caption").Commit()
 
 	assert.Nil(t, message)
@@ -424,9 +412,8 @@ func TestSendVideo_Success(t *testing.T) {
 	defer gock.Off()
 
 	client := telegraph.NewClient("token")
-	message, res, err := client.SendVideo(2434234, "./LICENSE").SetDisableNotification(false).
-		SetForceReply(telegraph.ForceReply{}).SetReplyKeyboardMarkup(telegraph.ReplyKeyboardMarkup{}).
-		SetReplyKeyboardRemove(telegraph.ReplyKeyboardRemove{}).SetInlineKeyboardMarkup([][]telegraph.InlineKeyboardButton{}).
+	message, res, err := client.SendVideo(2434234, telegraph.FilePath("./LICENSE")).SetDisableNotification(false).
+		SetReplyMarkup(telegraph.ForceReply{}).
 		SetReplyToMessageID(234324234).SetCaption("caption").SetDuration(1000).SetWidth(1000).
 		SetHeight(1000).Commit()
 
@@ -439,10 +426,9 @@ func TestSendVideo_Error(t *testing.T) {
 	gock.New(telegraph.BaseURL).Post(fmt.Sprintf(telegraph.EndpointSendVideo, "token")).Reply(http.StatusInternalServerError).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
-	message, res, err := client.SendVideo(2434234, "./LICENSE").SetDisableNotification(false).
-		SetForceReply(telegraph.ForceReply{}).SetReplyKeyboardMarkup(telegraph.ReplyKeyboardMarkup{}).
-		SetReplyKeyboardRemove(telegraph.ReplyKeyboardRemove{}).SetInlineKeyboardMarkup([][]telegraph.InlineKeyboardButton{}).
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
+	message, res, err := client.SendVideo(2434234, telegraph.FilePath("./LICENSE")).SetDisableNotification(false).
+		SetReplyMarkup(telegraph.ForceReply{}).
 		SetReplyToMessageID(234324234).SetCaption("caption").SetDuration(1000).SetWidth(1000).
 		SetHeight(1000).Commit()
 
@@ -459,11 +445,10 @@ func TestSendVideo_Failed(t *testing.T) {
 	}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 
-	message, res, err := client.SendVideo(2434234, "./LICENSE").SetDisableNotification(false).
-		SetForceReply(telegraph.ForceReply{}).SetReplyKeyboardMarkup(telegraph.ReplyKeyboardMarkup{}).
-		SetReplyKeyboardRemove(telegraph.ReplyKeyboardRemove{}).SetInlineKeyboardMarkup([][]telegraph.InlineKeyboardButton{}).
+	message, res, err := client.SendVideo(2434234, telegraph.FilePath("./LICENSE")).SetDisableNotification(false).
+		SetReplyMarkup(telegraph.ForceReply{}).
 		SetReplyToMessageID(234324234).SetCaption("caption").SetDuration(1000).SetWidth(1000).
 		SetHeight(1000).Commit()
 
@@ -502,9 +487,8 @@ func TestSendVoice_Success(t *testing.T) {
 	defer gock.Off()
 
 	client := telegraph.NewClient("token")
-	message, res, err := client.SendVoice(2434234, "./LICENSE").SetDisableNotification(false).
-		SetForceReply(telegraph.ForceReply{}).SetReplyKeyboardMarkup(telegraph.ReplyKeyboardMarkup{}).
-		SetReplyKeyboardRemove(telegraph.ReplyKeyboardRemove{}).SetInlineKeyboardMarkup([][]telegraph.InlineKeyboardButton{}).
+	message, res, err := client.SendVoice(2434234, telegraph.FilePath("./LICENSE")).SetDisableNotification(false).
+		SetReplyMarkup(telegraph.ForceReply{}).
 		SetReplyToMessageID(234324234).SetCaption("caption").SetDuration(1000).Commit()
 
 	assert.NotNil(t, message)
@@ -516,10 +500,9 @@ func TestSendVoice_Error(t *testing.T) {
 	gock.New(telegraph.BaseURL).Post(fmt.Sprintf(telegraph.EndpointSendVoice, "token")).Reply(http.StatusInternalServerError).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
-	message, res, err := client.SendVoice(2434234, "./LICENSE").SetDisableNotification(false).
-		SetForceReply(telegraph.ForceReply{}).SetReplyKeyboardMarkup(telegraph.ReplyKeyboardMarkup{}).
-		SetReplyKeyboardRemove(telegraph.ReplyKeyboardRemove{}).SetInlineKeyboardMarkup([][]telegraph.InlineKeyboardButton{}).
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
+	message, res, err := client.SendVoice(2434234, telegraph.FilePath("./LICENSE")).SetDisableNotification(false).
+		SetReplyMarkup(telegraph.ForceReply{}).
 		SetReplyToMessageID(234324234).SetCaption("caption").SetDuration(1000).Commit()
 
 	assert.Nil(t, message)
@@ -535,10 +518,9 @@ func TestSendVoice_Failed(t *testing.T) {
 	}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
-	message, res, err := client.SendVoice(2434234, "./LICENSE").SetDisableNotification(false).
-		SetForceReply(telegraph.ForceReply{}).SetReplyKeyboardMarkup(telegraph.ReplyKeyboardMarkup{}).
-		SetReplyKeyboardRemove(telegraph.ReplyKeyboardRemove{}).SetInlineKeyboardMarkup([][]telegraph.InlineKeyboardButton{}).
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
+	message, res, err := client.SendVoice(2434234, telegraph.FilePath("./LICENSE")).SetDisableNotification(false).
+		SetReplyMarkup(telegraph.ForceReply{}).
 		SetReplyToMessageID(234324234).SetCaption("caption").SetDuration(1000).Commit()
 
 	assert.Nil(t, message)
@@ -582,9 +564,8 @@ func TestSendVideoNote_Success(t *testing.T) {
 	defer gock.Off()
 
 	client := telegraph.NewClient("token")
-	message, res, err := client.SendVideoNote(2434234, "./LICENSE").SetDisableNotification(false).
-		SetForceReply(telegraph.ForceReply{}).SetReplyKeyboardMarkup(telegraph.ReplyKeyboardMarkup{}).
-		SetReplyKeyboardRemove(telegraph.ReplyKeyboardRemove{}).SetInlineKeyboardMarkup([][]telegraph.InlineKeyboardButton{}).
+	message, res, err := client.SendVideoNote(2434234, telegraph.FilePath("./LICENSE")).SetDisableNotification(false).
+		SetReplyMarkup(telegraph.ForceReply{}).
 		SetReplyToMessageID(234324234).SetLength(1000).SetDuration(1000).Commit()
 
 	assert.NotNil(t, message)
@@ -596,10 +577,9 @@ func TestSendVideoNote_Error(t *testing.T) {
 	gock.New(telegraph.BaseURL).Post(fmt.Sprintf(telegraph.EndpointSendVideoNote, "token")).Reply(http.StatusInternalServerError).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
-	message, res, err := client.SendVideoNote(2434234, "./LICENSE").SetDisableNotification(false).
-		SetForceReply(telegraph.ForceReply{}).SetReplyKeyboardMarkup(telegraph.ReplyKeyboardMarkup{}).
-		SetReplyKeyboardRemove(telegraph.ReplyKeyboardRemove{}).SetInlineKeyboardMarkup([][]telegraph.InlineKeyboardButton{}).
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
+	message, res, err := client.SendVideoNote(2434234, telegraph.FilePath("./LICENSE")).SetDisableNotification(false).
+		SetReplyMarkup(telegraph.ForceReply{}).
 		SetReplyToMessageID(234324234).SetLength(1000).SetDuration(1000).Commit()
 
 	assert.Nil(t, message)
@@ -615,10 +595,9 @@ func TestSendVideoNote_Failed(t *testing.T) {
 	}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
-	message, res, err := client.SendVideoNote(2434234, "./LICENSE").SetDisableNotification(false).
-		SetForceReply(telegraph.ForceReply{}).SetReplyKeyboardMarkup(telegraph.ReplyKeyboardMarkup{}).
-		SetReplyKeyboardRemove(telegraph.ReplyKeyboardRemove{}).SetInlineKeyboardMarkup([][]telegraph.InlineKeyboardButton{}).
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
+	message, res, err := client.SendVideoNote(2434234, telegraph.FilePath("./LICENSE")).SetDisableNotification(false).
+		SetReplyMarkup(telegraph.ForceReply{}).
 		SetReplyToMessageID(234324234).SetLength(1000).SetDuration(1000).Commit()
 
 	assert.Nil(t, message)
@@ -697,7 +676,7 @@ func TestSendMediaGroup_Error(t *testing.T) {
 	gock.New(telegraph.BaseURL).Post(fmt.Sprintf(telegraph.EndpointSendMediaGroup, "token")).Reply(http.StatusInternalServerError).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	message, res, err := client.SendMediaGroup(2434234, []telegraph.InputMedia{}).SetDisableNotification(false).
 		SetReplyToMessageID(234324234).Commit()
 
@@ -714,7 +693,7 @@ func TestSendMediaGroup_Failed(t *testing.T) {
 	}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	message, res, err := client.SendMediaGroup(2434234, []telegraph.InputMedia{}).SetDisableNotification(false).
 		SetReplyToMessageID(234324234).Commit()
 
@@ -752,8 +731,7 @@ func TestSendLocation_Success(t *testing.T) {
 
 	client := telegraph.NewClient("token")
 	message, res, err := client.SendLocation(2434234, 12312312.98, 324234324.67).SetDisableNotification(false).
-		SetForceReply(telegraph.ForceReply{}).SetReplyKeyboardMarkup(telegraph.ReplyKeyboardMarkup{}).
-		SetReplyKeyboardRemove(telegraph.ReplyKeyboardRemove{}).SetInlineKeyboardMarkup([][]telegraph.InlineKeyboardButton{}).
+		SetReplyMarkup(telegraph.ForceReply{}).
 		SetReplyToMessageID(234324234).SetLivePeriod(60).Commit()
 
 	assert.NotNil(t, message)
@@ -765,10 +743,9 @@ func TestSendLocation_Error(t *testing.T) {
 	gock.New(telegraph.BaseURL).Post(fmt.Sprintf(telegraph.EndpointSendLocation, "token")).Reply(http.StatusInternalServerError).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	message, res, err := client.SendLocation(2434234, 12312312.98, 324234324.67).SetDisableNotification(false).
-		SetForceReply(telegraph.ForceReply{}).SetReplyKeyboardMarkup(telegraph.ReplyKeyboardMarkup{}).
-		SetReplyKeyboardRemove(telegraph.ReplyKeyboardRemove{}).SetInlineKeyboardMarkup([][]telegraph.InlineKeyboardButton{}).
+		SetReplyMarkup(telegraph.ForceReply{}).
 		SetReplyToMessageID(234324234).SetLivePeriod(60).Commit()
 
 	assert.Nil(t, message)
@@ -784,10 +761,9 @@ func TestSendLocation_Failed(t *testing.T) {
 	}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	message, res, err := client.SendLocation(2434234, 12312312.98, 324234324.67).SetDisableNotification(false).
-		SetForceReply(telegraph.ForceReply{}).SetReplyKeyboardMarkup(telegraph.ReplyKeyboardMarkup{}).
-		SetReplyKeyboardRemove(telegraph.ReplyKeyboardRemove{}).SetInlineKeyboardMarkup([][]telegraph.InlineKeyboardButton{}).
+		SetReplyMarkup(telegraph.ForceReply{}).
 		SetReplyToMessageID(234324234).SetLivePeriod(60).Commit()
 
 	assert.Nil(t, message)
@@ -832,8 +808,7 @@ func TestSendVenue_Success(t *testing.T) {
 
 	client := telegraph.NewClient("token")
 	message, res, err := client.SendVenue(2434234, 12312312.98, 324234324.67, "title", "address").
-		SetDisableNotification(true).SetForceReply(telegraph.ForceReply{}).SetReplyKeyboardMarkup(telegraph.ReplyKeyboardMarkup{}).
-		SetReplyKeyboardRemove(telegraph.ReplyKeyboardRemove{}).SetInlineKeyboardMarkup([][]telegraph.InlineKeyboardButton{}).
+		SetDisableNotification(true).SetReplyMarkup(telegraph.ForceReply{}).
 		SetReplyToMessageID(234324234).SetFoursquareID("id").Commit()
 
 	assert.NotNil(t, message)
@@ -845,10 +820,9 @@ func TestSendVenue_Error(t *testing.T) {
 	gock.New(telegraph.BaseURL).Post(fmt.Sprintf(telegraph.EndpointSendVenue, "token")).Reply(http.StatusInternalServerError).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	message, res, err := client.SendVenue(2434234, 12312312.98, 324234324.67, "title", "address").
-		SetDisableNotification(true).SetForceReply(telegraph.ForceReply{}).SetReplyKeyboardMarkup(telegraph.ReplyKeyboardMarkup{}).
-		SetReplyKeyboardRemove(telegraph.ReplyKeyboardRemove{}).SetInlineKeyboardMarkup([][]telegraph.InlineKeyboardButton{}).
+		SetDisableNotification(true).SetReplyMarkup(telegraph.ForceReply{}).
 		SetReplyToMessageID(234324234).SetFoursquareID("id").Commit()
 
 	assert.Nil(t, message)
@@ -864,10 +838,9 @@ func TestSendVenue_Failed(t *testing.T) {
 	}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	message, res, err := client.SendVenue(2434234, 12312312.98, 324234324.67, "title", "address").
-		SetDisableNotification(true).SetForceReply(telegraph.ForceReply{}).SetReplyKeyboardMarkup(telegraph.ReplyKeyboardMarkup{}).
-		SetReplyKeyboardRemove(telegraph.ReplyKeyboardRemove{}).SetInlineKeyboardMarkup([][]telegraph.InlineKeyboardButton{}).
+		SetDisableNotification(true).SetReplyMarkup(telegraph.ForceReply{}).
 		SetReplyToMessageID(234324234).SetFoursquareID("id").Commit()
 
 	assert.Nil(t, message)
@@ -906,8 +879,7 @@ func TestSendContact_Success(t *testing.T) {
 
 	client := telegraph.NewClient("token")
 	message, res, err := client.SendContact(2434234, "23423423423", "name").
-		SetDisableNotification(true).SetForceReply(telegraph.ForceReply{}).SetReplyKeyboardMarkup(telegraph.ReplyKeyboardMarkup{}).
-		SetReplyKeyboardRemove(telegraph.ReplyKeyboardRemove{}).SetInlineKeyboardMarkup([][]telegraph.InlineKeyboardButton{}).
+		SetDisableNotification(true).SetReplyMarkup(telegraph.ForceReply{}).
 		SetReplyToMessageID(234324234).SetLastName("last").Commit()
 
 	assert.NotNil(t, message)
@@ -919,10 +891,9 @@ func TestSendContact_Error(t *testing.T) {
 	gock.New(telegraph.BaseURL).Post(fmt.Sprintf(telegraph.EndpointSendContact, "token")).Reply(http.StatusInternalServerError).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	message, res, err := client.SendContact(2434234, "23423423423", "name").
-		SetDisableNotification(true).SetForceReply(telegraph.ForceReply{}).SetReplyKeyboardMarkup(telegraph.ReplyKeyboardMarkup{}).
-		SetReplyKeyboardRemove(telegraph.ReplyKeyboardRemove{}).SetInlineKeyboardMarkup([][]telegraph.InlineKeyboardButton{}).
+		SetDisableNotification(true).SetReplyMarkup(telegraph.ForceReply{}).
 		SetReplyToMessageID(234324234).SetLastName("last").Commit()
 
 	assert.Nil(t, message)
@@ -938,10 +909,9 @@ func TestSendContact_Failed(t *testing.T) {
 	}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	message, res, err := client.SendContact(2434234, "23423423423", "name").
-		SetDisableNotification(true).SetForceReply(telegraph.ForceReply{}).SetReplyKeyboardMarkup(telegraph.ReplyKeyboardMarkup{}).
-		SetReplyKeyboardRemove(telegraph.ReplyKeyboardRemove{}).SetInlineKeyboardMarkup([][]telegraph.InlineKeyboardButton{}).
+		SetDisableNotification(true).SetReplyMarkup(telegraph.ForceReply{}).
 		SetReplyToMessageID(234324234).SetLastName("last").Commit()
 
 	assert.Nil(t, message)
@@ -985,10 +955,9 @@ func TestSendSticker_Success(t *testing.T) {
 	defer gock.Off()
 
 	client := telegraph.NewClient("token")
-	message, res, err := client.SendSticker(123131231, "./LICENSE").
-		SetDisableNotification(true).SetReplyToMessageID(324234234).SetForceReply(telegraph.ForceReply{}).
-		SetInlineKeyboardMarkup([][]telegraph.InlineKeyboardButton{}).SetReplyKeyboardMarkup(telegraph.ReplyKeyboardMarkup{}).
-		SetReplyKeyboardRemove(telegraph.ReplyKeyboardRemove{}).Commit()
+	message, res, err := client.SendSticker(123131231, telegraph.FilePath("./LICENSE")).
+		SetDisableNotification(true).SetReplyToMessageID(324234234).SetReplyMarkup(telegraph.ForceReply{}).
+		Commit()
 
 	assert.NotNil(t, message)
 	assert.Equal(t, http.StatusOK, res.StatusCode)
@@ -999,11 +968,10 @@ func TestSendSticker_Error(t *testing.T) {
 	gock.New(telegraph.BaseURL).Head(fmt.Sprintf(telegraph.EndpointSendSticker, "token")).Reply(http.StatusInternalServerError).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
-	message, res, err := client.SendSticker(123131231, "./LICENSE").
-		SetDisableNotification(true).SetReplyToMessageID(324234234).SetForceReply(telegraph.ForceReply{}).
-		SetInlineKeyboardMarkup([][]telegraph.InlineKeyboardButton{}).SetReplyKeyboardMarkup(telegraph.ReplyKeyboardMarkup{}).
-		SetReplyKeyboardRemove(telegraph.ReplyKeyboardRemove{}).Commit()
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
+	message, res, err := client.SendSticker(123131231, telegraph.FilePath("./LICENSE")).
+		SetDisableNotification(true).SetReplyToMessageID(324234234).SetReplyMarkup(telegraph.ForceReply{}).
+		Commit()
 
 	assert.Nil(t, message)
 	assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
@@ -1018,11 +986,10 @@ func TestSendSticker_Failed(t *testing.T) {
 	}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
-	message, res, err := client.SendSticker(123131231, "./LICENSE").
-		SetDisableNotification(true).SetReplyToMessageID(324234234).SetForceReply(telegraph.ForceReply{}).
-		SetInlineKeyboardMarkup([][]telegraph.InlineKeyboardButton{}).SetReplyKeyboardMarkup(telegraph.ReplyKeyboardMarkup{}).
-		SetReplyKeyboardRemove(telegraph.ReplyKeyboardRemove{}).Commit()
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
+	message, res, err := client.SendSticker(123131231, telegraph.FilePath("./LICENSE")).
+		SetDisableNotification(true).SetReplyToMessageID(324234234).SetReplyMarkup(telegraph.ForceReply{}).
+		Commit()
 
 	assert.Nil(t, message)
 	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
@@ -0,0 +1,67 @@
+package telegraph
+
+import (
+	"context"
+	"log"
+	"runtime/debug"
+	"time"
+)
+
+// Middleware wrap handler to run code before and after the update is handled,
+// e.g. recovery, logging, authorization or metrics
+type Middleware func(next Handler) Handler
+
+// Chain wrap handler with middlewares, the first middleware is the outermost and run first.
+// The returned handler can be used with Poller, WebHookHandler or called directly with updates
+// from GetUpdates and WebHookParseRequest
+func Chain(handler Handler, middlewares ...Middleware) Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+
+	return handler
+}
+
+// Recoverer recover panic from next handler and print it with stack trace to logger, log.Default() is used when logger is nil.
+// Other updates keep being handled after a handler panicked
+func Recoverer(logger *log.Logger) Middleware {
+	if logger == nil {
+		logger = log.Default()
+	}
+
+	return func(next Handler) Handler {
+		return HandlerFunc(func(ctx context.Context, update *Update) {
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					logger.Printf("telegraph: panic handling update %d: %v\n%s", update.UpdateID, recovered, debug.Stack())
+				}
+			}()
+
+			next.HandleUpdate(ctx, update)
+		})
+	}
+}
+
+// Logger print update id, type, chat, sender and handling duration of every update to logger, log.Default() is used when logger is nil
+func Logger(logger *log.Logger) Middleware {
+	if logger == nil {
+		logger = log.Default()
+	}
+
+	return func(next Handler) Handler {
+		return HandlerFunc(func(ctx context.Context, update *Update) {
+			start := time.Now()
+			next.HandleUpdate(ctx, update)
+
+			var chatID, userID int64
+			if chat := update.Chat(); chat != nil {
+				chatID = chat.ID
+			}
+			if user := update.From(); user != nil {
+				userID = user.ID
+			}
+
+			logger.Printf("telegraph: update %d type=%s chat=%d user=%d duration=%v", update.UpdateID, update.Type(), chatID, userID, time.Since(start))
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package telegraph_test
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"telegraph"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChain_Order(t *testing.T) {
+	calls := []string{}
+	trace := func(name string) telegraph.Middleware {
+		return func(next telegraph.Handler) telegraph.Handler {
+			return telegraph.HandlerFunc(func(ctx context.Context, update *telegraph.Update) {
+				calls = append(calls, name+" before")
+				next.HandleUpdate(ctx, update)
+				calls = append(calls, name+" after")
+			})
+		}
+	}
+
+	handler := telegraph.Chain(telegraph.HandlerFunc(func(ctx context.Context, update *telegraph.Update) {
+		calls = append(calls, "handler")
+	}), trace("first"), trace("second"))
+
+	handler.HandleUpdate(context.Background(), &telegraph.Update{})
+	assert.Equal(t, []string{"first before", "second before", "handler", "second after", "first after"}, calls)
+}
+
+func TestRecoverer(t *testing.T) {
+	out := &bytes.Buffer{}
+	handler := telegraph.Chain(telegraph.HandlerFunc(func(ctx context.Context, update *telegraph.Update) {
+		panic("boom")
+	}), telegraph.Recoverer(log.New(out, "", 0)))
+
+	assert.NotPanics(t, func() {
+		handler.HandleUpdate(context.Background(), &telegraph.Update{UpdateID: 7})
+	})
+	assert.Contains(t, out.String(), "panic handling update 7: boom")
+}
+
+func TestLogger(t *testing.T) {
+	out := &bytes.Buffer{}
+	handled := false
+	handler := telegraph.Chain(telegraph.HandlerFunc(func(ctx context.Context, update *telegraph.Update) {
+		handled = true
+	}), telegraph.Logger(log.New(out, "", 0)))
+
+	handler.HandleUpdate(context.Background(), &telegraph.Update{
+		UpdateID: 9,
+		Message: &telegraph.Message{
+			Chat: telegraph.Chat{ID: -100},
+			From: &telegraph.User{ID: 42},
+		},
+	})
+
+	assert.True(t, handled)
+	assert.Contains(t, out.String(), "update 9 type=message chat=-100 user=42")
+}
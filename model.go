@@ -7,9 +7,11 @@ import (
 )
 
 type (
-	ChatType   string
-	StatusType string
-	MediaType  string
+	ChatType    string
+	StatusType  string
+	MediaType   string
+	UpdateType  string
+	ContentType string
 )
 
 const (
@@ -27,6 +29,58 @@ const (
 
 	MediaTypePhoto MediaType = "photo"
 	MediaTypeVideo           = "video"
+
+	UpdateTypeMessage            UpdateType = "message"
+	UpdateTypeEditedMessage      UpdateType = "edited_message"
+	UpdateTypeChannelPost        UpdateType = "channel_post"
+	UpdateTypeEditedChannelPost  UpdateType = "edited_channel_post"
+	UpdateTypeInlineQuery        UpdateType = "inline_query"
+	UpdateTypeChosenInlineResult UpdateType = "chosen_inline_result"
+	UpdateTypeCallbackQuery      UpdateType = "callback_query"
+	UpdateTypeShippingQuery      UpdateType = "shipping_query"
+	UpdateTypePreCheckoutQuery   UpdateType = "pre_checkout_query"
+
+	ContentTypeText                  ContentType = "text"
+	ContentTypeAudio                 ContentType = "audio"
+	ContentTypeDocument              ContentType = "document"
+	ContentTypeGame                  ContentType = "game"
+	ContentTypePhoto                 ContentType = "photo"
+	ContentTypeSticker               ContentType = "sticker"
+	ContentTypeVideo                 ContentType = "video"
+	ContentTypeVoice                 ContentType = "voice"
+	ContentTypeVideoNote             ContentType = "video_note"
+	ContentTypeContact               ContentType = "contact"
+	ContentTypeLocation              ContentType = "location"
+	ContentTypeVenue                 ContentType = "venue"
+	ContentTypeInvoice               ContentType = "invoice"
+	ContentTypeSuccessfulPayment     ContentType = "successful_payment"
+	ContentTypeNewChatMembers        ContentType = "new_chat_members"
+	ContentTypeLeftChatMember        ContentType = "left_chat_member"
+	ContentTypeNewChatTitle          ContentType = "new_chat_title"
+	ContentTypeNewChatPhoto          ContentType = "new_chat_photo"
+	ContentTypeDeleteChatPhoto       ContentType = "delete_chat_photo"
+	ContentTypeGroupChatCreated      ContentType = "group_chat_created"
+	ContentTypeSuperGroupChatCreated ContentType = "supergroup_chat_created"
+	ContentTypeChannelChatCreated    ContentType = "channel_chat_created"
+	ContentTypeMigrateToChatID       ContentType = "migrate_to_chat_id"
+	ContentTypeMigrateFromChatID     ContentType = "migrate_from_chat_id"
+	ContentTypePinnedMessage         ContentType = "pinned_message"
+
+	EntityTypeMention       = "mention"
+	EntityTypeHashtag       = "hashtag"
+	EntityTypeCashtag       = "cashtag"
+	EntityTypeBotCommand    = "bot_command"
+	EntityTypeURL           = "url"
+	EntityTypeEmail         = "email"
+	EntityTypePhoneNumber   = "phone_number"
+	EntityTypeBold          = "bold"
+	EntityTypeItalic        = "italic"
+	EntityTypeUnderline     = "underline"
+	EntityTypeStrikethrough = "strikethrough"
+	EntityTypeCode          = "code"
+	EntityTypePre           = "pre"
+	EntityTypeTextLink      = "text_link"
+	EntityTypeTextMention   = "text_mention"
 )
 
 type (
@@ -35,9 +89,16 @@ type (
 
 	// ErrorResponse struct parse error response from telegram
 	ErrorResponse struct {
-		OK          bool   `json:"ok"`
-		ErrorCode   int    `json:"error_code,omitempty"`
-		Description string `json:"description,omitempty"`
+		OK          bool                `json:"ok"`
+		ErrorCode   int                 `json:"error_code,omitempty"`
+		Description string              `json:"description,omitempty"`
+		Parameters  *ResponseParameters `json:"parameters,omitempty"`
+	}
+
+	// ResponseParameters Contains information about why a request was unsuccessful.
+	ResponseParameters struct {
+		MigrateToChatID int64 `json:"migrate_to_chat_id,omitempty"`
+		RetryAfter      int   `json:"retry_after,omitempty"`
 	}
 
 	// Update This object represents an incoming update.
@@ -134,7 +195,7 @@ type (
 		ForwardFromMessageID  int64              `json:"forward_from_message_id,omitempty"`
 		ForwardSignature      string             `json:"forward_signature,omitempty"`
 		ForwardDate           int64              `json:"forward_date,omitempty"`
-		ReplyToMessage        string             `json:"reply_to_message,omitempty"`
+		ReplyToMessage        *Message           `json:"reply_to_message,omitempty"`
 		EditDate              int64              `json:"edit_date,omitempty"`
 		AuthorSignature       string             `json:"author_signature,omitempty"`
 		Text                  string             `json:"text,omitempty"`
@@ -202,11 +263,12 @@ type (
 
 	// MessageEntity This object represents one special entity in a text message. For example, hashtags, usernames, URLs, etc.
 	MessageEntity struct {
-		Type   string `json:"type"`
-		Offset int    `json:"offset"`
-		Length int    `json:"length"`
-		URL    string `json:"url,omitempty"`
-		User   *User  `json:"user,omitempty"`
+		Type     string `json:"type"`
+		Offset   int    `json:"offset"`
+		Length   int    `json:"length"`
+		URL      string `json:"url,omitempty"`
+		User     *User  `json:"user,omitempty"`
+		Language string `json:"language,omitempty"`
 	}
 
 	// Audio This object represents an audio file to be treated as music by the Telegram clients
@@ -242,8 +304,15 @@ type (
 		Description  string          `json:"description"`
 		Photo        []PhotoSize     `json:"photo"`
 		Text         string          `json:"text,omitempty"`
-		TextEntities []MessageEntity `json:"text_entities,animation"`
-		Animation    *Animation      `json:"animation,animation"`
+		TextEntities []MessageEntity `json:"text_entities,omitempty"`
+		Animation    *Animation      `json:"animation,omitempty"`
+	}
+
+	// GameHighScore This object represents one row of the high scores table for a game.
+	GameHighScore struct {
+		Position int  `json:"position"`
+		User     User `json:"user"`
+		Score    int  `json:"score"`
 	}
 
 	// Animation You can provide an animation for your game so that it looks stylish in chats (check out Lumberjack for an example).
@@ -340,6 +409,19 @@ type (
 		ProviderPaymentChargeID string     `json:"provider_payment_charge_id"`
 	}
 
+	// LabeledPrice This object represents a portion of the price for goods or services.
+	LabeledPrice struct {
+		Label  string `json:"label"`
+		Amount int64  `json:"amount"`
+	}
+
+	// ShippingOption This object represents one shipping option.
+	ShippingOption struct {
+		ID     string         `json:"id"`
+		Title  string         `json:"title"`
+		Prices []LabeledPrice `json:"prices"`
+	}
+
 	// OrderInfo This object represents information about an order.
 	OrderInfo struct {
 		Name            string           `json:"name,omitempty"`
@@ -369,16 +451,22 @@ type (
 
 	// InlineKeyboardButton This object represents one button of an inline keyboard.
 	// You must use exactly one of the optional fields.
+	// Switch inline query is a pointer because empty query is valid, it inserts only the bot's username
 	InlineKeyboardButton struct {
 		Text                         string        `json:"text"`
 		URL                          string        `json:"url,omitempty"`
 		CallbackData                 string        `json:"callback_data,omitempty"`
-		SwitchInlineQuery            string        `json:"switch_inline_query,omitempty"`
-		SwitchInlineQueryCurrentChat string        `json:"switch_inline_query_current_chat,omitempty"`
+		SwitchInlineQuery            *string       `json:"switch_inline_query,omitempty"`
+		SwitchInlineQueryCurrentChat *string       `json:"switch_inline_query_current_chat,omitempty"`
 		CallbackGame                 *CallbackGame `json:"callback_game,omitempty"`
 		Pay                          bool          `json:"pay,omitempty"`
 	}
 
+	// InlineKeyboardMarkup This object represents an inline keyboard that appears right next to the message it belongs to.
+	InlineKeyboardMarkup struct {
+		InlineKeyboard [][]InlineKeyboardButton `json:"inline_keyboard"`
+	}
+
 	// CallbackGame A placeholder, currently holds no information. Use BotFather to set up your game.
 	CallbackGame struct{}
 
@@ -0,0 +1,247 @@
+package telegraph
+
+import (
+	"fmt"
+
+	"github.com/parnurzeal/gorequest"
+)
+
+/*
+SendInvoice Use this method to send invoices. On success, the sent Message is returned.
++ chatId - Unique identifier for the target private chat
++ title - Product name, 1-32 characters
++ description - Product description, 1-255 characters
++ payload - Bot-defined invoice payload, 1-128 bytes. This will not be displayed to the user, use for your internal processes.
++ providerToken - Payments provider token, obtained via Botfather. Use token of test provider with WithTestEnvironment for local testing
++ startParameter - Unique deep-linking parameter that can be used to generate this invoice when used as a start parameter
++ currency - Three-letter ISO 4217 currency code
++ prices - Price breakdown, a list of components (e.g. product price, tax, discount, delivery cost, delivery tax, bonus, etc.)
+
+Available method can used with this method
++ SetProviderData()
++ SetPhotoURL()
++ SetPhotoSize()
++ SetPhotoWidth()
++ SetPhotoHeight()
++ SetNeedName()
++ SetNeedPhoneNumber()
++ SetNeedEmail()
++ SetNeedShippingAddress()
++ SetSendPhoneNumberToProvider()
++ SetSendEmailToProvider()
++ SetIsFlexible()
++ SetDisableNotification()
++ SetReplyToMessageID()
++ SetReplyMarkup()
+*/
+func (client *Client) SendInvoice(chatId interface{}, title, description, payload, providerToken, startParameter, currency string, prices ...LabeledPrice) *MessageResponse {
+	body := JSON{
+		"chat_id":         chatId,
+		"title":           title,
+		"description":     description,
+		"payload":         payload,
+		"provider_token":  providerToken,
+		"start_parameter": startParameter,
+		"currency":        currency,
+		"prices":          prices,
+	}
+	endpoint := client.baseURL + fmt.Sprintf(EndpointSendInvoice, client.accessToken)
+	request := gorequest.New().Post(endpoint).Type(gorequest.TypeJSON).Set(UserAgentHeader, client.userAgent).Send(body)
+
+	return &MessageResponse{
+		Client:  client,
+		Request: request,
+	}
+}
+
+// SetProviderData JSON-encoded data about the invoice, which will be shared with the payment provider.
+// A detailed description of required fields should be provided by the payment provider.
+func (message *MessageResponse) SetProviderData(data string) *MessageResponse {
+	body := JSON{
+		"provider_data": data,
+	}
+	message.Request = message.Request.Send(body)
+
+	return message
+}
+
+// SetPhotoURL URL of the product photo for the invoice. Can be a photo of the goods or a marketing image for a service.
+func (message *MessageResponse) SetPhotoURL(url string) *MessageResponse {
+	body := JSON{
+		"photo_url": url,
+	}
+	message.Request = message.Request.Send(body)
+
+	return message
+}
+
+// SetPhotoSize Photo size
+func (message *MessageResponse) SetPhotoSize(size int) *MessageResponse {
+	body := JSON{
+		"photo_size": size,
+	}
+	message.Request = message.Request.Send(body)
+
+	return message
+}
+
+// SetPhotoWidth Photo width
+func (message *MessageResponse) SetPhotoWidth(width int) *MessageResponse {
+	body := JSON{
+		"photo_width": width,
+	}
+	message.Request = message.Request.Send(body)
+
+	return message
+}
+
+// SetPhotoHeight Photo height
+func (message *MessageResponse) SetPhotoHeight(height int) *MessageResponse {
+	body := JSON{
+		"photo_height": height,
+	}
+	message.Request = message.Request.Send(body)
+
+	return message
+}
+
+// SetNeedName Pass True, if you require the user's full name to complete the order
+func (message *MessageResponse) SetNeedName(need bool) *MessageResponse {
+	body := JSON{
+		"need_name": need,
+	}
+	message.Request = message.Request.Send(body)
+
+	return message
+}
+
+// SetNeedPhoneNumber Pass True, if you require the user's phone number to complete the order
+func (message *MessageResponse) SetNeedPhoneNumber(need bool) *MessageResponse {
+	body := JSON{
+		"need_phone_number": need,
+	}
+	message.Request = message.Request.Send(body)
+
+	return message
+}
+
+// SetNeedEmail Pass True, if you require the user's email address to complete the order
+func (message *MessageResponse) SetNeedEmail(need bool) *MessageResponse {
+	body := JSON{
+		"need_email": need,
+	}
+	message.Request = message.Request.Send(body)
+
+	return message
+}
+
+// SetNeedShippingAddress Pass True, if you require the user's shipping address to complete the order
+func (message *MessageResponse) SetNeedShippingAddress(need bool) *MessageResponse {
+	body := JSON{
+		"need_shipping_address": need,
+	}
+	message.Request = message.Request.Send(body)
+
+	return message
+}
+
+// SetSendPhoneNumberToProvider Pass True, if user's phone number should be sent to provider
+func (message *MessageResponse) SetSendPhoneNumberToProvider(send bool) *MessageResponse {
+	body := JSON{
+		"send_phone_number_to_provider": send,
+	}
+	message.Request = message.Request.Send(body)
+
+	return message
+}
+
+// SetSendEmailToProvider Pass True, if user's email address should be sent to provider
+func (message *MessageResponse) SetSendEmailToProvider(send bool) *MessageResponse {
+	body := JSON{
+		"send_email_to_provider": send,
+	}
+	message.Request = message.Request.Send(body)
+
+	return message
+}
+
+// SetIsFlexible Pass True, if the final price depends on the shipping method
+func (message *MessageResponse) SetIsFlexible(flexible bool) *MessageResponse {
+	body := JSON{
+		"is_flexible": flexible,
+	}
+	message.Request = message.Request.Send(body)
+
+	return message
+}
+
+/*
+AnswerShippingQuery If you sent an invoice requesting a shipping address and the parameter is_flexible was specified,
+the Bot API will send an Update with a shipping_query field to the bot. Use this method to reply to shipping queries. On success, True is returned.
++ shippingQueryId - Unique identifier for the query to be answered
++ ok - Specify True if delivery to the specified address is possible and False if there are any problems
+  (for example, if delivery to the specified address is not possible)
+
+Available method can used with this method
++ SetShippingOptions()
++ SetErrorMessage()
+*/
+func (client *Client) AnswerShippingQuery(shippingQueryId string, ok bool) *VoidResponse {
+	body := JSON{
+		"shipping_query_id": shippingQueryId,
+		"ok":                ok,
+	}
+	endpoint := client.baseURL + fmt.Sprintf(EndpointAnswerShippingQuery, client.accessToken)
+	request := gorequest.New().Post(endpoint).Type(gorequest.TypeJSON).Set(UserAgentHeader, client.userAgent).Send(body)
+
+	return &VoidResponse{
+		Client:  client,
+		Request: request,
+	}
+}
+
+// SetShippingOptions Required if ok is True. Available shipping options.
+func (void *VoidResponse) SetShippingOptions(options ...ShippingOption) *VoidResponse {
+	body := JSON{
+		"shipping_options": options,
+	}
+	void.Request = void.Request.Send(body)
+
+	return void
+}
+
+// SetErrorMessage Required if ok is False. Error message in human readable form that explains why it is impossible to complete the order
+// (e.g. "Sorry, delivery to your desired address is unavailable"). Telegram will display this message to the user.
+func (void *VoidResponse) SetErrorMessage(message string) *VoidResponse {
+	body := JSON{
+		"error_message": message,
+	}
+	void.Request = void.Request.Send(body)
+
+	return void
+}
+
+/*
+AnswerPreCheckoutQuery Once the user has confirmed their payment and shipping details, the Bot API sends the final confirmation
+in the form of an Update with the field pre_checkout_query. Use this method to respond to such pre-checkout queries.
+On success, True is returned. Note: The Bot API must receive an answer within 10 seconds after the pre-checkout query was sent.
++ preCheckoutQueryId - Unique identifier for the query to be answered
++ ok - Specify True if everything is alright (goods are available, etc.) and the bot is ready to proceed with the order.
+  Use False if there are any problems.
+
+Available method can used with this method
++ SetErrorMessage()
+*/
+func (client *Client) AnswerPreCheckoutQuery(preCheckoutQueryId string, ok bool) *VoidResponse {
+	body := JSON{
+		"pre_checkout_query_id": preCheckoutQueryId,
+		"ok":                    ok,
+	}
+	endpoint := client.baseURL + fmt.Sprintf(EndpointAnswerPreCheckoutQuery, client.accessToken)
+	request := gorequest.New().Post(endpoint).Type(gorequest.TypeJSON).Set(UserAgentHeader, client.userAgent).Send(body)
+
+	return &VoidResponse{
+		Client:  client,
+		Request: request,
+	}
+}
@@ -0,0 +1,141 @@
+package telegraph_test
+
+import (
+	"fmt"
+	"net/http"
+	"telegraph"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestSendInvoice_Success(t *testing.T) {
+	gock.New(telegraph.BaseURL).Post(fmt.Sprintf(telegraph.EndpointSendInvoice, "token")).
+		MatchType("json").JSON(map[string]interface{}{
+		"chat_id":                       2434234,
+		"title":                         "Cube",
+		"description":                   "Rubik cube",
+		"payload":                       "order-1",
+		"provider_token":                "provider",
+		"start_parameter":               "cube",
+		"currency":                      "IDR",
+		"prices":                        []telegraph.LabeledPrice{{Label: "Cube", Amount: 10000000}, {Label: "Tax", Amount: 1000000}},
+		"provider_data":                 `{"sku":"cube"}`,
+		"photo_url":                     "https://www.cubesoft.co.id/cube.jpg",
+		"photo_size":                    1024,
+		"photo_width":                   512,
+		"photo_height":                  512,
+		"need_name":                     true,
+		"need_phone_number":             true,
+		"need_email":                    true,
+		"need_shipping_address":         true,
+		"send_phone_number_to_provider": true,
+		"send_email_to_provider":        true,
+		"is_flexible":                   true,
+	}).Reply(http.StatusOK).JSON(`{
+		"ok": true,
+		"result": {
+			"message_id": 100,
+			"chat": {
+				"id": 2434234,
+				"type": "private"
+			},
+			"date": 1510125931,
+			"invoice": {
+				"title": "Cube",
+				"description": "Rubik cube",
+				"start_parameter": "cube",
+				"currency": "IDR",
+				"total_amount": 11000000
+			}
+		}
+	}`)
+	defer gock.Off()
+
+	client := telegraph.NewClient("token")
+	message, res, err := client.SendInvoice(2434234, "Cube", "Rubik cube", "order-1", "provider", "cube", "IDR",
+		telegraph.LabeledPrice{Label: "Cube", Amount: 10000000}, telegraph.LabeledPrice{Label: "Tax", Amount: 1000000}).
+		SetProviderData(`{"sku":"cube"}`).SetPhotoURL("https://www.cubesoft.co.id/cube.jpg").SetPhotoSize(1024).
+		SetPhotoWidth(512).SetPhotoHeight(512).SetNeedName(true).SetNeedPhoneNumber(true).SetNeedEmail(true).
+		SetNeedShippingAddress(true).SetSendPhoneNumberToProvider(true).SetSendEmailToProvider(true).SetIsFlexible(true).Commit()
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, int64(11000000), message.Invoice.TotalAmount)
+}
+
+func TestSendInvoice_Failed(t *testing.T) {
+	gock.New(telegraph.BaseURL).Post(fmt.Sprintf(telegraph.EndpointSendInvoice, "token")).Reply(http.StatusBadRequest).JSON(`{
+		"ok": false,
+		"error_code": 400,
+		"description": "Bad Request: CURRENCY_INVALID"
+	}`)
+	defer gock.Off()
+
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
+	message, res, err := client.SendInvoice(2434234, "Cube", "Rubik cube", "order-1", "provider", "cube", "XXX",
+		telegraph.LabeledPrice{Label: "Cube", Amount: 100}).Commit()
+
+	assert.Nil(t, message)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	assert.True(t, telegraph.IsBadRequest(err))
+}
+
+func TestAnswerShippingQuery_Success(t *testing.T) {
+	gock.New(telegraph.BaseURL).Post(fmt.Sprintf(telegraph.EndpointAnswerShippingQuery, "token")).
+		MatchType("json").JSON(map[string]interface{}{
+		"shipping_query_id": "123",
+		"ok":                true,
+		"shipping_options": []telegraph.ShippingOption{
+			{ID: "jne", Title: "JNE", Prices: []telegraph.LabeledPrice{{Label: "Delivery", Amount: 900000}}},
+		},
+	}).Reply(http.StatusOK).JSON(`{
+		"ok": true,
+		"result": true
+	}`)
+	defer gock.Off()
+
+	client := telegraph.NewClient("token")
+	body, res, err := client.AnswerShippingQuery("123", true).SetShippingOptions(telegraph.ShippingOption{
+		ID: "jne", Title: "JNE", Prices: []telegraph.LabeledPrice{{Label: "Delivery", Amount: 900000}},
+	}).Commit()
+
+	assert.NotNil(t, body)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.NoError(t, err)
+}
+
+func TestAnswerPreCheckoutQuery_Success(t *testing.T) {
+	gock.New(telegraph.BaseURL).Post(fmt.Sprintf(telegraph.EndpointAnswerPreCheckoutQuery, "token")).
+		MatchType("json").JSON(map[string]interface{}{
+		"pre_checkout_query_id": "123",
+		"ok":                    false,
+		"error_message":         "Out of stock",
+	}).Reply(http.StatusOK).JSON(`{
+		"ok": true,
+		"result": true
+	}`)
+	defer gock.Off()
+
+	client := telegraph.NewClient("token")
+	body, res, err := client.AnswerPreCheckoutQuery("123", false).SetErrorMessage("Out of stock").Commit()
+
+	assert.NotNil(t, body)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.NoError(t, err)
+}
+
+func TestNewClient_WithTestEnvironment(t *testing.T) {
+	gock.New(telegraph.BaseURL).Post("/bottoken/test/answerPreCheckoutQuery").Reply(http.StatusOK).JSON(`{
+		"ok": true,
+		"result": true
+	}`)
+	defer gock.Off()
+
+	client := telegraph.NewClient("token", telegraph.WithTestEnvironment(), telegraph.WithTestEnvironment())
+	_, _, err := client.AnswerPreCheckoutQuery("123", true).Commit()
+
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+}
@@ -0,0 +1,224 @@
+package telegraph
+
+import (
+	"context"
+	"time"
+
+	"github.com/cenkalti/backoff"
+)
+
+// Poller receive updates from telegram with long polling (getUpdates).
+// Offset is managed automatically, an update is acknowledged to telegram once the handler returns
+type Poller struct {
+	client         *Client
+	offset         int64
+	limit          int
+	timeout        int
+	allowedUpdates []string
+	errBackOff     *backoff.ExponentialBackOff
+	errHandler     func(error)
+	err            error
+}
+
+/*
+NewPoller create long polling update receiver, with 30 seconds long polling timeout by default.
+Make sure client timeout, if any, is longer than long polling timeout.
+
+Available method can used with this method
++ SetOffset()
++ SetLimit()
++ SetTimeout()
++ SetAllowedUpdates()
++ SetErrorBackOff()
++ SetErrorHandler()
+*/
+func (client *Client) NewPoller() *Poller {
+	errBackOff := backoff.NewExponentialBackOff()
+	errBackOff.MaxInterval = time.Minute
+	errBackOff.MaxElapsedTime = 0
+
+	return &Poller{
+		client:     client,
+		timeout:    30,
+		errBackOff: errBackOff,
+	}
+}
+
+// StartPolling receive updates with default long polling and pass them to handler until ctx is done, see Poller.Start
+func (client *Client) StartPolling(ctx context.Context, handler Handler) error {
+	return client.NewPoller().Start(ctx, handler)
+}
+
+// SetOffset Identifier of the first update to be returned, by default updates starting with the earliest unconfirmed update are returned
+func (poller *Poller) SetOffset(offset int64) *Poller {
+	poller.offset = offset
+	return poller
+}
+
+// SetLimit Limits the number of updates to be retrieved per request. Values between 1—100 are accepted. Defaults to 100.
+func (poller *Poller) SetLimit(limit int) *Poller {
+	poller.limit = limit
+	return poller
+}
+
+// SetTimeout Timeout in seconds for long polling. Defaults to 30.
+func (poller *Poller) SetTimeout(timeout int) *Poller {
+	poller.timeout = timeout
+	return poller
+}
+
+// SetAllowedUpdates List the types of updates you want your bot to receive, e.g. “message”, “callback_query”.
+// Call it without types to receive all update types, don't call it to keep the previous setting.
+func (poller *Poller) SetAllowedUpdates(allowed ...string) *Poller {
+	if allowed == nil {
+		allowed = []string{}
+	}
+	poller.allowedUpdates = allowed
+	return poller
+}
+
+// SetErrorBackOff back off used to wait after getUpdates failed, by default it never gives up and wait at most 1 minute
+func (poller *Poller) SetErrorBackOff(errBackOff *backoff.ExponentialBackOff) *Poller {
+	poller.errBackOff = errBackOff
+	return poller
+}
+
+// SetErrorHandler called with every error of getUpdates before poller wait and try again
+func (poller *Poller) SetErrorHandler(handler func(error)) *Poller {
+	poller.errHandler = handler
+	return poller
+}
+
+/*
+Start receive updates and pass them one by one to handler in order, until ctx is done or telegram rejects the access token
+or reports conflict because webhook is set or another poller is running.
+Failed getUpdates is repeated after error back off, or after retry_after seconds on flood control error.
+When ctx is done, updates already handled are acknowledged to telegram before Start returns ctx.Err().
+When handler is a Submitter such as WorkerPool, update is acknowledged once it is accepted by Submit,
+Start stops and returns the error of Submit when the update is refused, the refused update is received again next time.
+*/
+func (poller *Poller) Start(ctx context.Context, handler Handler) error {
+	if submitter, ok := handler.(Submitter); ok {
+		return poller.poll(ctx, submitter.Submit)
+	}
+
+	return poller.poll(ctx, func(ctx context.Context, update *Update) error {
+		handler.HandleUpdate(ctx, update)
+		return nil
+	})
+}
+
+// poll receive updates until ctx is done and pass them to handle, update is acknowledged only if handle return nil
+func (poller *Poller) poll(ctx context.Context, handle func(ctx context.Context, update *Update) error) error {
+	acknowledged := poller.offset
+	defer func() {
+		if poller.offset != acknowledged {
+			poller.acknowledge()
+		}
+	}()
+
+	poller.errBackOff.Reset()
+	for {
+		request := poller.client.GetUpdates().SetTimeout(poller.timeout).SetRetryPolicy(NoRetry)
+		if poller.offset != 0 {
+			request.SetOffset(int(poller.offset))
+		}
+		if poller.limit > 0 {
+			request.SetLimit(poller.limit)
+		}
+		if poller.allowedUpdates != nil {
+			request.SetAllowedUpdates(poller.allowedUpdates...)
+		}
+
+		updates, _, err := request.CommitContext(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil {
+			if IsUnauthorized(err) || IsNotFound(err) || IsConflict(err) {
+				return err
+			}
+			if err := poller.wait(ctx, err); err != nil {
+				return err
+			}
+			continue
+		}
+		acknowledged = poller.offset
+		poller.errBackOff.Reset()
+
+		for i := range updates {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if err := handle(ctx, &updates[i]); err != nil {
+				return err
+			}
+			poller.offset = updates[i].UpdateID + 1
+		}
+	}
+}
+
+/*
+Updates receive updates and send them to the returned channel until ctx is done, see Poller.Start.
+An update is acknowledged once it is received from the channel.
+The channel is closed when poller stops, the reason is available from Err()
+*/
+func (poller *Poller) Updates(ctx context.Context) <-chan Update {
+	updates := make(chan Update)
+
+	go func() {
+		defer close(updates)
+
+		poller.err = poller.poll(ctx, func(ctx context.Context, update *Update) error {
+			select {
+			case updates <- *update:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+
+	return updates
+}
+
+// Err reason the poller stopped, only valid after channel from Updates is closed
+func (poller *Poller) Err() error {
+	return poller.err
+}
+
+// wait for error back off or retry_after from telegram before getUpdates is repeated
+func (poller *Poller) wait(ctx context.Context, err error) error {
+	if poller.errHandler != nil {
+		poller.errHandler(err)
+	}
+
+	wait := poller.errBackOff.NextBackOff()
+	if apiErr, ok := err.(*APIError); ok && apiErr.RetryAfter() > 0 {
+		wait = apiErr.RetryAfter()
+	}
+	if wait == backoff.Stop {
+		return err
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// acknowledge confirm handled updates to telegram, getUpdates with offset is the only way to confirm updates
+func (poller *Poller) acknowledge() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, _, err := poller.client.GetUpdates().SetOffset(int(poller.offset)).SetLimit(1).SetTimeout(0).CommitContext(ctx)
+	if err != nil && poller.errHandler != nil {
+		poller.errHandler(err)
+	}
+}
@@ -0,0 +1,204 @@
+package telegraph_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"telegraph"
+	"testing"
+	"time"
+
+	"github.com/cenkalti/backoff"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeUpdates struct {
+	sync.Mutex
+	queries []string
+	batches []string
+}
+
+func (fake *fakeUpdates) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	fake.Lock()
+	fake.queries = append(fake.queries, r.URL.RawQuery)
+	batch := `{"ok": true, "result": []}`
+	if len(fake.batches) > 0 {
+		batch, fake.batches = fake.batches[0], fake.batches[1:]
+	}
+	fake.Unlock()
+
+	if batch == "" {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	if batch == "unauthorized" {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"ok": false, "error_code": 401, "description": "Unauthorized"}`))
+		return
+	}
+	if batch == "conflict" {
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{"ok": false, "error_code": 409, "description": "Conflict: can't use getUpdates method while webhook is active"}`))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write([]byte(batch))
+}
+
+func (fake *fakeUpdates) Queries() []string {
+	fake.Lock()
+	defer fake.Unlock()
+	return append([]string{}, fake.queries...)
+}
+
+func newFakeUpdatesServer(batches ...string) (*fakeUpdates, *httptest.Server) {
+	fake := &fakeUpdates{batches: batches}
+	return fake, httptest.NewServer(fake)
+}
+
+func updateBatch(ids ...int64) string {
+	result := ""
+	for i, id := range ids {
+		if i > 0 {
+			result += ","
+		}
+		result += fmt.Sprintf(`{"update_id": %v, "message": {"message_id": %v, "date": 1508298329, "chat": {"id": 100, "type": "private"}, "text": "text %v"}}`, id, id, id)
+	}
+	return `{"ok": true, "result": [` + result + `]}`
+}
+
+func TestPoller_Start(t *testing.T) {
+	fake, server := newFakeUpdatesServer(updateBatch(1, 2), updateBatch(3))
+	defer server.Close()
+
+	client := telegraph.NewClient("token", telegraph.WithBaseURL(server.URL))
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var received []int64
+	err := client.NewPoller().SetTimeout(0).SetLimit(50).SetAllowedUpdates("message").
+		Start(ctx, telegraph.HandlerFunc(func(ctx context.Context, update *telegraph.Update) {
+			received = append(received, update.UpdateID)
+			if update.UpdateID == 3 {
+				cancel()
+			}
+		}))
+
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, []int64{1, 2, 3}, received)
+	assert.Equal(t, []string{
+		"allowed_updates=%5B%22message%22%5D&limit=50&timeout=0",
+		"allowed_updates=%5B%22message%22%5D&limit=50&offset=3&timeout=0",
+		"limit=1&offset=4&timeout=0",
+	}, fake.Queries())
+}
+
+func TestPoller_ErrorBackOff(t *testing.T) {
+	fake, server := newFakeUpdatesServer("", updateBatch(7))
+	defer server.Close()
+
+	errBackOff := backoff.NewExponentialBackOff()
+	errBackOff.InitialInterval = time.Millisecond
+
+	client := telegraph.NewClient("token", telegraph.WithBaseURL(server.URL))
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var errs []error
+	var received []int64
+	err := client.NewPoller().SetOffset(7).SetErrorBackOff(errBackOff).SetErrorHandler(func(err error) {
+		errs = append(errs, err)
+	}).Start(ctx, telegraph.HandlerFunc(func(ctx context.Context, update *telegraph.Update) {
+		received = append(received, update.UpdateID)
+		cancel()
+	}))
+
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, []int64{7}, received)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, []string{"offset=7&timeout=30", "offset=7&timeout=30", "limit=1&offset=8&timeout=0"}, fake.Queries())
+}
+
+type refusingSubmitter struct {
+	accept   int64
+	received []int64
+}
+
+func (submitter *refusingSubmitter) HandleUpdate(ctx context.Context, update *telegraph.Update) {
+	submitter.Submit(ctx, update)
+}
+
+func (submitter *refusingSubmitter) Submit(ctx context.Context, update *telegraph.Update) error {
+	if update.UpdateID > submitter.accept {
+		return telegraph.ErrWorkerPoolClosed
+	}
+	submitter.received = append(submitter.received, update.UpdateID)
+	return nil
+}
+
+func TestPoller_SubmitRefused(t *testing.T) {
+	fake, server := newFakeUpdatesServer(updateBatch(1, 2, 3))
+	defer server.Close()
+
+	client := telegraph.NewClient("token", telegraph.WithBaseURL(server.URL))
+	submitter := &refusingSubmitter{accept: 1}
+
+	err := client.NewPoller().SetTimeout(0).Start(context.Background(), submitter)
+
+	assert.Equal(t, telegraph.ErrWorkerPoolClosed, err)
+	assert.Equal(t, []int64{1}, submitter.received)
+	assert.Equal(t, []string{"timeout=0", "limit=1&offset=2&timeout=0"}, fake.Queries())
+}
+
+func TestPoller_Unauthorized(t *testing.T) {
+	_, server := newFakeUpdatesServer("unauthorized")
+	defer server.Close()
+
+	client := telegraph.NewClient("token", telegraph.WithBaseURL(server.URL))
+
+	err := client.StartPolling(context.Background(), telegraph.HandlerFunc(func(ctx context.Context, update *telegraph.Update) {
+		t.Fail()
+	}))
+
+	assert.True(t, telegraph.IsUnauthorized(err))
+}
+
+func TestPoller_Conflict(t *testing.T) {
+	fake, server := newFakeUpdatesServer("conflict")
+	defer server.Close()
+
+	client := telegraph.NewClient("token", telegraph.WithBaseURL(server.URL))
+
+	err := client.NewPoller().SetTimeout(0).SetAllowedUpdates().Start(context.Background(),
+		telegraph.HandlerFunc(func(ctx context.Context, update *telegraph.Update) {
+			t.Fail()
+		}))
+
+	assert.True(t, telegraph.IsConflict(err))
+	assert.Equal(t, []string{"allowed_updates=%5B%5D&timeout=0"}, fake.Queries())
+}
+
+func TestPoller_Updates(t *testing.T) {
+	fake, server := newFakeUpdatesServer(updateBatch(10, 11, 12))
+	defer server.Close()
+
+	client := telegraph.NewClient("token", telegraph.WithBaseURL(server.URL))
+	ctx, cancel := context.WithCancel(context.Background())
+
+	poller := client.NewPoller().SetTimeout(0)
+	updates := poller.Updates(ctx)
+
+	first := <-updates
+	second := <-updates
+	cancel()
+
+	offset := second.UpdateID + 1
+	for update := range updates {
+		offset = update.UpdateID + 1
+	}
+
+	assert.Equal(t, int64(10), first.UpdateID)
+	assert.Equal(t, int64(11), second.UpdateID)
+	assert.Equal(t, context.Canceled, poller.Err())
+	assert.Equal(t, fmt.Sprintf("limit=1&offset=%v&timeout=0", offset), fake.Queries()[len(fake.Queries())-1])
+}
@@ -0,0 +1,249 @@
+package telegraph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/parnurzeal/gorequest"
+)
+
+// ErrRateLimited returned by Commit when rate limiter is in fail fast mode and the request can't be sent immediately
+var ErrRateLimited = errors.New("telegraph: rate limit exceeded")
+
+// chatBucketCleanup number of chat buckets kept before idle buckets are removed
+const chatBucketCleanup = 1024
+
+// RateLimiter throttle outgoing send and edit request with a global bucket and a bucket per chat,
+// so broadcasting doesn't hit telegram limits. Requests are spaced evenly, e.g. 30 requests per second
+// means one request every 33ms. Group, supergroup and channel (negative id or @username) use group limit,
+// other chats use private chat limit
+type RateLimiter struct {
+	mu       sync.Mutex
+	global   rateBucket
+	private  time.Duration
+	group    time.Duration
+	chats    map[string]*rateBucket
+	failFast bool
+}
+
+type rateBucket struct {
+	interval time.Duration
+	next     time.Time
+}
+
+// NewRateLimiter create rate limiter with telegram default limits,
+// 30 requests per second globally, 1 request per second per private chat and 20 requests per minute per group
+func NewRateLimiter() *RateLimiter {
+	return &RateLimiter{
+		global:  rateBucket{interval: time.Second / 30},
+		private: time.Second,
+		group:   time.Minute / 20,
+		chats:   map[string]*rateBucket{},
+	}
+}
+
+// WithRateLimiter pass every send and edit request through limiter before it is sent to telegram, retry included
+func WithRateLimiter(limiter *RateLimiter) ClientOption {
+	return func(client *Client) {
+		client.rateLimiter = limiter
+	}
+}
+
+// SetGlobalLimit allow limit requests per duration for all chats, zero limit disable global limit
+func (limiter *RateLimiter) SetGlobalLimit(limit int, per time.Duration) *RateLimiter {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	limiter.global.interval = rateInterval(limit, per)
+	return limiter
+}
+
+// SetPrivateChatLimit allow limit requests per duration for each private chat, zero limit disable the limit
+func (limiter *RateLimiter) SetPrivateChatLimit(limit int, per time.Duration) *RateLimiter {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	limiter.private = rateInterval(limit, per)
+	return limiter
+}
+
+// SetGroupChatLimit allow limit requests per duration for each group, supergroup or channel, zero limit disable the limit
+func (limiter *RateLimiter) SetGroupChatLimit(limit int, per time.Duration) *RateLimiter {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	limiter.group = rateInterval(limit, per)
+	return limiter
+}
+
+// SetFailFast return ErrRateLimited immediately instead of waiting when request would exceed the limit
+func (limiter *RateLimiter) SetFailFast(failFast bool) *RateLimiter {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	limiter.failFast = failFast
+	return limiter
+}
+
+// Wait block until a request to chatID is allowed or ctx is done, nil chatID only use the global limit.
+// The slot is reserved when Wait is called, so waiting requests are allowed in the order they called Wait.
+// Request canceled while waiting gives the slot back unless a later request has reserved the next one.
+// In fail fast mode ErrRateLimited is returned instead of waiting
+func (limiter *RateLimiter) Wait(ctx context.Context, chatID interface{}) error {
+	wait, release, err := limiter.reserve(chatID)
+	if err != nil || wait <= 0 {
+		return err
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		release()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// reserve take the first slot allowed by both global and chat bucket and return how long until it.
+// release give the slot back when it's still the last one reserved.
+// In fail fast mode nothing is reserved when the slot isn't available now
+func (limiter *RateLimiter) reserve(chatID interface{}) (wait time.Duration, release func(), err error) {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	now := time.Now()
+	at := now
+	if limiter.global.next.After(at) {
+		at = limiter.global.next
+	}
+
+	var chat *rateBucket
+	if key, group, ok := chatKey(chatID); ok {
+		interval := limiter.private
+		if group {
+			interval = limiter.group
+		}
+		if interval > 0 {
+			chat = limiter.chat(key, now)
+			chat.interval = interval
+			if chat.next.After(at) {
+				at = chat.next
+			}
+		}
+	}
+
+	if at.After(now) && limiter.failFast {
+		return 0, nil, ErrRateLimited
+	}
+
+	globalReserved := limiter.global.reserve(at)
+	var chatReserved func()
+	if chat != nil {
+		chatReserved = chat.reserve(at)
+	}
+
+	return at.Sub(now), func() {
+		limiter.mu.Lock()
+		defer limiter.mu.Unlock()
+
+		globalReserved()
+		if chatReserved != nil {
+			chatReserved()
+		}
+	}, nil
+}
+
+// reserve slot at and return function restoring the bucket if no later slot has been reserved, caller must hold the lock
+func (bucket *rateBucket) reserve(at time.Time) func() {
+	previous := bucket.next
+	bucket.next = at.Add(bucket.interval)
+	reserved := bucket.next
+
+	return func() {
+		if bucket.next.Equal(reserved) {
+			bucket.next = previous
+		}
+	}
+}
+
+// chat bucket for key, idle buckets are removed once there are too many
+func (limiter *RateLimiter) chat(key string, now time.Time) *rateBucket {
+	if bucket, ok := limiter.chats[key]; ok {
+		return bucket
+	}
+
+	if len(limiter.chats) >= chatBucketCleanup {
+		for k, bucket := range limiter.chats {
+			if !bucket.next.After(now) {
+				delete(limiter.chats, k)
+			}
+		}
+	}
+
+	bucket := &rateBucket{}
+	limiter.chats[key] = bucket
+	return bucket
+}
+
+// waitRateLimit wait for client rate limiter when request is a send or edit method
+func (client *Client) waitRateLimit(ctx context.Context, agent *gorequest.SuperAgent) error {
+	if client.rateLimiter == nil || !rateLimited(path.Base(agent.Url)) {
+		return nil
+	}
+
+	chatID, ok := agent.Data["chat_id"]
+	if !ok {
+		if id := agent.QueryData.Get("chat_id"); id != "" {
+			chatID = id
+		}
+	}
+
+	return client.rateLimiter.Wait(ctx, chatID)
+}
+
+// rateLimited report whether telegram method sends or edits message
+func rateLimited(method string) bool {
+	switch {
+	case method == "sendChatAction":
+		return false
+	case strings.HasPrefix(method, "send"), strings.HasPrefix(method, "edit"),
+		strings.HasPrefix(method, "forward"), strings.HasPrefix(method, "stop"):
+		return true
+	}
+
+	return false
+}
+
+// chatKey normalize chat id, group is true for group, supergroup and channel
+func chatKey(chatID interface{}) (key string, group bool, ok bool) {
+	switch id := chatID.(type) {
+	case nil:
+		return "", false, false
+	case float64:
+		key = strconv.FormatFloat(id, 'f', -1, 64)
+	default:
+		key = fmt.Sprint(id)
+	}
+	if key == "" {
+		return "", false, false
+	}
+
+	return key, strings.HasPrefix(key, "-") || strings.HasPrefix(key, "@"), true
+}
+
+func rateInterval(limit int, per time.Duration) time.Duration {
+	if limit <= 0 {
+		return 0
+	}
+
+	return per / time.Duration(limit)
+}
@@ -0,0 +1,109 @@
+package telegraph_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"telegraph"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func countingTransport(count *int) roundTripFunc {
+	return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		*count++
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       ioutil.NopCloser(strings.NewReader(`{"ok": true, "result": {"message_id": 1}}`)),
+			Request:    req,
+		}, nil
+	})
+}
+
+func TestRateLimiter_FailFastPerChat(t *testing.T) {
+	sent := 0
+	limiter := telegraph.NewRateLimiter().SetGlobalLimit(0, time.Second).SetFailFast(true)
+	client := telegraph.NewClient("token", telegraph.WithTransport(countingTransport(&sent)), telegraph.WithRateLimiter(limiter))
+
+	_, _, err := client.SendMessage(1234, "first").Commit()
+	assert.NoError(t, err)
+
+	_, _, err = client.SendMessage(1234, "second").Commit()
+	assert.Equal(t, telegraph.ErrRateLimited, err)
+
+	_, _, err = client.SendMessage(5678, "other chat").Commit()
+	assert.NoError(t, err)
+
+	// Chat action isn't limited
+	_, _, err = client.SendChatAction(1234, "typing").Commit()
+	assert.NoError(t, err)
+
+	assert.Equal(t, 3, sent)
+}
+
+func TestRateLimiter_GroupLimit(t *testing.T) {
+	limiter := telegraph.NewRateLimiter().SetGlobalLimit(0, time.Second).
+		SetPrivateChatLimit(1, time.Millisecond).SetGroupChatLimit(1, time.Hour).SetFailFast(true)
+
+	assert.NoError(t, limiter.Wait(context.Background(), int64(-100123)))
+	assert.Equal(t, telegraph.ErrRateLimited, limiter.Wait(context.Background(), int64(-100123)))
+	assert.NoError(t, limiter.Wait(context.Background(), "@cubesoft"))
+	assert.Equal(t, telegraph.ErrRateLimited, limiter.Wait(context.Background(), "@cubesoft"))
+
+	assert.NoError(t, limiter.Wait(context.Background(), 42))
+	time.Sleep(2 * time.Millisecond)
+	assert.NoError(t, limiter.Wait(context.Background(), 42))
+}
+
+func TestRateLimiter_GlobalBlocking(t *testing.T) {
+	limiter := telegraph.NewRateLimiter().SetGlobalLimit(10, 200*time.Millisecond)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, limiter.Wait(context.Background(), nil))
+	}
+	assert.True(t, time.Since(start) >= 40*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	limiter.SetGlobalLimit(1, time.Hour)
+	assert.NoError(t, limiter.Wait(context.Background(), nil))
+	assert.Equal(t, context.Canceled, limiter.Wait(ctx, nil))
+}
+
+func TestRateLimiter_CanceledWaitKeepsSlot(t *testing.T) {
+	limiter := telegraph.NewRateLimiter().SetGlobalLimit(1, 50*time.Millisecond)
+	assert.NoError(t, limiter.Wait(context.Background(), nil))
+
+	for i := 0; i < 5; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+		assert.Equal(t, context.DeadlineExceeded, limiter.Wait(ctx, nil))
+		cancel()
+	}
+
+	start := time.Now()
+	assert.NoError(t, limiter.Wait(context.Background(), nil))
+	assert.True(t, time.Since(start) < 100*time.Millisecond)
+}
+
+func TestRateLimiter_WaitInOrder(t *testing.T) {
+	limiter := telegraph.NewRateLimiter().SetGlobalLimit(1, 20*time.Millisecond)
+	assert.NoError(t, limiter.Wait(context.Background(), nil))
+
+	order := make(chan int, 5)
+	for i := 0; i < 5; i++ {
+		go func(i int) {
+			assert.NoError(t, limiter.Wait(context.Background(), nil))
+			order <- i
+		}(i)
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, i, <-order)
+	}
+}
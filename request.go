@@ -1,32 +1,34 @@
 package telegraph
 
 import (
+	"context"
 	"fmt"
 
 	"net/http"
-	"net/url"
 
-	"github.com/cenkalti/backoff"
 	"github.com/parnurzeal/gorequest"
 )
 
 type (
 	// VoidResponse struct to handle request and response telegram api
 	VoidResponse struct {
-		Client  *Client
-		Request *gorequest.SuperAgent
+		Client      *Client
+		Request     *gorequest.SuperAgent
+		retryPolicy RetryPolicy
 	}
 
 	// StringResponse struct to handle request and response telegram api
 	StringResponse struct {
-		Client  *Client
-		Request *gorequest.SuperAgent
+		Client      *Client
+		Request     *gorequest.SuperAgent
+		retryPolicy RetryPolicy
 	}
 
 	// IntegerResponse struct to handle request and response telegram api
 	IntegerResponse struct {
-		Client  *Client
-		Request *gorequest.SuperAgent
+		Client      *Client
+		Request     *gorequest.SuperAgent
+		retryPolicy RetryPolicy
 	}
 )
 
@@ -41,13 +43,14 @@ Available method can used with this method
 + SetCertificate()
 + SetMaxConnection()
 + SetAllowedUpdates()
++ SetSecretToken()
 */
 func (client *Client) SetWebHook(webHook string) *VoidResponse {
 	body := JSON{
 		"url": webHook,
 	}
 	endpoint := client.baseURL + fmt.Sprintf(EndpointSetWebHook, client.accessToken)
-	request := gorequest.New().Post(endpoint).Type(gorequest.TypeJSON).Set(UserAgentHeader, UserAgent+"/"+Version).Send(body)
+	request := gorequest.New().Post(endpoint).Type(gorequest.TypeJSON).Set(UserAgentHeader, client.userAgent).Send(body)
 
 	return &VoidResponse{
 		Client:  client,
@@ -57,8 +60,8 @@ func (client *Client) SetWebHook(webHook string) *VoidResponse {
 
 // SetCertificate Upload your public key certificate so that the root certificate in use can be checked.
 // See our self-signed guide for details.
-func (void *VoidResponse) SetCertificate(path string) *VoidResponse {
-	void.Request = void.Request.Type(gorequest.TypeMultipart).SendFile(path, "", "certificate")
+func (void *VoidResponse) SetCertificate(certificate *InputFile) *VoidResponse {
+	void.Request = certificate.attach(void.Request, "certificate")
 
 	return void
 }
@@ -91,13 +94,24 @@ func (void *VoidResponse) SetAllowedUpdates(allowed ...string) *VoidResponse {
 	return void
 }
 
+// SetSecretToken A secret token to be sent in a header “X-Telegram-Bot-Api-Secret-Token” in every webhook request, 1-256 characters.
+// Only characters A-Z, a-z, 0-9, _ and - are allowed. The header is useful to ensure that the request comes from a webhook set by you.
+func (void *VoidResponse) SetSecretToken(token string) *VoidResponse {
+	body := JSON{
+		"secret_token": token,
+	}
+	void.Request = void.Request.Send(body)
+
+	return void
+}
+
 /*
 DeleteWebHook Use this method to remove webhook integration if you decide to switch back to getUpdates.
 Returns True on success. Requires no parameters.
 */
 func (client *Client) DeleteWebHook() *VoidResponse {
 	endpoint := client.baseURL + fmt.Sprintf(EndpointDeleteWebHook, client.accessToken)
-	request := gorequest.New().Get(endpoint).Set(UserAgentHeader, UserAgent+"/"+Version)
+	request := gorequest.New().Get(endpoint).Set(UserAgentHeader, client.userAgent)
 
 	return &VoidResponse{
 		Client:  client,
@@ -124,7 +138,7 @@ func (client *Client) EditMessageLiveLocation(latitude, longitude float64) *Void
 		"longitude": longitude,
 	}
 	endpoint := client.baseURL + fmt.Sprintf(EndpointEditMessageLiveLocation, client.accessToken)
-	request := gorequest.New().Type(gorequest.TypeJSON).Post(endpoint).Set(UserAgentHeader, UserAgent+"/"+Version).Send(body)
+	request := gorequest.New().Type(gorequest.TypeJSON).Post(endpoint).Set(UserAgentHeader, client.userAgent).Send(body)
 
 	return &VoidResponse{
 		Client:  client,
@@ -144,7 +158,7 @@ Available method can used with this method
 */
 func (client *Client) StopMessageLiveLocation() *VoidResponse {
 	endpoint := client.baseURL + fmt.Sprintf(EndpointStopMessageLiveLocation, client.accessToken)
-	request := gorequest.New().Type(gorequest.TypeJSON).Post(endpoint).Set(UserAgentHeader, UserAgent+"/"+Version)
+	request := gorequest.New().Type(gorequest.TypeJSON).Post(endpoint).Set(UserAgentHeader, client.userAgent)
 
 	return &VoidResponse{
 		Client:  client,
@@ -170,7 +184,7 @@ func (client *Client) EditMessageText(text string) *VoidResponse {
 		"text": text,
 	}
 	endpoint := client.baseURL + fmt.Sprintf(EndpointEditMessageText, client.accessToken)
-	request := gorequest.New().Type(gorequest.TypeJSON).Post(endpoint).Set(UserAgentHeader, UserAgent+"/"+Version).Send(body)
+	request := gorequest.New().Type(gorequest.TypeJSON).Post(endpoint).Set(UserAgentHeader, client.userAgent).Send(body)
 
 	return &VoidResponse{
 		Client:  client,
@@ -214,7 +228,7 @@ func (client *Client) EditMessageCaption(caption string) *VoidResponse {
 		"caption": caption,
 	}
 	endpoint := client.baseURL + fmt.Sprintf(EndpointEditMessageCaption, client.accessToken)
-	request := gorequest.New().Type(gorequest.TypeJSON).Post(endpoint).Set(UserAgentHeader, UserAgent+"/"+Version).Send(body)
+	request := gorequest.New().Type(gorequest.TypeJSON).Post(endpoint).Set(UserAgentHeader, client.userAgent).Send(body)
 
 	return &VoidResponse{
 		Client:  client,
@@ -234,7 +248,7 @@ Available method can used with this method
 */
 func (client *Client) EditMessageReplyMarkup() *VoidResponse {
 	endpoint := client.baseURL + fmt.Sprintf(EndpointEditMessageReplyMarkup, client.accessToken)
-	request := gorequest.New().Type(gorequest.TypeJSON).Post(endpoint).Set(UserAgentHeader, UserAgent+"/"+Version)
+	request := gorequest.New().Type(gorequest.TypeJSON).Post(endpoint).Set(UserAgentHeader, client.userAgent)
 
 	return &VoidResponse{
 		Client:  client,
@@ -273,14 +287,10 @@ func (void *VoidResponse) SetInlineMessageID(inlineMessage string) *VoidResponse
 	return void
 }
 
-// SetReplyMarkup A JSON-serialized object for a new inline keyboard.
-func (void *VoidResponse) SetReplyMarkup(inline [][]InlineKeyboardButton) *VoidResponse {
-	body := JSON{
-		"reply_markup": JSON{
-			"inline_keyboard": inline,
-		},
-	}
-	void.Request = void.Request.Send(body)
+// SetReplyMarkup Additional interface options. Edited message only accept InlineKeyboardMarkup.
+// Only one reply markup can be set, Commit return ErrConflictingReplyMarkup otherwise
+func (void *VoidResponse) SetReplyMarkup(markup ReplyMarkup) *VoidResponse {
+	void.Request = sendReplyMarkup(void.Request, markup)
 
 	return void
 }
@@ -298,7 +308,7 @@ Returns True on success.
 */
 func (client *Client) DeleteMessage(chatId interface{}, messageId int64) *VoidResponse {
 	endpoint := client.baseURL + fmt.Sprintf(EndpointDeleteMessage, client.accessToken)
-	request := gorequest.New().Type(gorequest.TypeJSON).Get(endpoint).Set(UserAgentHeader, UserAgent+"/"+Version).
+	request := gorequest.New().Type(gorequest.TypeJSON).Get(endpoint).Set(UserAgentHeader, client.userAgent).
 		Query(fmt.Sprintf("chat_id=%v&message_id=%v", chatId, messageId))
 
 	return &VoidResponse{
@@ -326,7 +336,7 @@ func (client *Client) SendChatAction(chatId interface{}, action string) *VoidRes
 		"action":  action,
 	}
 	endpoint := client.baseURL + fmt.Sprintf(EndpointSendChatAction, client.accessToken)
-	request := gorequest.New().Type(gorequest.TypeJSON).Post(endpoint).Set(UserAgentHeader, UserAgent+"/"+Version).Send(body)
+	request := gorequest.New().Type(gorequest.TypeJSON).Post(endpoint).Set(UserAgentHeader, client.userAgent).Send(body)
 
 	return &VoidResponse{
 		Client:  client,
@@ -354,7 +364,7 @@ func (client *Client) KickChatMember(chatId interface{}, userId int64) *VoidResp
 		"user_id": userId,
 	}
 	endpoint := client.baseURL + fmt.Sprintf(EndpointKickChatMember, client.accessToken)
-	request := gorequest.New().Type(gorequest.TypeJSON).Post(endpoint).Set(UserAgentHeader, UserAgent+"/"+Version).Send(body)
+	request := gorequest.New().Type(gorequest.TypeJSON).Post(endpoint).Set(UserAgentHeader, client.userAgent).Send(body)
 
 	return &VoidResponse{
 		Client:  client,
@@ -382,7 +392,7 @@ func (client *Client) RestrictChatMember(chatId interface{}, userId int64) *Void
 		"user_id": userId,
 	}
 	endpoint := client.baseURL + fmt.Sprintf(EndpointRestrictChatMember, client.accessToken)
-	request := gorequest.New().Type(gorequest.TypeJSON).Post(endpoint).Set(UserAgentHeader, UserAgent+"/"+Version).Send(body)
+	request := gorequest.New().Type(gorequest.TypeJSON).Post(endpoint).Set(UserAgentHeader, client.userAgent).Send(body)
 
 	return &VoidResponse{
 		Client:  client,
@@ -465,7 +475,7 @@ func (client *Client) PromoteChatMember(chatId interface{}, userId int64) *VoidR
 		"user_id": userId,
 	}
 	endpoint := client.baseURL + fmt.Sprintf(EndpointPromoteChatMember, client.accessToken)
-	request := gorequest.New().Type(gorequest.TypeJSON).Post(endpoint).Set(UserAgentHeader, UserAgent+"/"+Version).Send(body)
+	request := gorequest.New().Type(gorequest.TypeJSON).Post(endpoint).Set(UserAgentHeader, client.userAgent).Send(body)
 
 	return &VoidResponse{
 		Client:  client,
@@ -557,10 +567,11 @@ func (void *VoidResponse) SetCanPromoteMember(can bool) *VoidResponse {
 /*
 GetContent function for download file from telegram server, file path obtained from function GetFile()
 Exp https://api.telegram.org/file/bot<token>/<file_path>
+The whole content is read into memory, use DownloadFile to stream the content by file id
 */
 func (client *Client) GetContent(path string) *VoidResponse {
 	endpoint := client.baseURL + fmt.Sprintf(EndpointGetContent, client.accessToken, path)
-	request := gorequest.New().Get(endpoint).Set(UserAgentHeader, UserAgent+"/"+Version)
+	request := gorequest.New().Get(endpoint).Set(UserAgentHeader, client.userAgent)
 
 	return &VoidResponse{
 		Client:  client,
@@ -581,7 +592,7 @@ func (client *Client) UnbanChatMember(chatId interface{}, userId int64) *VoidRes
 		"user_id": userId,
 	}
 	endpoint := client.baseURL + fmt.Sprintf(EndpointUnbanChatMember, client.accessToken)
-	request := gorequest.New().Type(gorequest.TypeJSON).Post(endpoint).Set(UserAgentHeader, UserAgent+"/"+Version).Send(body)
+	request := gorequest.New().Type(gorequest.TypeJSON).Post(endpoint).Set(UserAgentHeader, client.userAgent).Send(body)
 
 	return &VoidResponse{
 		Client:  client,
@@ -594,15 +605,15 @@ SetChatPhoto Use this method to set a new profile photo for the chat. Photos can
 The bot must be an administrator in the chat for this to work and must have the appropriate admin rights. Returns True on success.
 Note: In regular groups (non-supergroups), this method will only work if the ‘All Members Are Admins’ setting is off in the target group.
 + chatId - Unique identifier for the target chat or username of the target channel (in the format @channelusername)
-+ photo - New chat photo, uploaded using multipart/form-data
++ photo - New chat photo, uploaded with FilePath, FileReader or FileBytes
 */
-func (client *Client) SetChatPhoto(chatId interface{}, photo string) *VoidResponse {
+func (client *Client) SetChatPhoto(chatId interface{}, photo *InputFile) *VoidResponse {
 	body := JSON{
 		"chat_id": chatId,
 	}
 	endpoint := client.baseURL + fmt.Sprintf(EndpointSetChatPhoto, client.accessToken)
-	request := gorequest.New().Type(gorequest.TypeMultipart).Post(endpoint).Set(UserAgentHeader, UserAgent+"/"+Version).
-		Send(body).SendFile(photo, "", "photo")
+	request := gorequest.New().Type(gorequest.TypeMultipart).Post(endpoint).Set(UserAgentHeader, client.userAgent).Send(body)
+	request = photo.attach(request, "photo")
 
 	return &VoidResponse{
 		Client:  client,
@@ -618,7 +629,7 @@ Note: In regular groups (non-supergroups), this method will only work if the ‘
 */
 func (client *Client) DeleteChatPhoto(chatId interface{}) *VoidResponse {
 	endpoint := client.baseURL + fmt.Sprintf(EndpointDeleteChatPhoto, client.accessToken)
-	request := gorequest.New().Type(gorequest.TypeJSON).Get(endpoint).Set(UserAgentHeader, UserAgent+"/"+Version).
+	request := gorequest.New().Type(gorequest.TypeJSON).Get(endpoint).Set(UserAgentHeader, client.userAgent).
 		Query(fmt.Sprintf("chat_id=%v", chatId))
 
 	return &VoidResponse{
@@ -640,7 +651,7 @@ func (client *Client) SetChatTitle(chatId interface{}, title string) *VoidRespon
 		"title":   title,
 	}
 	endpoint := client.baseURL + fmt.Sprintf(EndpointSetChatTitle, client.accessToken)
-	request := gorequest.New().Type(gorequest.TypeJSON).Post(endpoint).Set(UserAgentHeader, UserAgent+"/"+Version).Send(body)
+	request := gorequest.New().Type(gorequest.TypeJSON).Post(endpoint).Set(UserAgentHeader, client.userAgent).Send(body)
 
 	return &VoidResponse{
 		Client:  client,
@@ -660,7 +671,7 @@ func (client *Client) SetChatDescription(chatId interface{}, description string)
 		"description": description,
 	}
 	endpoint := client.baseURL + fmt.Sprintf(EndpointSetChatDescription, client.accessToken)
-	request := gorequest.New().Type(gorequest.TypeJSON).Post(endpoint).Set(UserAgentHeader, UserAgent+"/"+Version).Send(body)
+	request := gorequest.New().Type(gorequest.TypeJSON).Post(endpoint).Set(UserAgentHeader, client.userAgent).Send(body)
 
 	return &VoidResponse{
 		Client:  client,
@@ -684,7 +695,7 @@ func (client *Client) PinChatMessage(chatId interface{}, messageId int64) *VoidR
 		"message_id": messageId,
 	}
 	endpoint := client.baseURL + fmt.Sprintf(EndpointPinChatMessage, client.accessToken)
-	request := gorequest.New().Type(gorequest.TypeJSON).Post(endpoint).Set(UserAgentHeader, UserAgent+"/"+Version).Send(body)
+	request := gorequest.New().Type(gorequest.TypeJSON).Post(endpoint).Set(UserAgentHeader, client.userAgent).Send(body)
 
 	return &VoidResponse{
 		Client:  client,
@@ -711,7 +722,7 @@ Returns True on success.
 */
 func (client *Client) UnpinChatMessage(chatId interface{}) *VoidResponse {
 	endpoint := client.baseURL + fmt.Sprintf(EndpointUnpinChatMessage, client.accessToken)
-	request := gorequest.New().Type(gorequest.TypeJSON).Get(endpoint).Set(UserAgentHeader, UserAgent+"/"+Version).
+	request := gorequest.New().Type(gorequest.TypeJSON).Get(endpoint).Set(UserAgentHeader, client.userAgent).
 		Query(fmt.Sprintf("chat_id=%v", chatId))
 
 	return &VoidResponse{
@@ -726,7 +737,7 @@ LeaveChat Use this method for your bot to leave a group, supergroup or channel.
 */
 func (client *Client) LeaveChat(chatId interface{}) *VoidResponse {
 	endpoint := client.baseURL + fmt.Sprintf(EndpointLeaveChat, client.accessToken)
-	request := gorequest.New().Type(gorequest.TypeJSON).Get(endpoint).Set(UserAgentHeader, UserAgent+"/"+Version).
+	request := gorequest.New().Type(gorequest.TypeJSON).Get(endpoint).Set(UserAgentHeader, client.userAgent).
 		Query(fmt.Sprintf("chat_id=%v", chatId))
 
 	return &VoidResponse{
@@ -749,7 +760,7 @@ func (client *Client) SetChatStickerSet(chatId interface{}, name string) *VoidRe
 		"sticker_set_name": name,
 	}
 	endpoint := client.baseURL + fmt.Sprintf(EndpointSetChatStickerSet, client.accessToken)
-	request := gorequest.New().Type(gorequest.TypeJSON).Post(endpoint).Set(UserAgentHeader, UserAgent+"/"+Version).Send(body)
+	request := gorequest.New().Type(gorequest.TypeJSON).Post(endpoint).Set(UserAgentHeader, client.userAgent).Send(body)
 
 	return &VoidResponse{
 		Client:  client,
@@ -766,7 +777,7 @@ Returns True on success.
 */
 func (client *Client) DeleteChatStickerSet(chatId interface{}) *VoidResponse {
 	endpoint := client.baseURL + fmt.Sprintf(EndpointDeleteChatStickerSet, client.accessToken)
-	request := gorequest.New().Type(gorequest.TypeJSON).Get(endpoint).Set(UserAgentHeader, UserAgent+"/"+Version).
+	request := gorequest.New().Type(gorequest.TypeJSON).Get(endpoint).Set(UserAgentHeader, client.userAgent).
 		Query(fmt.Sprintf("chat_id=%v", chatId))
 
 	return &VoidResponse{
@@ -795,7 +806,7 @@ func (client *Client) AnswerCallbackQuery(queryId string) *VoidResponse {
 		"callback_query_id": queryId,
 	}
 	endpoint := client.baseURL + fmt.Sprintf(EndpointAnswerCallbackQuery, client.accessToken)
-	request := gorequest.New().Type(gorequest.TypeJSON).Post(endpoint).Set(UserAgentHeader, UserAgent+"/"+Version).Send(body)
+	request := gorequest.New().Type(gorequest.TypeJSON).Post(endpoint).Set(UserAgentHeader, client.userAgent).Send(body)
 
 	return &VoidResponse{
 		Client:  client,
@@ -844,7 +855,7 @@ The bot will be able to edit the created sticker set. Returns True on success.
   <bot_username> is case insensitive. 1-64 characters
 - title Sticker set title, 1-64 characters
 - pngSticker Png image with the sticker, must be up to 512 kilobytes in size, dimensions must not exceed 512px, and either width or height must be exactly 512px.
-  Pass a file_id as a String to send a file that already exists on the Telegram servers, pass an HTTP URL as a String for Telegram to get a file from the Internet, or upload a new one using multipart/form-data
+  Pass FileID to send a file that already exists on the Telegram servers, pass FileURL for Telegram to get a file from the Internet, or upload a new one with FilePath, FileReader or FileBytes
 - emojis One or more emoji corresponding to the sticker
 - upload set true if upload file to telegram from local
 
@@ -854,27 +865,23 @@ The bot will be able to edit the created sticker set. Returns True on success.
   <bot_username> is case insensitive. 1-64 characters.
 + title - Sticker set title, 1-64 characters
 + pngSticker - Png image with the sticker, must be up to 512 kilobytes in size, dimensions must not exceed 512px, and either width or height must be exactly 512px.
-  Pass a file_id as a String to send a file that already exists on the Telegram servers, pass an HTTP URL as a String for Telegram to get a file from the Internet, or upload a new one using multipart/form-data.
+  Pass FileID to send a file that already exists on the Telegram servers, pass FileURL for Telegram to get a file from the Internet, or upload a new one with FilePath, FileReader or FileBytes.
 + emojis - One or more emoji corresponding to the sticker
 
 Available method can used with this method
 + SetContainsMask()
 + SetMaskPosition()
 */
-func (client *Client) CreateNewStickerSet(userId int64, name, title, pngSticker, emojis string) *VoidResponse {
+func (client *Client) CreateNewStickerSet(userId int64, name, title string, pngSticker *InputFile, emojis string) *VoidResponse {
 	body := JSON{
-		"user_id":     userId,
-		"name":        name,
-		"title":       title,
-		"png_sticker": pngSticker,
-		"emojis":      emojis,
+		"user_id": userId,
+		"name":    name,
+		"title":   title,
+		"emojis":  emojis,
 	}
 	endpoint := client.baseURL + fmt.Sprintf(EndpointCreateNewStickerSet, client.accessToken)
-	request := gorequest.New().Post(endpoint).Set(UserAgentHeader, UserAgent+"/"+Version).Send(body)
-
-	if _, err := url.ParseRequestURI(pngSticker); err != nil {
-		request.Type(gorequest.TypeMultipart).SendFile(pngSticker, "", "png_sticker")
-	}
+	request := gorequest.New().Post(endpoint).Set(UserAgentHeader, client.userAgent).Send(body)
+	request = pngSticker.attach(request, "png_sticker")
 
 	return &VoidResponse{
 		Client:  client,
@@ -897,32 +904,28 @@ AddStickerToSet Use this method to add a new sticker to a set created by the bot
 - userId User identifier of sticker set owner
 - name Sticker set name
 - pngSticker Png image with the sticker, must be up to 512 kilobytes in size, dimensions must not exceed 512px, and either width or height must be exactly 512px.
-  Pass a file_id as a String to send a file that already exists on the Telegram servers, pass an HTTP URL as a String for Telegram to get a file from the Internet, or upload a new one using multipart/form-data.
+  Pass FileID to send a file that already exists on the Telegram servers, pass FileURL for Telegram to get a file from the Internet, or upload a new one with FilePath, FileReader or FileBytes.
 - emojis One or more emoji corresponding to the sticker
 - upload set true if upload file to telegram from local
 
 + userId - User identifier of sticker set owner
 + name - Sticker set name
 + pngSticker - Png image with the sticker, must be up to 512 kilobytes in size, dimensions must not exceed 512px, and either width or height must be exactly 512px.
-  Pass a file_id as a String to send a file that already exists on the Telegram servers, pass an HTTP URL as a String for Telegram to get a file from the Internet, or upload a new one using multipart/form-data.
+  Pass FileID to send a file that already exists on the Telegram servers, pass FileURL for Telegram to get a file from the Internet, or upload a new one with FilePath, FileReader or FileBytes.
 + emojis - One or more emoji corresponding to the sticker
 
 Available method can used with this method
 + SetMaskPosition()
 */
-func (client *Client) AddStickerToSet(userId int64, name, pngSticker, emojis string) *VoidResponse {
+func (client *Client) AddStickerToSet(userId int64, name string, pngSticker *InputFile, emojis string) *VoidResponse {
 	body := JSON{
-		"user_id":     userId,
-		"name":        name,
-		"png_sticker": pngSticker,
-		"emojis":      emojis,
+		"user_id": userId,
+		"name":    name,
+		"emojis":  emojis,
 	}
 	endpoint := client.baseURL + fmt.Sprintf(EndpointAddStickerToSet, client.accessToken)
-	request := gorequest.New().Post(endpoint).Set(UserAgentHeader, UserAgent+"/"+Version).Send(body)
-
-	if _, err := url.ParseRequestURI(pngSticker); err != nil {
-		request.Type(gorequest.TypeMultipart).SendFile(pngSticker, "", "png_sticker")
-	}
+	request := gorequest.New().Post(endpoint).Set(UserAgentHeader, client.userAgent).Send(body)
+	request = pngSticker.attach(request, "png_sticker")
 
 	return &VoidResponse{
 		Client:  client,
@@ -954,7 +957,7 @@ func (client *Client) SetStickerPositionInSet(sticker string, position int) *Voi
 		"position": position,
 	}
 	endpoint := client.baseURL + fmt.Sprintf(EndpointSetStickerPositionInSet, client.accessToken)
-	request := gorequest.New().Post(endpoint).Set(UserAgentHeader, UserAgent+"/"+Version).Type(gorequest.TypeJSON).Send(body)
+	request := gorequest.New().Post(endpoint).Set(UserAgentHeader, client.userAgent).Type(gorequest.TypeJSON).Send(body)
 
 	return &VoidResponse{
 		Client:  client,
@@ -968,7 +971,7 @@ DeleteStickerFromSet Use this method to delete a sticker from a set created by t
 */
 func (client *Client) DeleteStickerFromSet(sticker string) *VoidResponse {
 	endpoint := client.baseURL + fmt.Sprintf(EndpointDeleteStickerFromSet, client.accessToken)
-	request := gorequest.New().Get(endpoint).Set(UserAgentHeader, UserAgent+"/"+Version).Type(gorequest.TypeJSON).
+	request := gorequest.New().Get(endpoint).Set(UserAgentHeader, client.userAgent).Type(gorequest.TypeJSON).
 		Query(fmt.Sprintf("sticker=%v", sticker))
 
 	return &VoidResponse{
@@ -981,7 +984,7 @@ func (client *Client) DeleteStickerFromSet(sticker string) *VoidResponse {
 AnswerInlineQuery Use this method to send answers to an inline query. On success, True is returned.
 No more than 50 results per query are allowed.
 + inlineQueryId - Unique identifier for the answered query
-+ result - A JSON-serialized array of results for the inline query
++ result - Results for the inline query, e.g. InlineQueryResultArticle. Result with missing required field is returned as error by Commit
 
 Available method can used with this method
 + SetCacheTime()
@@ -990,13 +993,17 @@ Available method can used with this method
 + SetSwitchPMText()
 + SetSwitchPMParameter()
 */
-func (client *Client) AnswerInlineQuery(inlineQueryId string, result ...JSON) *VoidResponse {
+func (client *Client) AnswerInlineQuery(inlineQueryId string, result ...InlineQueryResult) *VoidResponse {
+	results, err := inlineQueryResultsJSON(result)
 	body := JSON{
 		"inline_query_id": inlineQueryId,
-		"results":         result,
+		"results":         results,
 	}
 	endpoint := client.baseURL + fmt.Sprintf(EndpointAnswerInlineQuery, client.accessToken)
-	request := gorequest.New().Post(endpoint).Set(UserAgentHeader, UserAgent+"/"+Version).Type(gorequest.TypeJSON).Send(body)
+	request := gorequest.New().Post(endpoint).Set(UserAgentHeader, client.userAgent).Type(gorequest.TypeJSON).Send(body)
+	if err != nil {
+		request.Errors = append(request.Errors, err)
+	}
 
 	return &VoidResponse{
 		Client:  client,
@@ -1064,32 +1071,21 @@ func (void *VoidResponse) SetSwitchPMParameter(param string) *VoidResponse {
 	return void
 }
 
-// Commit execute request to telegram
-func (void *VoidResponse) Commit() ([]byte, *http.Response, error) {
-	var body []byte
-	var errs []error
-
-	res := &http.Response{}
-	model := struct {
-		ErrorResponse
-	}{}
+// SetRetryPolicy decide how this request is retried when it fails, override retry policy of the client
+func (void *VoidResponse) SetRetryPolicy(policy RetryPolicy) *VoidResponse {
+	void.retryPolicy = policy
 
-	operation := func() error {
-		res, body, errs = void.Request.EndBytes()
-		if len(errs) > 0 {
-			return errs[0]
-		}
-		return nil
-	}
+	return void
+}
 
-	if err := backoff.Retry(operation, void.Client.expBackOff); err != nil {
-		return nil, MakeHTTPResponse(void.Request), err
-	}
-	if res.StatusCode != http.StatusOK {
-		return nil, res, fmt.Errorf("%v %v", model.ErrorCode, model.Description)
-	}
+// Commit execute request to telegram
+func (void *VoidResponse) Commit() ([]byte, *http.Response, error) {
+	return void.CommitContext(context.Background())
+}
 
-	return body, res, nil
+// CommitContext execute request to telegram, the request and its back off retry are aborted when ctx is done
+func (void *VoidResponse) CommitContext(ctx context.Context) ([]byte, *http.Response, error) {
+	return void.Client.commit(ctx, void.Request, void.retryPolicy, nil)
 }
 
 /*
@@ -1103,7 +1099,7 @@ func (client *Client) ExportChatInviteLink(chatId interface{}) *StringResponse {
 		"chat_id": chatId,
 	}
 	endpoint := client.baseURL + fmt.Sprintf(EndpointExportChatInviteLink, client.accessToken)
-	request := gorequest.New().Type(gorequest.TypeJSON).Post(endpoint).Set(UserAgentHeader, UserAgent+"/"+Version).Send(body)
+	request := gorequest.New().Type(gorequest.TypeJSON).Post(endpoint).Set(UserAgentHeader, client.userAgent).Send(body)
 
 	return &StringResponse{
 		Client:  client,
@@ -1111,29 +1107,27 @@ func (client *Client) ExportChatInviteLink(chatId interface{}) *StringResponse {
 	}
 }
 
+// SetRetryPolicy decide how this request is retried when it fails, override retry policy of the client
+func (void *StringResponse) SetRetryPolicy(policy RetryPolicy) *StringResponse {
+	void.retryPolicy = policy
+
+	return void
+}
+
 // Commit execute request to telegram
 func (void *StringResponse) Commit() (string, *http.Response, error) {
-	var errs []error
+	return void.CommitContext(context.Background())
+}
 
-	res := &http.Response{}
+// CommitContext execute request to telegram, the request and its back off retry are aborted when ctx is done
+func (void *StringResponse) CommitContext(ctx context.Context) (string, *http.Response, error) {
 	model := struct {
-		ErrorResponse
 		Result string `json:"result,omitempty"`
 	}{}
 
-	operation := func() error {
-		res, _, errs = void.Request.EndStruct(&model)
-		if len(errs) > 0 {
-			return errs[0]
-		}
-		return nil
-	}
-
-	if err := backoff.Retry(operation, void.Client.expBackOff); err != nil {
-		return "", MakeHTTPResponse(void.Request), err
-	}
-	if res.StatusCode != http.StatusOK {
-		return "", res, fmt.Errorf("%v %v", model.ErrorCode, model.Description)
+	_, res, err := void.Client.commit(ctx, void.Request, void.retryPolicy, &model)
+	if err != nil {
+		return "", res, err
 	}
 
 	return model.Result, res, nil
@@ -1145,7 +1139,7 @@ GetChatMembersCount Use this method to get the number of members in a chat. Retu
 */
 func (client *Client) GetChatMembersCount(chatId interface{}) *IntegerResponse {
 	endpoint := client.baseURL + fmt.Sprintf(EndpointGetChatMembersCount, client.accessToken)
-	request := gorequest.New().Type(gorequest.TypeJSON).Get(endpoint).Set(UserAgentHeader, UserAgent+"/"+Version).
+	request := gorequest.New().Type(gorequest.TypeJSON).Get(endpoint).Set(UserAgentHeader, client.userAgent).
 		Query(fmt.Sprintf("chat_id=%v", chatId))
 
 	return &IntegerResponse{
@@ -1154,29 +1148,27 @@ func (client *Client) GetChatMembersCount(chatId interface{}) *IntegerResponse {
 	}
 }
 
+// SetRetryPolicy decide how this request is retried when it fails, override retry policy of the client
+func (void *IntegerResponse) SetRetryPolicy(policy RetryPolicy) *IntegerResponse {
+	void.retryPolicy = policy
+
+	return void
+}
+
 // Commit execute request to telegram
 func (void *IntegerResponse) Commit() (*int64, *http.Response, error) {
-	var errs []error
+	return void.CommitContext(context.Background())
+}
 
-	res := &http.Response{}
+// CommitContext execute request to telegram, the request and its back off retry are aborted when ctx is done
+func (void *IntegerResponse) CommitContext(ctx context.Context) (*int64, *http.Response, error) {
 	model := struct {
-		ErrorResponse
 		Result *int64 `json:"result,omitempty"`
 	}{}
 
-	operation := func() error {
-		res, _, errs = void.Request.EndStruct(&model)
-		if len(errs) > 0 {
-			return errs[0]
-		}
-		return nil
-	}
-
-	if err := backoff.Retry(operation, void.Client.expBackOff); err != nil {
-		return nil, MakeHTTPResponse(void.Request), err
-	}
-	if res.StatusCode != http.StatusOK {
-		return nil, res, fmt.Errorf("%v %v", model.ErrorCode, model.Description)
+	_, res, err := void.Client.commit(ctx, void.Request, void.retryPolicy, &model)
+	if err != nil {
+		return nil, res, err
 	}
 
 	return model.Result, res, nil
@@ -20,7 +20,7 @@ func TestSetWebHook_Success(t *testing.T) {
 
 	client := telegraph.NewClient("token")
 
-	body, status, err := client.SetWebHook("https://www.cubesoft.co.id").SetCertificate("./LICENSE").
+	body, status, err := client.SetWebHook("https://www.cubesoft.co.id").SetCertificate(telegraph.FilePath("./LICENSE")).
 		SetMaxConnection(100).SetAllowedUpdates("1", "2", "3").Commit()
 
 	assert.NotNil(t, body)
@@ -32,9 +32,9 @@ func TestSetWebHook_Error(t *testing.T) {
 	gock.New(telegraph.BaseURL).Head(fmt.Sprintf(telegraph.EndpointSetWebHook, "token")).Reply(http.StatusInternalServerError).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 
-	body, status, err := client.SetWebHook("https://www.cubesoft.co.id").SetCertificate("./LICENSE").
+	body, status, err := client.SetWebHook("https://www.cubesoft.co.id").SetCertificate(telegraph.FilePath("./LICENSE")).
 		SetMaxConnection(100).SetAllowedUpdates("1", "2", "3").Commit()
 
 	assert.Nil(t, body)
@@ -50,9 +50,9 @@ func TestSetWebHook_Failed(t *testing.T) {
 	}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 
-	body, status, err := client.SetWebHook("https://www.cubesoft.co.id").SetCertificate("./LICENSE").
+	body, status, err := client.SetWebHook("https://www.cubesoft.co.id").SetCertificate(telegraph.FilePath("./LICENSE")).
 		SetMaxConnection(100).SetAllowedUpdates("1", "2", "3").Commit()
 
 	assert.Nil(t, body)
@@ -81,7 +81,7 @@ func TestDeleteWebHook_Error(t *testing.T) {
 	gock.New(telegraph.BaseURL).Head(fmt.Sprintf(telegraph.EndpointDeleteWebHook, "token")).Reply(http.StatusInternalServerError).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 
 	body, status, err := client.DeleteWebHook().Commit()
 
@@ -98,7 +98,7 @@ func TestDeleteWebHook_Failed(t *testing.T) {
 	}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 
 	body, status, err := client.DeleteWebHook().Commit()
 
@@ -117,7 +117,7 @@ func TestEditMessageLiveLocation_Success(t *testing.T) {
 	client := telegraph.NewClient("token")
 	body, res, err := client.EditMessageLiveLocation(12312312.98, 324234324.67).SetChatID(21342321).
 		SetMessageID(234234234).SetInlineMessageID("test").
-		SetReplyMarkup([][]telegraph.InlineKeyboardButton{}).Commit()
+		SetReplyMarkup(telegraph.InlineKeyboardMarkup{}).Commit()
 
 	assert.NotNil(t, body)
 	assert.NotNil(t, res)
@@ -129,10 +129,10 @@ func TestEditMessageLiveLocation_Error(t *testing.T) {
 	gock.New(telegraph.BaseURL).Post(fmt.Sprintf(telegraph.EndpointEditMessageLiveLocation, "token")).Reply(http.StatusInternalServerError).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.EditMessageLiveLocation(12312312.98, 324234324.67).SetChatID(21342321).
 		SetMessageID(234234234).SetInlineMessageID("test").
-		SetReplyMarkup([][]telegraph.InlineKeyboardButton{}).Commit()
+		SetReplyMarkup(telegraph.InlineKeyboardMarkup{}).Commit()
 
 	assert.Nil(t, body)
 	assert.NotNil(t, res)
@@ -148,10 +148,10 @@ func TestEditMessageLiveLocation_Failed(t *testing.T) {
 	}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.EditMessageLiveLocation(12312312.98, 324234324.67).SetChatID(21342321).
 		SetMessageID(234234234).SetInlineMessageID("test").
-		SetReplyMarkup([][]telegraph.InlineKeyboardButton{}).Commit()
+		SetReplyMarkup(telegraph.InlineKeyboardMarkup{}).Commit()
 
 	assert.Nil(t, body)
 	assert.NotNil(t, res)
@@ -178,7 +178,7 @@ func TestSendChatAction_Error(t *testing.T) {
 	gock.New(telegraph.BaseURL).Head(fmt.Sprintf(telegraph.EndpointSendChatAction, "token")).Reply(http.StatusInternalServerError).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, status, err := client.SendChatAction("id", "action").Commit()
 
 	assert.Nil(t, body)
@@ -194,7 +194,7 @@ func TestSendChatAction_Failed(t *testing.T) {
 	}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 
 	body, status, err := client.SendChatAction("id", "action").Commit()
 
@@ -222,7 +222,7 @@ func TestKickChatMember_Error(t *testing.T) {
 	gock.New(telegraph.BaseURL).Head(fmt.Sprintf(telegraph.EndpointKickChatMember, "token")).Reply(http.StatusInternalServerError).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, status, err := client.KickChatMember("234234234", 123423423).SetUntilDate(2343242342).Commit()
 
 	assert.Nil(t, body)
@@ -238,7 +238,7 @@ func TestKickChatMember_Failed(t *testing.T) {
 	}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, status, err := client.KickChatMember("234234234", 123423423).SetUntilDate(2343242342).Commit()
 
 	assert.Nil(t, body)
@@ -255,7 +255,7 @@ func TestStopMessageLiveLocation_Success(t *testing.T) {
 
 	client := telegraph.NewClient("token")
 	body, res, err := client.StopMessageLiveLocation().SetChatID(21342321).SetMessageID(234234234).
-		SetInlineMessageID("test").SetReplyMarkup([][]telegraph.InlineKeyboardButton{}).Commit()
+		SetInlineMessageID("test").SetReplyMarkup(telegraph.InlineKeyboardMarkup{}).Commit()
 
 	assert.NotNil(t, body)
 	assert.NotNil(t, res)
@@ -267,9 +267,9 @@ func TestStopMessageLiveLocation_Error(t *testing.T) {
 	gock.New(telegraph.BaseURL).Post(fmt.Sprintf(telegraph.EndpointStopMessageLiveLocation, "token")).Reply(http.StatusInternalServerError).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.StopMessageLiveLocation().SetChatID(21342321).SetMessageID(234234234).
-		SetInlineMessageID("test").SetReplyMarkup([][]telegraph.InlineKeyboardButton{}).Commit()
+		SetInlineMessageID("test").SetReplyMarkup(telegraph.InlineKeyboardMarkup{}).Commit()
 
 	assert.Nil(t, body)
 	assert.NotNil(t, res)
@@ -285,9 +285,9 @@ func TestStopMessageLiveLocation_Failed(t *testing.T) {
 	}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.StopMessageLiveLocation().SetChatID(21342321).SetMessageID(234234234).
-		SetInlineMessageID("test").SetReplyMarkup([][]telegraph.InlineKeyboardButton{}).Commit()
+		SetInlineMessageID("test").SetReplyMarkup(telegraph.InlineKeyboardMarkup{}).Commit()
 
 	assert.Nil(t, body)
 	assert.NotNil(t, res)
@@ -314,7 +314,7 @@ func TestGetContent_Error(t *testing.T) {
 	gock.New(telegraph.BaseURL).Head(fmt.Sprintf(telegraph.EndpointGetContent, "token", "path")).Reply(http.StatusInternalServerError).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.GetContent("path").Commit()
 
 	assert.Nil(t, body)
@@ -330,7 +330,7 @@ func TestGetContent_Failed(t *testing.T) {
 	}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.GetContent("path").Commit()
 
 	assert.Nil(t, body)
@@ -357,7 +357,7 @@ func TestUnbanChatMember_Error(t *testing.T) {
 	gock.New(telegraph.BaseURL).Head(fmt.Sprintf(telegraph.EndpointUnbanChatMember, "token")).Reply(http.StatusInternalServerError).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.UnbanChatMember("32423423", 23423423).Commit()
 
 	assert.Nil(t, body)
@@ -373,7 +373,7 @@ func TestUnbanChatMember_Failed(t *testing.T) {
 	}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.UnbanChatMember("32423423", 23423423).Commit()
 
 	assert.Nil(t, body)
@@ -401,7 +401,7 @@ func TestRestrictChatMember_Error(t *testing.T) {
 	gock.New(telegraph.BaseURL).Head(fmt.Sprintf(telegraph.EndpointRestrictChatMember, "token")).Reply(http.StatusInternalServerError).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.RestrictChatMember("32423423", 23423423).SetCanSendMessage(true).
 		SetCanSendMediaMessage(true).SetCanSendOtherMessage(true).SetCanAddWebPagePreview(true).Commit()
 
@@ -418,7 +418,7 @@ func TestRestrictChatMember_Failed(t *testing.T) {
 	}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.RestrictChatMember("32423423", 23423423).SetCanSendMessage(true).
 		SetCanSendMediaMessage(true).SetCanSendOtherMessage(true).SetCanAddWebPagePreview(true).Commit()
 
@@ -448,7 +448,7 @@ func TestPromoteChatMember_Error(t *testing.T) {
 	gock.New(telegraph.BaseURL).Head(fmt.Sprintf(telegraph.EndpointPromoteChatMember, "token")).Reply(http.StatusInternalServerError).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.PromoteChatMember("32423423", 23423423).SetCanChangeInfo(true).
 		SetCanPostMessage(true).SetCanEditMessage(true).SetCanDeleteMessage(true).SetCanInviteUser(true).
 		SetCanRestrictMember(true).SetCanPinMessage(true).SetCanPromoteMember(true).Commit()
@@ -466,7 +466,7 @@ func TestPromoteChatMember_Failed(t *testing.T) {
 	}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.PromoteChatMember("32423423", 23423423).SetCanChangeInfo(true).
 		SetCanPostMessage(true).SetCanEditMessage(true).SetCanDeleteMessage(true).SetCanInviteUser(true).
 		SetCanRestrictMember(true).SetCanPinMessage(true).SetCanPromoteMember(true).Commit()
@@ -495,7 +495,7 @@ func TestExportChatInviteLink_Error(t *testing.T) {
 	gock.New(telegraph.BaseURL).Head(fmt.Sprintf(telegraph.EndpointExportChatInviteLink, "token")).Reply(http.StatusInternalServerError).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.ExportChatInviteLink(32423423).Commit()
 
 	assert.Empty(t, body)
@@ -511,7 +511,7 @@ func TestExportChatInviteLink_Failed(t *testing.T) {
 	}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.ExportChatInviteLink(32423423).Commit()
 
 	assert.Empty(t, body)
@@ -527,7 +527,7 @@ func TestSetChatPhoto_Success(t *testing.T) {
 	defer gock.Off()
 
 	client := telegraph.NewClient("token")
-	body, res, err := client.SetChatPhoto(32423423, "./LICENSE").Commit()
+	body, res, err := client.SetChatPhoto(32423423, telegraph.FilePath("./LICENSE")).Commit()
 
 	assert.NotNil(t, body)
 	assert.Equal(t, http.StatusOK, res.StatusCode)
@@ -538,8 +538,8 @@ func TestSetChatPhoto_Error(t *testing.T) {
 	gock.New(telegraph.BaseURL).Head(fmt.Sprintf(telegraph.EndpointSetChatPhoto, "token")).Reply(http.StatusInternalServerError).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
-	body, res, err := client.SetChatPhoto(32423423, "./LICENSE").Commit()
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
+	body, res, err := client.SetChatPhoto(32423423, telegraph.FilePath("./LICENSE")).Commit()
 
 	assert.Nil(t, body)
 	assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
@@ -554,8 +554,8 @@ func TestSetChatPhoto_Failed(t *testing.T) {
 	}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
-	body, res, err := client.SetChatPhoto(32423423, "./LICENSE").Commit()
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
+	body, res, err := client.SetChatPhoto(32423423, telegraph.FilePath("./LICENSE")).Commit()
 
 	assert.Nil(t, body)
 	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
@@ -583,7 +583,7 @@ func TestDeleteChatPhoto_Error(t *testing.T) {
 		Reply(http.StatusInternalServerError).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.DeleteChatPhoto(32423423).Commit()
 
 	assert.Nil(t, body)
@@ -600,7 +600,7 @@ func TestDeleteChatPhoto_Failed(t *testing.T) {
 		}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.DeleteChatPhoto(32423423).Commit()
 
 	assert.Nil(t, body)
@@ -627,7 +627,7 @@ func TestSetChatTitle_Error(t *testing.T) {
 	gock.New(telegraph.BaseURL).Head(fmt.Sprintf(telegraph.EndpointSetChatTitle, "token")).Reply(http.StatusInternalServerError).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.SetChatTitle(32423423, "title").Commit()
 
 	assert.Nil(t, body)
@@ -643,7 +643,7 @@ func TestSetChatTitle_Failed(t *testing.T) {
 	}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.SetChatTitle(32423423, "title").Commit()
 
 	assert.Nil(t, body)
@@ -670,7 +670,7 @@ func TestSetChatDescription_Error(t *testing.T) {
 	gock.New(telegraph.BaseURL).Head(fmt.Sprintf(telegraph.EndpointSetChatDescription, "token")).Reply(http.StatusInternalServerError).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.SetChatDescription(32423423, "desc").Commit()
 
 	assert.Nil(t, body)
@@ -686,7 +686,7 @@ func TestSetChatDescription_Failed(t *testing.T) {
 	}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.SetChatDescription(32423423, "desc").Commit()
 
 	assert.Nil(t, body)
@@ -713,7 +713,7 @@ func TestPinChatMessage_Error(t *testing.T) {
 	gock.New(telegraph.BaseURL).Head(fmt.Sprintf(telegraph.EndpointPinChatMessage, "token")).Reply(http.StatusInternalServerError).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.PinChatMessage(32423423, 23423423).SetDisableNotification(true).Commit()
 
 	assert.Nil(t, body)
@@ -729,7 +729,7 @@ func TestPinChatMessage_Failed(t *testing.T) {
 	}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.PinChatMessage(32423423, 23423423).SetDisableNotification(true).Commit()
 
 	assert.Nil(t, body)
@@ -758,7 +758,7 @@ func TestUnpinChatMessage_Error(t *testing.T) {
 		Reply(http.StatusOK).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.UnpinChatMessage(32423423).Commit()
 
 	assert.Nil(t, body)
@@ -775,7 +775,7 @@ func TestUnpinChatMessage_Failed(t *testing.T) {
 		}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.UnpinChatMessage(32423423).Commit()
 
 	assert.Nil(t, body)
@@ -804,7 +804,7 @@ func TestLeaveChat_Error(t *testing.T) {
 		Reply(http.StatusOK).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.LeaveChat(32423423).Commit()
 
 	assert.Nil(t, body)
@@ -821,7 +821,7 @@ func TestLeaveChat_Failed(t *testing.T) {
 		}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.LeaveChat(32423423).Commit()
 
 	assert.Nil(t, body)
@@ -850,7 +850,7 @@ func TestGetChatMembersCount_Error(t *testing.T) {
 		Reply(http.StatusOK).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.GetChatMembersCount(32423423).Commit()
 
 	assert.Nil(t, body)
@@ -867,7 +867,7 @@ func TestGetChatMembersCount_Failed(t *testing.T) {
 		}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.GetChatMembersCount(32423423).Commit()
 
 	assert.Nil(t, body)
@@ -894,7 +894,7 @@ func TestSetChatStickerSet_Error(t *testing.T) {
 	gock.New(telegraph.BaseURL).Head(fmt.Sprintf(telegraph.EndpointSetChatStickerSet, "token")).Reply(http.StatusOK).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.SetChatStickerSet(32423423, "name").Commit()
 
 	assert.Nil(t, body)
@@ -910,7 +910,7 @@ func TestSetChatStickerSet_Failed(t *testing.T) {
 	}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.SetChatStickerSet(32423423, "name").Commit()
 
 	assert.Nil(t, body)
@@ -939,7 +939,7 @@ func TestDeleteChatStickerSet_Error(t *testing.T) {
 		Reply(http.StatusInternalServerError).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.DeleteChatStickerSet(32423423).Commit()
 
 	assert.Nil(t, body)
@@ -956,7 +956,7 @@ func TestDeleteChatStickerSet_Failed(t *testing.T) {
 		}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.DeleteChatStickerSet(32423423).Commit()
 
 	assert.Nil(t, body)
@@ -984,7 +984,7 @@ func TestAnswerCallbackQuery_Error(t *testing.T) {
 	gock.New(telegraph.BaseURL).Head(fmt.Sprintf(telegraph.EndpointAnswerCallbackQuery, "token")).Reply(http.StatusInternalServerError).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.AnswerCallbackQuery("23434234").SetText("text").SetShowAlert(true).
 		SetURL("https://www.cubesoft.co.id").SetCacheTime(123123123).Commit()
 
@@ -1001,7 +1001,7 @@ func TestAnswerCallbackQuery_Failed(t *testing.T) {
 	}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.AnswerCallbackQuery("23434234").SetText("text").SetShowAlert(true).
 		SetURL("https://www.cubesoft.co.id").SetCacheTime(123123123).Commit()
 
@@ -1020,7 +1020,7 @@ func TestEditMessageText_Success(t *testing.T) {
 	client := telegraph.NewClient("token")
 	body, res, err := client.EditMessageText("text").SetChatID(1312312).SetMessageID(2323423).
 		SetInlineMessageID("inline").SetParseMode("HTML").SetDisableWebPagePreview(true).
-		SetReplyMarkup([][]telegraph.InlineKeyboardButton{}).Commit()
+		SetReplyMarkup(telegraph.InlineKeyboardMarkup{}).Commit()
 
 	assert.NotNil(t, body)
 	assert.Equal(t, http.StatusOK, res.StatusCode)
@@ -1031,10 +1031,10 @@ func TestEditMessageText_Error(t *testing.T) {
 	gock.New(telegraph.BaseURL).Head(fmt.Sprintf(telegraph.EndpointEditMessageText, "token")).Reply(http.StatusInternalServerError).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.EditMessageText("text").SetChatID(1312312).SetMessageID(2323423).
 		SetInlineMessageID("inline").SetParseMode("HTML").SetDisableWebPagePreview(true).
-		SetReplyMarkup([][]telegraph.InlineKeyboardButton{}).Commit()
+		SetReplyMarkup(telegraph.InlineKeyboardMarkup{}).Commit()
 
 	assert.Nil(t, body)
 	assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
@@ -1049,10 +1049,10 @@ func TestEditMessageText_Failed(t *testing.T) {
 	}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.EditMessageText("text").SetChatID(1312312).SetMessageID(2323423).
 		SetInlineMessageID("inline").SetParseMode("HTML").SetDisableWebPagePreview(true).
-		SetReplyMarkup([][]telegraph.InlineKeyboardButton{}).Commit()
+		SetReplyMarkup(telegraph.InlineKeyboardMarkup{}).Commit()
 
 	assert.Nil(t, body)
 	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
@@ -1068,7 +1068,7 @@ func TestEditMessageCaption_Success(t *testing.T) {
 
 	client := telegraph.NewClient("token")
 	body, res, err := client.EditMessageCaption("caption").SetChatID(1312312).SetMessageID(2323423).
-		SetInlineMessageID("inline").SetReplyMarkup([][]telegraph.InlineKeyboardButton{}).Commit()
+		SetInlineMessageID("inline").SetReplyMarkup(telegraph.InlineKeyboardMarkup{}).Commit()
 
 	assert.NotNil(t, body)
 	assert.Equal(t, http.StatusOK, res.StatusCode)
@@ -1079,9 +1079,9 @@ func TestEditMessageCaption_Error(t *testing.T) {
 	gock.New(telegraph.BaseURL).Head(fmt.Sprintf(telegraph.EndpointEditMessageCaption, "token")).Reply(http.StatusInternalServerError).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.EditMessageCaption("caption").SetChatID(1312312).SetMessageID(2323423).
-		SetInlineMessageID("inline").SetReplyMarkup([][]telegraph.InlineKeyboardButton{}).Commit()
+		SetInlineMessageID("inline").SetReplyMarkup(telegraph.InlineKeyboardMarkup{}).Commit()
 
 	assert.Nil(t, body)
 	assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
@@ -1096,9 +1096,9 @@ func TestEditMessageCaption_Failed(t *testing.T) {
 	}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.EditMessageCaption("caption").SetChatID(1312312).SetMessageID(2323423).
-		SetInlineMessageID("inline").SetReplyMarkup([][]telegraph.InlineKeyboardButton{}).Commit()
+		SetInlineMessageID("inline").SetReplyMarkup(telegraph.InlineKeyboardMarkup{}).Commit()
 
 	assert.Nil(t, body)
 	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
@@ -1114,7 +1114,7 @@ func TestEditMessageReplyMarkup_Success(t *testing.T) {
 
 	client := telegraph.NewClient("token")
 	body, res, err := client.EditMessageReplyMarkup().SetChatID(1312312).SetMessageID(2323423).
-		SetInlineMessageID("inline").SetReplyMarkup([][]telegraph.InlineKeyboardButton{}).Commit()
+		SetInlineMessageID("inline").SetReplyMarkup(telegraph.InlineKeyboardMarkup{}).Commit()
 
 	assert.NotNil(t, body)
 	assert.Equal(t, http.StatusOK, res.StatusCode)
@@ -1125,9 +1125,9 @@ func TestEditMessageReplyMarkup_Error(t *testing.T) {
 	gock.New(telegraph.BaseURL).Head(fmt.Sprintf(telegraph.EndpointEditMessageReplyMarkup, "token")).Reply(http.StatusInternalServerError).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.EditMessageReplyMarkup().SetChatID(1312312).SetMessageID(2323423).
-		SetInlineMessageID("inline").SetReplyMarkup([][]telegraph.InlineKeyboardButton{}).Commit()
+		SetInlineMessageID("inline").SetReplyMarkup(telegraph.InlineKeyboardMarkup{}).Commit()
 
 	assert.Nil(t, body)
 	assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
@@ -1142,9 +1142,9 @@ func TestEditMessageReplyMarkup_Failed(t *testing.T) {
 	}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.EditMessageReplyMarkup().SetChatID(1312312).SetMessageID(2323423).
-		SetInlineMessageID("inline").SetReplyMarkup([][]telegraph.InlineKeyboardButton{}).Commit()
+		SetInlineMessageID("inline").SetReplyMarkup(telegraph.InlineKeyboardMarkup{}).Commit()
 
 	assert.Nil(t, body)
 	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
@@ -1172,7 +1172,7 @@ func TestDeleteMessage_Error(t *testing.T) {
 		ParamPresent("message_id").Reply(http.StatusInternalServerError).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.DeleteMessage(23223, 232344).Commit()
 
 	assert.Nil(t, body)
@@ -1189,7 +1189,7 @@ func TestDeleteMessage_Failed(t *testing.T) {
 		}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.DeleteMessage(23223, 232344).Commit()
 
 	assert.Nil(t, body)
@@ -1205,7 +1205,7 @@ func TestCreateNewStickerSet_Success(t *testing.T) {
 	defer gock.Off()
 
 	client := telegraph.NewClient("token")
-	body, res, err := client.CreateNewStickerSet(234234234, "name", "title", "./LICENSE", ":)").
+	body, res, err := client.CreateNewStickerSet(234234234, "name", "title", telegraph.FilePath("./LICENSE"), ":)").
 		SetContainsMask(true).SetMaskPosition(telegraph.MaskPosition{}).Commit()
 
 	assert.NotNil(t, body)
@@ -1217,8 +1217,8 @@ func TestCreateNewStickerSet_Error(t *testing.T) {
 	gock.New(telegraph.BaseURL).Head(fmt.Sprintf(telegraph.EndpointCreateNewStickerSet, "token")).Reply(http.StatusInternalServerError).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
-	body, res, err := client.CreateNewStickerSet(234234234, "name", "title", "./LICENSE", ":)").
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
+	body, res, err := client.CreateNewStickerSet(234234234, "name", "title", telegraph.FilePath("./LICENSE"), ":)").
 		SetContainsMask(true).SetMaskPosition(telegraph.MaskPosition{}).Commit()
 
 	assert.Nil(t, body)
@@ -1234,8 +1234,8 @@ func TestCreateNewStickerSet_Failed(t *testing.T) {
 	}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
-	body, res, err := client.CreateNewStickerSet(234234234, "name", "title", "./LICENSE", ":)").
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
+	body, res, err := client.CreateNewStickerSet(234234234, "name", "title", telegraph.FilePath("./LICENSE"), ":)").
 		SetContainsMask(true).SetMaskPosition(telegraph.MaskPosition{}).Commit()
 
 	assert.Nil(t, body)
@@ -1251,7 +1251,7 @@ func TestAddStickerToSet_Success(t *testing.T) {
 	defer gock.Off()
 
 	client := telegraph.NewClient("token")
-	body, res, err := client.AddStickerToSet(13123123, "name", "./LICENSE", "emojis").
+	body, res, err := client.AddStickerToSet(13123123, "name", telegraph.FilePath("./LICENSE"), "emojis").
 		SetMaskPosition(telegraph.MaskPosition{}).Commit()
 
 	assert.NotNil(t, body)
@@ -1263,8 +1263,8 @@ func TestAddStickerToSet_Error(t *testing.T) {
 	gock.New(telegraph.BaseURL).Head(fmt.Sprintf(telegraph.EndpointAddStickerToSet, "token")).Reply(http.StatusInternalServerError).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
-	body, res, err := client.AddStickerToSet(13123123, "name", "./LICENSE", "emojis").
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
+	body, res, err := client.AddStickerToSet(13123123, "name", telegraph.FilePath("./LICENSE"), "emojis").
 		SetMaskPosition(telegraph.MaskPosition{}).Commit()
 
 	assert.Nil(t, body)
@@ -1280,8 +1280,8 @@ func TestAddStickerToSet_Failed(t *testing.T) {
 	}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
-	body, res, err := client.AddStickerToSet(13123123, "name", "./LICENSE", "emojis").
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
+	body, res, err := client.AddStickerToSet(13123123, "name", telegraph.FilePath("./LICENSE"), "emojis").
 		SetMaskPosition(telegraph.MaskPosition{}).Commit()
 
 	assert.Nil(t, body)
@@ -1308,7 +1308,7 @@ func TestSetStickerPositionInSet_Error(t *testing.T) {
 	gock.New(telegraph.BaseURL).Head(fmt.Sprintf(telegraph.EndpointSetStickerPositionInSet, "token")).Reply(http.StatusInternalServerError).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.SetStickerPositionInSet("sticker", 10).Commit()
 
 	assert.Nil(t, body)
@@ -1324,7 +1324,7 @@ func TestSetStickerPositionInSet_Failed(t *testing.T) {
 	}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.SetStickerPositionInSet("sticker", 10).Commit()
 
 	assert.Nil(t, body)
@@ -1351,7 +1351,7 @@ func TestDeleteStickerFromSet_Error(t *testing.T) {
 	gock.New(telegraph.BaseURL).Head(fmt.Sprintf(telegraph.EndpointDeleteStickerFromSet, "token")).Reply(http.StatusInternalServerError).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.DeleteStickerFromSet("sticker").Commit()
 
 	assert.Nil(t, body)
@@ -1367,7 +1367,7 @@ func TestDeleteStickerFromSet_Failed(t *testing.T) {
 	}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.DeleteStickerFromSet("sticker").Commit()
 
 	assert.Nil(t, body)
@@ -1395,7 +1395,7 @@ func TestAnswerInlineQuery_Error(t *testing.T) {
 	gock.New(telegraph.BaseURL).Head(fmt.Sprintf(telegraph.EndpointAnswerInlineQuery, "token")).Reply(http.StatusInternalServerError).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.AnswerInlineQuery("123123123", telegraph.JSON{}, telegraph.JSON{}).SetCacheTime(10000).
 		SetIsPersonal(true).SetNextOffset("offset").SetSwitchPMText("text").SetSwitchPMParameter("param").Commit()
 
@@ -1412,7 +1412,7 @@ func TestAnswerInlineQuery_Failed(t *testing.T) {
 	}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	body, res, err := client.AnswerInlineQuery("123123123", telegraph.JSON{}, telegraph.JSON{}).SetCacheTime(10000).
 		SetIsPersonal(true).SetNextOffset("offset").SetSwitchPMText("text").SetSwitchPMParameter("param").Commit()
 
@@ -0,0 +1,85 @@
+package telegraph
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/cenkalti/backoff"
+)
+
+type (
+	// RetryPolicy decide whether a failed request to telegram is sent again.
+	// Retry is called after every failed attempt, attempt starts from 1 and elapsed is time since the first attempt started.
+	// err is *APIError when telegram answered with unsuccessful response, otherwise it's a network or decode error.
+	// Return how long to wait before the next attempt, or false to give up and return err to the caller
+	RetryPolicy interface {
+		Retry(attempt int, elapsed time.Duration, err error) (time.Duration, bool)
+	}
+
+	// RetryPolicyFunc adapter to use ordinary function as RetryPolicy
+	RetryPolicyFunc func(attempt int, elapsed time.Duration, err error) (time.Duration, bool)
+
+	// BackOffRetryPolicy default retry policy of client created from client back off.
+	// Flood control error (429) is retried after exactly the retry_after seconds sent by telegram,
+	// server error (5xx) and network error are retried with exponential back off,
+	// other telegram error such as 400 or 403 and response which can't be decoded are never retried.
+	// Nothing is retried once the next attempt would exceed MaxElapsedTime of the back off, zero or negative means no limit
+	BackOffRetryPolicy struct {
+		BackOff *backoff.ExponentialBackOff
+	}
+)
+
+// NoRetry retry policy which never retry failed request
+var NoRetry RetryPolicy = RetryPolicyFunc(func(int, time.Duration, error) (time.Duration, bool) {
+	return 0, false
+})
+
+// Retry call fn(attempt, elapsed, err)
+func (fn RetryPolicyFunc) Retry(attempt int, elapsed time.Duration, err error) (time.Duration, bool) {
+	return fn(attempt, elapsed, err)
+}
+
+// Retry decide wait duration before next attempt based on telegram error code
+func (policy *BackOffRetryPolicy) Retry(attempt int, elapsed time.Duration, err error) (time.Duration, bool) {
+	if policy.BackOff == nil {
+		return 0, false
+	}
+
+	if _, ok := err.(*DecodeError); ok {
+		return 0, false
+	}
+
+	wait := policy.interval(attempt)
+	if apiErr, ok := err.(*APIError); ok {
+		switch {
+		case apiErr.ErrorCode == http.StatusTooManyRequests:
+			if retryAfter := apiErr.RetryAfter(); retryAfter > 0 {
+				wait = retryAfter
+			}
+		case apiErr.ErrorCode >= http.StatusInternalServerError:
+		default:
+			return 0, false
+		}
+	}
+
+	if maxElapsed := policy.BackOff.MaxElapsedTime; maxElapsed > 0 && elapsed+wait > maxElapsed {
+		return 0, false
+	}
+
+	return wait, true
+}
+
+// interval exponential back off interval of attempt, randomized the same way backoff.ExponentialBackOff does
+func (policy *BackOffRetryPolicy) interval(attempt int) time.Duration {
+	expBackOff := policy.BackOff
+
+	interval := float64(expBackOff.InitialInterval) * math.Pow(expBackOff.Multiplier, float64(attempt-1))
+	if interval > float64(expBackOff.MaxInterval) {
+		interval = float64(expBackOff.MaxInterval)
+	}
+	delta := expBackOff.RandomizationFactor * interval
+
+	return time.Duration(interval - delta + rand.Float64()*(2*delta+1))
+}
@@ -0,0 +1,238 @@
+package telegraph_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"telegraph"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func newTestBackOff() *telegraph.BackOffRetryPolicy {
+	expBackOff := telegraph.NewBackOff(1, 10)
+	expBackOff.InitialInterval = 10 * time.Millisecond
+
+	return &telegraph.BackOffRetryPolicy{BackOff: expBackOff}
+}
+
+func TestBackOffRetryPolicy_TooManyRequests(t *testing.T) {
+	policy := newTestBackOff()
+
+	wait, retry := policy.Retry(1, 0, &telegraph.APIError{
+		ErrorCode:   http.StatusTooManyRequests,
+		Description: "Too Many Requests: retry after 3",
+		Parameters:  &telegraph.ResponseParameters{RetryAfter: 3},
+	})
+
+	assert.True(t, retry)
+	assert.Equal(t, 3*time.Second, wait)
+}
+
+func TestBackOffRetryPolicy_ServerError(t *testing.T) {
+	policy := newTestBackOff()
+
+	wait, retry := policy.Retry(1, 0, &telegraph.APIError{ErrorCode: http.StatusBadGateway})
+
+	assert.True(t, retry)
+	assert.True(t, wait > 0 && wait < time.Second)
+}
+
+func TestBackOffRetryPolicy_NetworkError(t *testing.T) {
+	policy := newTestBackOff()
+
+	wait, retry := policy.Retry(20, 0, errors.New("connection reset by peer"))
+
+	assert.True(t, retry)
+	assert.True(t, wait <= 2*time.Second)
+}
+
+func TestBackOffRetryPolicy_NotRetryable(t *testing.T) {
+	policy := newTestBackOff()
+
+	_, retry := policy.Retry(1, 0, &telegraph.APIError{ErrorCode: http.StatusBadRequest})
+	assert.False(t, retry)
+
+	_, retry = policy.Retry(1, 0, &telegraph.APIError{ErrorCode: http.StatusForbidden})
+	assert.False(t, retry)
+}
+
+func TestBackOffRetryPolicy_MaxElapsedTime(t *testing.T) {
+	policy := newTestBackOff()
+
+	_, retry := policy.Retry(3, 11*time.Second, errors.New("connection reset by peer"))
+	assert.False(t, retry)
+
+	_, retry = policy.Retry(1, 5*time.Second, &telegraph.APIError{
+		ErrorCode:  http.StatusTooManyRequests,
+		Parameters: &telegraph.ResponseParameters{RetryAfter: 30},
+	})
+	assert.False(t, retry)
+
+	_, retry = (&telegraph.BackOffRetryPolicy{BackOff: telegraph.NewBackOff(60, -1)}).Retry(1, time.Hour, errors.New("timeout"))
+	assert.True(t, retry)
+}
+
+func TestBackOffRetryPolicy_DecodeError(t *testing.T) {
+	policy := newTestBackOff()
+
+	_, retry := policy.Retry(1, 0, &telegraph.DecodeError{Err: errors.New("unexpected end of JSON input")})
+	assert.False(t, retry)
+}
+
+func TestCommit_RetryTooManyRequests(t *testing.T) {
+	gock.New(telegraph.BaseURL).Post(fmt.Sprintf(telegraph.EndpointSendMessage, "token")).Reply(http.StatusTooManyRequests).JSON(`{
+		"ok": false,
+		"error_code": 429,
+		"description": "Too Many Requests: retry after 1",
+		"parameters": {
+			"retry_after": 1
+		}
+	}`)
+	gock.New(telegraph.BaseURL).Post(fmt.Sprintf(telegraph.EndpointSendMessage, "token")).Reply(http.StatusOK).JSON(`{
+		"ok": true,
+		"result": {
+			"message_id": 100,
+			"date": 1510125931,
+			"chat": {
+				"id": 1234567890,
+				"type": "private"
+			},
+			"text": "test via server"
+		}
+	}`)
+	defer gock.Off()
+
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(newTestBackOff()))
+
+	start := time.Now()
+	message, res, err := client.SendMessage(2434234, "test").Commit()
+
+	assert.NotNil(t, message)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.NoError(t, err)
+	assert.True(t, time.Since(start) >= time.Second)
+	assert.True(t, gock.IsDone())
+}
+
+func TestCommit_RetryServerError(t *testing.T) {
+	gock.New(telegraph.BaseURL).Get(fmt.Sprintf(telegraph.EndpointGetMe, "token")).Times(2).Reply(http.StatusBadGateway).BodyString("")
+	gock.New(telegraph.BaseURL).Get(fmt.Sprintf(telegraph.EndpointGetMe, "token")).Reply(http.StatusOK).JSON(`{
+		"ok": true,
+		"result": {
+			"id": 1234567890,
+			"is_bot": true,
+			"first_name": "cube"
+		}
+	}`)
+	defer gock.Off()
+
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(newTestBackOff()))
+
+	user, res, err := client.GetMe().Commit()
+
+	assert.NotNil(t, user)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+}
+
+func TestCommit_NotRetryBadRequest(t *testing.T) {
+	gock.New(telegraph.BaseURL).Post(fmt.Sprintf(telegraph.EndpointSendMessage, "token")).Reply(http.StatusBadRequest).JSON(`{
+		"ok": false,
+		"error_code": 400,
+		"description": "Bad Request: chat not found"
+	}`)
+	gock.New(telegraph.BaseURL).Post(fmt.Sprintf(telegraph.EndpointSendMessage, "token")).Reply(http.StatusOK).JSON(`{
+		"ok": true,
+		"result": {}
+	}`)
+	defer gock.Off()
+
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(newTestBackOff()))
+
+	message, res, err := client.SendMessage(2434234, "test").Commit()
+
+	assert.Nil(t, message)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	assert.True(t, telegraph.IsBadRequest(err))
+	assert.True(t, gock.IsPending())
+}
+
+func TestCommit_SetRetryPolicy(t *testing.T) {
+	gock.New(telegraph.BaseURL).Get(fmt.Sprintf(telegraph.EndpointGetMe, "token")).Reply(http.StatusInternalServerError).BodyString("")
+	gock.New(telegraph.BaseURL).Get(fmt.Sprintf(telegraph.EndpointGetMe, "token")).Reply(http.StatusOK).JSON(`{
+		"ok": true,
+		"result": {}
+	}`)
+	defer gock.Off()
+
+	attempts := 0
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(newTestBackOff()))
+
+	user, res, err := client.GetMe().SetRetryPolicy(telegraph.RetryPolicyFunc(func(attempt int, elapsed time.Duration, err error) (time.Duration, bool) {
+		attempts = attempt
+		return 0, false
+	})).Commit()
+
+	assert.Nil(t, user)
+	assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+	assert.Error(t, err)
+	assert.Equal(t, 1, attempts)
+
+	user, res, err = client.GetMe().SetRetryPolicy(telegraph.NoRetry).Commit()
+
+	assert.NotNil(t, user)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.NoError(t, err)
+}
+
+func TestCommit_DefaultClientRetry(t *testing.T) {
+	gock.New(telegraph.BaseURL).Get(fmt.Sprintf(telegraph.EndpointGetMe, "token")).Reply(http.StatusTooManyRequests).JSON(`{
+		"ok": false,
+		"error_code": 429,
+		"description": "Too Many Requests: retry after 1",
+		"parameters": {
+			"retry_after": 1
+		}
+	}`)
+	gock.New(telegraph.BaseURL).Get(fmt.Sprintf(telegraph.EndpointGetMe, "token")).Reply(http.StatusOK).JSON(`{
+		"ok": true,
+		"result": {
+			"id": 1234567890,
+			"is_bot": true,
+			"first_name": "cube"
+		}
+	}`)
+	defer gock.Off()
+
+	client := telegraph.NewClient("token")
+
+	user, res, err := client.GetMe().Commit()
+
+	assert.NotNil(t, user)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+}
+
+func TestCommit_NotRetryDecodeError(t *testing.T) {
+	gock.New(telegraph.BaseURL).Post(fmt.Sprintf(telegraph.EndpointSendMessage, "token")).Reply(http.StatusOK).BodyString(`{"ok": true, "result": `)
+	gock.New(telegraph.BaseURL).Post(fmt.Sprintf(telegraph.EndpointSendMessage, "token")).Reply(http.StatusOK).JSON(`{
+		"ok": true,
+		"result": {}
+	}`)
+	defer gock.Off()
+
+	client := telegraph.NewClient("token")
+
+	message, _, err := client.SendMessage(2434234, "test").Commit()
+
+	var decodeErr *telegraph.DecodeError
+	assert.Nil(t, message)
+	assert.True(t, errors.As(err, &decodeErr))
+	assert.True(t, gock.IsPending())
+}
@@ -1,19 +1,20 @@
 package telegraph
 
 import (
+	"context"
 	"fmt"
 
 	"net/http"
 
-	"github.com/cenkalti/backoff"
 	"github.com/parnurzeal/gorequest"
 )
 
 type (
 	// StickerSetResponse struct to handle request and response telegram api
 	StickerSetResponse struct {
-		Client  *Client
-		Request *gorequest.SuperAgent
+		Client      *Client
+		Request     *gorequest.SuperAgent
+		retryPolicy RetryPolicy
 	}
 )
 
@@ -23,7 +24,7 @@ GetStickerSet Use this method to get a sticker set. On success, a StickerSet obj
 */
 func (client *Client) GetStickerSet(name string) *StickerSetResponse {
 	url := client.baseURL + fmt.Sprintf(EndpointGetStickerSet, client.accessToken)
-	request := gorequest.New().Type(gorequest.TypeJSON).Get(url).Set(UserAgentHeader, UserAgent+"/"+Version).
+	request := gorequest.New().Type(gorequest.TypeJSON).Get(url).Set(UserAgentHeader, client.userAgent).
 		Query(fmt.Sprintf("name=%v", name))
 
 	return &StickerSetResponse{
@@ -32,29 +33,27 @@ func (client *Client) GetStickerSet(name string) *StickerSetResponse {
 	}
 }
 
+// SetRetryPolicy decide how this request is retried when it fails, override retry policy of the client
+func (sticker *StickerSetResponse) SetRetryPolicy(policy RetryPolicy) *StickerSetResponse {
+	sticker.retryPolicy = policy
+
+	return sticker
+}
+
 // Commit execute request to telegram
 func (sticker *StickerSetResponse) Commit() (*StickerSet, *http.Response, error) {
-	var errs []error
+	return sticker.CommitContext(context.Background())
+}
 
-	res := &http.Response{}
+// CommitContext execute request to telegram, the request and its back off retry are aborted when ctx is done
+func (sticker *StickerSetResponse) CommitContext(ctx context.Context) (*StickerSet, *http.Response, error) {
 	model := struct {
-		ErrorResponse
 		Result *StickerSet `json:"result,omitempty"`
 	}{}
 
-	operation := func() error {
-		res, _, errs = sticker.Request.EndStruct(&model)
-		if len(errs) > 0 {
-			return errs[0]
-		}
-		return nil
-	}
-
-	if err := backoff.Retry(operation, sticker.Client.expBackOff); err != nil {
-		return nil, MakeHTTPResponse(sticker.Request), err
-	}
-	if res.StatusCode != http.StatusOK {
-		return nil, res, fmt.Errorf("%v %v", model.ErrorCode, model.Description)
+	_, res, err := sticker.Client.commit(ctx, sticker.Request, sticker.retryPolicy, &model)
+	if err != nil {
+		return nil, res, err
 	}
 
 	return model.Result, res, nil
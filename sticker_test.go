@@ -387,7 +387,7 @@ func TestGetStickerSet_Error(t *testing.T) {
 		Reply(http.StatusInternalServerError).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	model, res, err := client.GetStickerSet("persik").Commit()
 
 	assert.Nil(t, model)
@@ -404,7 +404,7 @@ func TestGetStickerSet_Failed(t *testing.T) {
 	}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 	model, res, err := client.GetStickerSet("persik").Commit()
 
 	assert.Nil(t, model)
@@ -0,0 +1,345 @@
+package telegraphtest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+const (
+	// ModeRecord send request to telegram and record the interactions
+	ModeRecord Mode = iota
+	// ModeReplay answer request from recorded interactions without network
+	ModeReplay
+	// ModeAuto replay when the cassette file exists, otherwise record
+	ModeAuto
+)
+
+// RedactedToken replace bot token in recorded interactions
+const RedactedToken = "<TOKEN>"
+
+// ErrInteractionNotFound returned by replaying recorder when no recorded interaction matches the request
+var ErrInteractionNotFound = errors.New("telegraphtest: interaction not found in cassette")
+
+// tokenPattern bot token in bot api and file download path
+var tokenPattern = regexp.MustCompile(`/(file/)?bot([0-9]+:[A-Za-z0-9_-]+)`)
+
+type (
+	// Mode whether Recorder record or replay interactions
+	Mode int
+
+	// Recorder http.RoundTripper recording request and response of client to a cassette file and replaying them,
+	// use it with telegraph.WithTransport. Bot token is redacted from url, body and response,
+	// request is matched by method, url and normalized JSON, form or multipart body,
+	// so random multipart boundary and JSON key order don't matter
+	Recorder struct {
+		path      string
+		mode      Mode
+		transport http.RoundTripper
+		mutex     sync.Mutex
+		cassette  Cassette
+		replayed  []bool
+		tokens    map[string]bool
+	}
+
+	// Cassette recorded interactions in order
+	Cassette struct {
+		Interactions []Interaction `json:"interactions"`
+	}
+
+	// Interaction request and its response
+	Interaction struct {
+		Request  RecordedRequest  `json:"request"`
+		Response RecordedResponse `json:"response"`
+	}
+
+	// RecordedRequest request with redacted url and normalized body
+	RecordedRequest struct {
+		Method string `json:"method"`
+		URL    string `json:"url"`
+		Body   string `json:"body,omitempty"`
+	}
+
+	// RecordedResponse response with redacted body, binary body such as downloaded file is kept in Binary
+	RecordedResponse struct {
+		StatusCode int         `json:"status_code"`
+		Header     http.Header `json:"header,omitempty"`
+		Body       string      `json:"body,omitempty"`
+		Binary     []byte      `json:"binary,omitempty"`
+	}
+)
+
+// NewRecorder create recorder of cassette file at path, in replay mode the cassette is loaded and must exist
+func NewRecorder(path string, mode Mode) (*Recorder, error) {
+	if mode == ModeAuto {
+		mode = ModeRecord
+		if _, err := os.Stat(path); err == nil {
+			mode = ModeReplay
+		}
+	}
+
+	recorder := &Recorder{
+		path:      path,
+		mode:      mode,
+		transport: http.DefaultTransport,
+		tokens:    map[string]bool{},
+	}
+	if mode == ModeReplay {
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(content, &recorder.cassette); err != nil {
+			return nil, fmt.Errorf("telegraphtest: invalid cassette %v: %w", path, err)
+		}
+		recorder.replayed = make([]bool, len(recorder.cassette.Interactions))
+	}
+
+	return recorder, nil
+}
+
+// Mode whether the recorder record or replay, ModeAuto is resolved when the recorder is created
+func (recorder *Recorder) Mode() Mode {
+	return recorder.mode
+}
+
+// SetTransport transport sending request in record mode, default is http.DefaultTransport
+func (recorder *Recorder) SetTransport(transport http.RoundTripper) *Recorder {
+	recorder.transport = transport
+	return recorder
+}
+
+// Cassette interactions recorded or loaded so far
+func (recorder *Recorder) Cassette() Cassette {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	return Cassette{Interactions: append([]Interaction{}, recorder.cassette.Interactions...)}
+}
+
+// Save write recorded interactions to the cassette file
+func (recorder *Recorder) Save() error {
+	content := &bytes.Buffer{}
+	encoder := json.NewEncoder(content)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(recorder.Cassette()); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(recorder.path, content.Bytes(), 0644)
+}
+
+// RoundTrip record or replay request
+func (recorder *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	recorder.mutex.Lock()
+	for _, match := range tokenPattern.FindAllStringSubmatch(req.URL.String(), -1) {
+		recorder.tokens[match[2]] = true
+	}
+	recorded := RecordedRequest{
+		Method: req.Method,
+		URL:    recorder.redact(normalizeURL(req.URL)),
+		Body:   recorder.redact(normalizeBody(req.Header.Get("Content-Type"), body)),
+	}
+	recorder.mutex.Unlock()
+
+	if recorder.mode == ModeReplay {
+		return recorder.replay(req, recorded)
+	}
+	return recorder.record(req, recorded, body)
+}
+
+// record send request with the transport and save the interaction
+func (recorder *Recorder) record(req *http.Request, recorded RecordedRequest, body []byte) (*http.Response, error) {
+	clone := req.Clone(req.Context())
+	clone.Body = ioutil.NopCloser(bytes.NewReader(body))
+	clone.ContentLength = int64(len(body))
+
+	res, err := recorder.transport.RoundTrip(clone)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	content, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	res.Body = ioutil.NopCloser(bytes.NewReader(content))
+
+	response := RecordedResponse{StatusCode: res.StatusCode, Header: res.Header.Clone()}
+	response.Header.Del("Date")
+	response.Header.Del("Content-Length")
+
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	if utf8.Valid(content) {
+		response.Body = recorder.redact(string(content))
+	} else {
+		response.Binary = content
+	}
+	recorder.cassette.Interactions = append(recorder.cassette.Interactions, Interaction{Request: recorded, Response: response})
+
+	return res, nil
+}
+
+// replay answer request with the first matching interaction not replayed yet
+func (recorder *Recorder) replay(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	for i, interaction := range recorder.cassette.Interactions {
+		if recorder.replayed[i] || interaction.Request != recorded {
+			continue
+		}
+		recorder.replayed[i] = true
+
+		content := interaction.Response.Binary
+		if content == nil {
+			content = []byte(interaction.Response.Body)
+		}
+		header := interaction.Response.Header.Clone()
+		if header == nil {
+			header = http.Header{}
+		}
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          ioutil.NopCloser(bytes.NewReader(content)),
+			ContentLength: int64(len(content)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %v %v %v", ErrInteractionNotFound, recorded.Method, recorded.URL, recorded.Body)
+}
+
+// redact replace every bot token seen in request url
+func (recorder *Recorder) redact(text string) string {
+	text = tokenPattern.ReplaceAllString(text, "/${1}bot"+RedactedToken)
+	for token := range recorder.tokens {
+		text = strings.ReplaceAll(text, token, RedactedToken)
+	}
+	return text
+}
+
+// Unused interactions not replayed yet, a test can check all recorded interactions are used
+func (recorder *Recorder) Unused() []Interaction {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	unused := []Interaction{}
+	for i, interaction := range recorder.cassette.Interactions {
+		if i < len(recorder.replayed) && !recorder.replayed[i] {
+			unused = append(unused, interaction)
+		}
+	}
+	return unused
+}
+
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+	defer req.Body.Close()
+
+	return ioutil.ReadAll(req.Body)
+}
+
+// normalizeURL url with sorted query and without host, so recording against another server replay the same
+func normalizeURL(u *url.URL) string {
+	normalized := u.Path
+	if query := u.Query(); len(query) > 0 {
+		normalized += "?" + query.Encode()
+	}
+	return normalized
+}
+
+/*
+normalizeBody canonical form of body, JSON is re-encoded with sorted keys, form is sorted
+and multipart is encoded as JSON of its fields and files, file content is replaced with its sha256.
+Body which can't be parsed is kept as it is
+*/
+func normalizeBody(contentType string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	mediaType, params, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "application/json":
+		var value interface{}
+		decoder := json.NewDecoder(bytes.NewReader(body))
+		decoder.UseNumber()
+		if err := decoder.Decode(&value); err == nil {
+			if canonical, err := json.Marshal(value); err == nil {
+				return string(canonical)
+			}
+		}
+
+	case "application/x-www-form-urlencoded":
+		if form, err := url.ParseQuery(string(body)); err == nil {
+			return form.Encode()
+		}
+
+	case "multipart/form-data":
+		if canonical, err := normalizeMultipart(body, params["boundary"]); err == nil {
+			return canonical
+		}
+	}
+
+	return string(body)
+}
+
+// normalizeMultipart encode multipart body as JSON of its fields and files, malformed body is reported as error
+func normalizeMultipart(body []byte, boundary string) (string, error) {
+	fields, files := map[string]string{}, map[string]string{}
+	reader := multipart.NewReader(bytes.NewReader(body), boundary)
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		content, err := ioutil.ReadAll(part)
+		if err != nil {
+			return "", err
+		}
+		if part.FileName() == "" {
+			fields[part.FormName()] = string(content)
+			continue
+		}
+		sum := sha256.Sum256(content)
+		files[part.FormName()] = part.FileName() + " sha256:" + hex.EncodeToString(sum[:])
+	}
+	canonical, err := json.Marshal(map[string]interface{}{"fields": fields, "files": files})
+
+	return string(canonical), err
+}
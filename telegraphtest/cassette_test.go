@@ -0,0 +1,87 @@
+package telegraphtest_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"telegraph"
+	"telegraph/telegraphtest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// converse run the same conversation for recording and replaying
+func converse(t *testing.T, client *telegraph.Client) {
+	message, _, err := client.SendMessage(42, "hello").SetDisableNotification(true).Commit()
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", message.Text)
+
+	document, _, err := client.SendDocument(42, telegraph.FileBytes("report.txt", []byte{0xff, 0xfe, 0x00})).Commit()
+	assert.NoError(t, err)
+
+	buffer := &bytes.Buffer{}
+	_, _, err = client.DownloadFile(document.Document.FileID, buffer).Commit()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0xff, 0xfe, 0x00}, buffer.Bytes())
+
+	_, _, err = client.SendMessage(7, "nobody").Commit()
+	assert.True(t, telegraph.IsBadRequest(err))
+}
+
+func TestRecorder_RecordReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	server := newServer()
+	recorder, err := telegraphtest.NewRecorder(path, telegraphtest.ModeAuto)
+	assert.NoError(t, err)
+	assert.Equal(t, telegraphtest.ModeRecord, recorder.Mode())
+
+	converse(t, server.Client(telegraph.WithTransport(recorder)))
+	server.Close()
+	assert.NoError(t, recorder.Save())
+
+	content, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(content), token)
+	assert.NotContains(t, string(content), strings.Split(token, ":")[1])
+	assert.Contains(t, string(content), "/bot"+telegraphtest.RedactedToken+"/sendMessage")
+	assert.Contains(t, string(content), "/file/bot"+telegraphtest.RedactedToken+"/")
+
+	replayer, err := telegraphtest.NewRecorder(path, telegraphtest.ModeAuto)
+	assert.NoError(t, err)
+	assert.Equal(t, telegraphtest.ModeReplay, replayer.Mode())
+
+	client := telegraph.NewClient("987:replay", telegraph.WithBaseURL("http://replay.invalid"),
+		telegraph.WithRetryPolicy(telegraph.NoRetry), telegraph.WithTransport(replayer))
+	converse(t, client)
+	assert.Empty(t, replayer.Unused())
+
+	_, _, err = client.SendMessage(42, "hello").SetDisableNotification(true).Commit()
+	assert.True(t, errors.Is(err, telegraphtest.ErrInteractionNotFound))
+}
+
+func TestRecorder_ReplayMissingCassette(t *testing.T) {
+	_, err := telegraphtest.NewRecorder(filepath.Join(t.TempDir(), "missing.json"), telegraphtest.ModeReplay)
+	assert.Error(t, err)
+}
+
+func TestRecorder_MalformedMultipart(t *testing.T) {
+	server := newServer()
+	defer server.Close()
+	recorder, err := telegraphtest.NewRecorder(filepath.Join(t.TempDir(), "cassette.json"), telegraphtest.ModeRecord)
+	assert.NoError(t, err)
+
+	body := "--boundary\r\nContent-Disposition: form-data; name=\"chat_id\"\r\n\r\n42"
+	res, err := (&http.Client{Transport: recorder}).Post(server.URL()+"/bot"+token+"/sendDocument",
+		"multipart/form-data; boundary=boundary", strings.NewReader(body))
+	assert.NoError(t, err)
+	res.Body.Close()
+
+	interactions := recorder.Cassette().Interactions
+	assert.Len(t, interactions, 1)
+	assert.Equal(t, body, interactions[0].Request.Body)
+}
@@ -0,0 +1,1106 @@
+package telegraphtest
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"telegraph"
+	"time"
+)
+
+// method answer one bot api method, result is sent as result of the response
+type method func(server *Server, r *http.Request, call Call) (interface{}, *telegraph.APIError)
+
+// methods bot api methods by lower case name
+var methods = map[string]method{}
+
+func init() {
+	for name, handler := range map[string]func(server *Server, call Call) (interface{}, *telegraph.APIError){
+		"getMe":                   getMe,
+		"setWebhook":              setWebhook,
+		"deleteWebhook":           deleteWebhook,
+		"getWebhookInfo":          getWebhookInfo,
+		"sendMessage":             sendMessage,
+		"forwardMessage":          forwardMessage,
+		"sendPhoto":               sendMedia("photo"),
+		"sendAudio":               sendMedia("audio"),
+		"sendDocument":            sendMedia("document"),
+		"sendVideo":               sendMedia("video"),
+		"sendVoice":               sendMedia("voice"),
+		"sendVideoNote":           sendMedia("video_note"),
+		"sendSticker":             sendMedia("sticker"),
+		"sendMediaGroup":          sendMediaGroup,
+		"sendLocation":            sendLocation,
+		"editMessageLiveLocation": editMessageLiveLocation,
+		"stopMessageLiveLocation": stopMessageLiveLocation,
+		"sendVenue":               sendVenue,
+		"sendContact":             sendContact,
+		"sendChatAction":          sendChatAction,
+		"getUserProfilePhotos":    getUserProfilePhotos,
+		"getFile":                 getFile,
+		"kickChatMember":          kickChatMember,
+		"unbanChatMember":         unbanChatMember,
+		"restrictChatMember":      restrictChatMember,
+		"promoteChatMember":       promoteChatMember,
+		"exportChatInviteLink":    exportChatInviteLink,
+		"setChatPhoto":            setChatPhoto,
+		"deleteChatPhoto":         deleteChatPhoto,
+		"setChatTitle":            setChatTitle,
+		"setChatDescription":      setChatDescription,
+		"pinChatMessage":          pinChatMessage,
+		"unpinChatMessage":        unpinChatMessage,
+		"leaveChat":               leaveChat,
+		"getChat":                 getChat,
+		"getChatAdministrators":   getChatAdministrators,
+		"getChatMembersCount":     getChatMembersCount,
+		"getChatMember":           getChatMember,
+		"setChatStickerSet":       setChatStickerSet,
+		"deleteChatStickerSet":    deleteChatStickerSet,
+		"answerCallbackQuery":     requireTrue("callback_query_id"),
+		"editMessageText":         editMessageText,
+		"editMessageCaption":      editMessageCaption,
+		"editMessageReplyMarkup":  editMessageReplyMarkup,
+		"deleteMessage":           deleteMessage,
+		"getStickerSet":           getStickerSet,
+		"uploadStickerFile":       uploadStickerFile,
+		"createNewStickerSet":     createNewStickerSet,
+		"addStickerToSet":         addStickerToSet,
+		"setStickerPositionInSet": setStickerPositionInSet,
+		"deleteStickerFromSet":    deleteStickerFromSet,
+		"answerInlineQuery":       requireTrue("inline_query_id", "results"),
+		"sendInvoice":             sendInvoice,
+		"answerShippingQuery":     answerShippingQuery,
+		"answerPreCheckoutQuery":  answerPreCheckoutQuery,
+		"sendGame":                sendGame,
+		"setGameScore":            setGameScore,
+		"getGameHighScores":       getGameHighScores,
+	} {
+		methods[strings.ToLower(name)] = locked(handler)
+	}
+	methods["getupdates"] = getUpdates
+}
+
+// locked run handler holding the server lock
+func locked(handler func(server *Server, call Call) (interface{}, *telegraph.APIError)) method {
+	return func(server *Server, r *http.Request, call Call) (interface{}, *telegraph.APIError) {
+		server.mutex.Lock()
+		defer server.mutex.Unlock()
+
+		return handler(server, call)
+	}
+}
+
+func getMe(server *Server, call Call) (interface{}, *telegraph.APIError) {
+	return server.bot, nil
+}
+
+func setWebhook(server *Server, call Call) (interface{}, *telegraph.APIError) {
+	webhook := call.Params["url"]
+	if webhook == "" {
+		server.webhook = telegraph.WebhookInfo{}
+		return true, nil
+	}
+	if !strings.HasPrefix(webhook, "https://") {
+		return nil, badRequest("bad webhook: HTTPS url must be provided for webhook")
+	}
+
+	info := telegraph.WebhookInfo{URL: webhook, MaxConnections: 40}
+	if _, ok := call.Files["certificate"]; ok {
+		info.HasCustomCertificate = true
+	}
+	if connections := call.Int64("max_connections"); connections != 0 {
+		info.MaxConnections = int(connections)
+	}
+	if err := call.Decode("allowed_updates", &info.AllowedUpdates); err != nil && call.Params["allowed_updates"] != "" {
+		return nil, badRequest("can't parse allowed updates")
+	}
+	server.webhook = info
+
+	return true, nil
+}
+
+func deleteWebhook(server *Server, call Call) (interface{}, *telegraph.APIError) {
+	server.webhook = telegraph.WebhookInfo{}
+	return true, nil
+}
+
+func getWebhookInfo(server *Server, call Call) (interface{}, *telegraph.APIError) {
+	info := server.webhook
+	info.PendingUpdateCount = len(server.updates)
+	return info, nil
+}
+
+// getUpdates confirm updates before offset and return the next updates, wait for timeout seconds when there is no update
+func getUpdates(server *Server, r *http.Request, call Call) (interface{}, *telegraph.APIError) {
+	deadline := time.Now().Add(time.Duration(call.Int64("timeout")) * time.Second)
+
+	for {
+		server.mutex.Lock()
+		if server.webhook.URL != "" {
+			server.mutex.Unlock()
+			return nil, &telegraph.APIError{
+				ErrorCode:   http.StatusConflict,
+				Description: "Conflict: can't use getUpdates method while webhook is active; use deleteWebhook to delete the webhook first",
+			}
+		}
+		updates := server.pendingUpdates(call.Int64("offset"), int(call.Int64("limit")))
+		signal := server.updateSignal
+		server.mutex.Unlock()
+
+		wait := time.Until(deadline)
+		if len(updates) > 0 || wait <= 0 {
+			return updates, nil
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-signal:
+		case <-timer.C:
+		case <-r.Context().Done():
+		case <-server.closed:
+		}
+		timer.Stop()
+		if r.Context().Err() != nil || isClosed(server.closed) {
+			return []telegraph.Update{}, nil
+		}
+	}
+}
+
+// pendingUpdates drop updates confirmed by offset and return up to limit updates
+func (server *Server) pendingUpdates(offset int64, limit int) []telegraph.Update {
+	if offset > 0 {
+		index := 0
+		for index < len(server.updates) && server.updates[index].UpdateID < offset {
+			index++
+		}
+		server.updates = server.updates[index:]
+	}
+
+	updates := server.updates
+	if offset < 0 && int(-offset) < len(updates) {
+		updates = updates[len(updates)+int(offset):]
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 100
+	}
+	if len(updates) > limit {
+		updates = updates[:limit]
+	}
+
+	return append([]telegraph.Update{}, updates...)
+}
+
+func sendMessage(server *Server, call Call) (interface{}, *telegraph.APIError) {
+	chat, err := server.chat(call, "chat_id")
+	if err != nil {
+		return nil, err
+	}
+	if call.Params["text"] == "" {
+		return nil, badRequest("message text is empty")
+	}
+
+	return server.send(chat, call, telegraph.Message{Text: call.Params["text"]})
+}
+
+func forwardMessage(server *Server, call Call) (interface{}, *telegraph.APIError) {
+	chat, err := server.chat(call, "chat_id")
+	if err != nil {
+		return nil, err
+	}
+	from, err := server.chat(call, "from_chat_id")
+	if err != nil {
+		return nil, err
+	}
+	original := server.message(from.ID, call.Int64("message_id"))
+	if original == nil {
+		return nil, badRequest("message to forward not found")
+	}
+
+	message := *original
+	message.ReplyToMessage = nil
+	message.EditDate = 0
+	message.ForwardFromMessageID = original.MessageID
+	message.ForwardDate = original.Date
+	if original.ForwardDate != 0 {
+		message.ForwardDate = original.ForwardDate
+	}
+	if original.ForwardFrom == nil && original.ForwardFromChat == nil {
+		message.ForwardFrom = original.From
+		if from.Type == telegraph.ChatTypeChannel {
+			channel := *from
+			message.ForwardFrom, message.ForwardFromChat = nil, &channel
+		}
+	}
+
+	return server.send(chat, Call{Params: map[string]string{}}, message)
+}
+
+// sendMedia send file of field, e.g. photo or document
+func sendMedia(field string) func(server *Server, call Call) (interface{}, *telegraph.APIError) {
+	return func(server *Server, call Call) (interface{}, *telegraph.APIError) {
+		chat, err := server.chat(call, "chat_id")
+		if err != nil {
+			return nil, err
+		}
+		file, err := server.inputFile(call, field)
+		if err != nil {
+			return nil, err
+		}
+
+		message := telegraph.Message{}
+		setMedia(&message, field, file, call)
+		return server.send(chat, call, message)
+	}
+}
+
+// setMedia set file of message content according to field
+func setMedia(message *telegraph.Message, field string, file *storedFile, call Call) {
+	duration := int(call.Int64("duration"))
+	switch field {
+	case "photo":
+		message.Photos = []telegraph.PhotoSize{{FileID: file.FileID, FileSize: file.FileSize}}
+	case "audio":
+		message.Audio = &telegraph.Audio{FileID: file.FileID, FileSize: file.FileSize, Duration: duration,
+			Performer: call.Params["performer"], Title: call.Params["title"]}
+	case "document":
+		message.Document = &telegraph.Document{FileID: file.FileID, FileSize: file.FileSize, FileName: file.name}
+	case "video":
+		message.Video = &telegraph.Video{FileID: file.FileID, FileSize: file.FileSize, Duration: duration,
+			Width: call.Int64("width"), Height: call.Int64("height")}
+	case "voice":
+		message.Voice = &telegraph.Voice{FileID: file.FileID, FileSize: file.FileSize, Duration: duration}
+	case "video_note":
+		message.VideoNote = &telegraph.VideoNote{FileID: file.FileID, FileSize: file.FileSize, Duration: duration,
+			Length: int(call.Int64("length"))}
+	case "sticker":
+		message.Sticker = &telegraph.Sticker{FileID: file.FileID, FileSize: file.FileSize, Width: 512, Height: 512}
+	}
+}
+
+func sendMediaGroup(server *Server, call Call) (interface{}, *telegraph.APIError) {
+	chat, err := server.chat(call, "chat_id")
+	if err != nil {
+		return nil, err
+	}
+	media := []telegraph.InputMedia{}
+	if err := call.Decode("media", &media); err != nil {
+		return nil, badRequest("can't parse media JSON object")
+	}
+	if len(media) < 2 || len(media) > 10 {
+		return nil, badRequest("media must include 2-10 items")
+	}
+
+	messages := []telegraph.Message{}
+	for _, item := range media {
+		field := "photo"
+		if item.Type == telegraph.MediaTypeVideo {
+			field = "video"
+		}
+		file, err := server.inputFile(Call{Params: map[string]string{field: item.Media}, Files: call.Files}, field)
+		if err != nil {
+			return nil, err
+		}
+
+		message := telegraph.Message{Caption: item.Caption}
+		setMedia(&message, field, file, Call{Params: map[string]string{
+			"duration": strconv.Itoa(item.Duration),
+			"width":    strconv.Itoa(item.Width),
+			"height":   strconv.Itoa(item.Height),
+		}})
+		sent, err := server.send(chat, call, message)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, sent)
+	}
+
+	return messages, nil
+}
+
+func sendLocation(server *Server, call Call) (interface{}, *telegraph.APIError) {
+	chat, err := server.chat(call, "chat_id")
+	if err != nil {
+		return nil, err
+	}
+
+	return server.send(chat, call, telegraph.Message{Location: &telegraph.Location{
+		Latitude:  call.Float64("latitude"),
+		Longitude: call.Float64("longitude"),
+	}})
+}
+
+func editMessageLiveLocation(server *Server, call Call) (interface{}, *telegraph.APIError) {
+	return server.edit(call, func(message *telegraph.Message) *telegraph.APIError {
+		if message.Location == nil {
+			return badRequest("message can't be edited")
+		}
+		message.Location = &telegraph.Location{Latitude: call.Float64("latitude"), Longitude: call.Float64("longitude")}
+		return nil
+	})
+}
+
+func stopMessageLiveLocation(server *Server, call Call) (interface{}, *telegraph.APIError) {
+	return server.edit(call, func(message *telegraph.Message) *telegraph.APIError {
+		if message.Location == nil {
+			return badRequest("message can't be edited")
+		}
+		return nil
+	})
+}
+
+func sendVenue(server *Server, call Call) (interface{}, *telegraph.APIError) {
+	chat, err := server.chat(call, "chat_id")
+	if err != nil {
+		return nil, err
+	}
+
+	location := telegraph.Location{Latitude: call.Float64("latitude"), Longitude: call.Float64("longitude")}
+	return server.send(chat, call, telegraph.Message{
+		Location: &location,
+		Venue: &telegraph.Venue{
+			Location:     location,
+			Title:        call.Params["title"],
+			Address:      call.Params["address"],
+			FoursquareID: call.Params["foursquare_id"],
+		},
+	})
+}
+
+func sendContact(server *Server, call Call) (interface{}, *telegraph.APIError) {
+	chat, err := server.chat(call, "chat_id")
+	if err != nil {
+		return nil, err
+	}
+	if call.Params["phone_number"] == "" {
+		return nil, badRequest("phone number is empty")
+	}
+
+	return server.send(chat, call, telegraph.Message{Contact: &telegraph.Contact{
+		PhoneNumber: call.Params["phone_number"],
+		FirstName:   call.Params["first_name"],
+		LastName:    call.Params["last_name"],
+	}})
+}
+
+func sendChatAction(server *Server, call Call) (interface{}, *telegraph.APIError) {
+	if _, err := server.chat(call, "chat_id"); err != nil {
+		return nil, err
+	}
+	if call.Params["action"] == "" {
+		return nil, badRequest("wrong type of action")
+	}
+	return true, nil
+}
+
+func getUserProfilePhotos(server *Server, call Call) (interface{}, *telegraph.APIError) {
+	if call.Int64("user_id") == 0 {
+		return nil, badRequest("user not found")
+	}
+	return telegraph.UserProfilePhotos{Photos: [][]telegraph.PhotoSize{}}, nil
+}
+
+func getFile(server *Server, call Call) (interface{}, *telegraph.APIError) {
+	file, ok := server.files[call.Params["file_id"]]
+	if !ok {
+		return nil, badRequest("invalid file_id")
+	}
+	return file.File, nil
+}
+
+func kickChatMember(server *Server, call Call) (interface{}, *telegraph.APIError) {
+	return server.updateMember(call, func(member *telegraph.ChatMember) {
+		member.Status = telegraph.StatusTypeKicked
+		member.UntilDate = call.Int64("until_date")
+	})
+}
+
+func unbanChatMember(server *Server, call Call) (interface{}, *telegraph.APIError) {
+	return server.updateMember(call, func(member *telegraph.ChatMember) {
+		if member.Status == telegraph.StatusTypeKicked {
+			member.Status = telegraph.StatusTypeLeft
+			member.UntilDate = 0
+		}
+	})
+}
+
+func restrictChatMember(server *Server, call Call) (interface{}, *telegraph.APIError) {
+	return server.updateMember(call, func(member *telegraph.ChatMember) {
+		member.Status = telegraph.StatusTypeRestricted
+		member.UntilDate = call.Int64("until_date")
+		member.CanSendMessage = call.Bool("can_send_messages")
+		member.CanSendMediaMessage = call.Bool("can_send_media_messages")
+		member.CanSendOtherMessage = call.Bool("can_send_other_messages")
+		member.CanAddWebPagePreview = call.Bool("can_add_web_page_previews")
+	})
+}
+
+func promoteChatMember(server *Server, call Call) (interface{}, *telegraph.APIError) {
+	return server.updateMember(call, func(member *telegraph.ChatMember) {
+		member.Status = telegraph.StatusTypeAdministrator
+		member.CanChangeInfo = call.Bool("can_change_info")
+		member.CanPostMessage = call.Bool("can_post_messages")
+		member.CanEditMessage = call.Bool("can_edit_messages")
+		member.CanDeleteMessage = call.Bool("can_delete_messages")
+		member.CanInviteUser = call.Bool("can_invite_users")
+		member.CanRestrictMember = call.Bool("can_restrict_members")
+		member.CanPinMessage = call.Bool("can_pin_messages")
+		member.CanPromoteMember = call.Bool("can_promote_members")
+	})
+}
+
+func exportChatInviteLink(server *Server, call Call) (interface{}, *telegraph.APIError) {
+	chat, err := server.group(call)
+	if err != nil {
+		return nil, err
+	}
+	chat.InviteLink = fmt.Sprintf("https://t.me/joinchat/%x", time.Now().UnixNano())
+	return chat.InviteLink, nil
+}
+
+func setChatPhoto(server *Server, call Call) (interface{}, *telegraph.APIError) {
+	chat, err := server.group(call)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := call.Files["photo"]; !ok {
+		return nil, badRequest("there is no photo in the request")
+	}
+	file, err := server.inputFile(call, "photo")
+	if err != nil {
+		return nil, err
+	}
+	chat.Photo = &telegraph.ChatPhoto{SmallFileID: file.FileID, BigFileID: file.FileID}
+	return true, nil
+}
+
+func deleteChatPhoto(server *Server, call Call) (interface{}, *telegraph.APIError) {
+	chat, err := server.group(call)
+	if err != nil {
+		return nil, err
+	}
+	if chat.Photo == nil {
+		return nil, badRequest("CHAT_NOT_MODIFIED")
+	}
+	chat.Photo = nil
+	return true, nil
+}
+
+func setChatTitle(server *Server, call Call) (interface{}, *telegraph.APIError) {
+	chat, err := server.group(call)
+	if err != nil {
+		return nil, err
+	}
+	if call.Params["title"] == "" {
+		return nil, badRequest("chat title is empty")
+	}
+	chat.Title = call.Params["title"]
+	return true, nil
+}
+
+func setChatDescription(server *Server, call Call) (interface{}, *telegraph.APIError) {
+	chat, err := server.group(call)
+	if err != nil {
+		return nil, err
+	}
+	chat.Description = call.Params["description"]
+	return true, nil
+}
+
+func pinChatMessage(server *Server, call Call) (interface{}, *telegraph.APIError) {
+	chat, err := server.group(call)
+	if err != nil {
+		return nil, err
+	}
+	message := server.message(chat.ID, call.Int64("message_id"))
+	if message == nil {
+		return nil, badRequest("message to pin not found")
+	}
+	pinned := *message
+	chat.PinnedMessage = &pinned
+	return true, nil
+}
+
+func unpinChatMessage(server *Server, call Call) (interface{}, *telegraph.APIError) {
+	chat, err := server.group(call)
+	if err != nil {
+		return nil, err
+	}
+	chat.PinnedMessage = nil
+	return true, nil
+}
+
+func leaveChat(server *Server, call Call) (interface{}, *telegraph.APIError) {
+	chat, err := server.chat(call, "chat_id")
+	if err != nil {
+		return nil, err
+	}
+	delete(server.members[chat.ID], server.bot.ID)
+	return true, nil
+}
+
+func getChat(server *Server, call Call) (interface{}, *telegraph.APIError) {
+	chat, err := server.chat(call, "chat_id")
+	if err != nil {
+		return nil, err
+	}
+	return *chat, nil
+}
+
+func getChatAdministrators(server *Server, call Call) (interface{}, *telegraph.APIError) {
+	chat, err := server.chat(call, "chat_id")
+	if err != nil {
+		return nil, err
+	}
+
+	administrators := []telegraph.ChatMember{}
+	for _, member := range server.sortedMembers(chat.ID) {
+		if member.Status == telegraph.StatusTypeCreator || member.Status == telegraph.StatusTypeAdministrator {
+			administrators = append(administrators, member)
+		}
+	}
+	return administrators, nil
+}
+
+func getChatMembersCount(server *Server, call Call) (interface{}, *telegraph.APIError) {
+	chat, err := server.chat(call, "chat_id")
+	if err != nil {
+		return nil, err
+	}
+
+	count := 0
+	for _, member := range server.members[chat.ID] {
+		if member.Status != telegraph.StatusTypeLeft && member.Status != telegraph.StatusTypeKicked {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func getChatMember(server *Server, call Call) (interface{}, *telegraph.APIError) {
+	chat, err := server.chat(call, "chat_id")
+	if err != nil {
+		return nil, err
+	}
+	member, ok := server.members[chat.ID][call.Int64("user_id")]
+	if !ok {
+		return nil, badRequest("user not found")
+	}
+	return *member, nil
+}
+
+func setChatStickerSet(server *Server, call Call) (interface{}, *telegraph.APIError) {
+	chat, err := server.group(call)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := server.stickerSets[call.Params["sticker_set_name"]]; !ok {
+		return nil, badRequest("STICKERSET_INVALID")
+	}
+	chat.StickerSetName = call.Params["sticker_set_name"]
+	return true, nil
+}
+
+func deleteChatStickerSet(server *Server, call Call) (interface{}, *telegraph.APIError) {
+	chat, err := server.group(call)
+	if err != nil {
+		return nil, err
+	}
+	chat.StickerSetName = ""
+	return true, nil
+}
+
+func editMessageText(server *Server, call Call) (interface{}, *telegraph.APIError) {
+	if call.Params["text"] == "" {
+		return nil, badRequest("message text is empty")
+	}
+	return server.edit(call, func(message *telegraph.Message) *telegraph.APIError {
+		if message.Text == "" {
+			return badRequest("there is no text in the message to edit")
+		}
+		if message.Text == call.Params["text"] && call.Params["reply_markup"] == "" {
+			return notModified()
+		}
+		message.Text = call.Params["text"]
+		return nil
+	})
+}
+
+func editMessageCaption(server *Server, call Call) (interface{}, *telegraph.APIError) {
+	return server.edit(call, func(message *telegraph.Message) *telegraph.APIError {
+		if message.Text != "" {
+			return badRequest("there is no caption in the message to edit")
+		}
+		if message.Caption == call.Params["caption"] && call.Params["reply_markup"] == "" {
+			return notModified()
+		}
+		message.Caption = call.Params["caption"]
+		return nil
+	})
+}
+
+func editMessageReplyMarkup(server *Server, call Call) (interface{}, *telegraph.APIError) {
+	return server.edit(call, func(message *telegraph.Message) *telegraph.APIError {
+		return nil
+	})
+}
+
+func deleteMessage(server *Server, call Call) (interface{}, *telegraph.APIError) {
+	chat, err := server.chat(call, "chat_id")
+	if err != nil {
+		return nil, err
+	}
+
+	messageId := call.Int64("message_id")
+	messages := server.messages[chat.ID]
+	for i, message := range messages {
+		if message.MessageID == messageId {
+			server.messages[chat.ID] = append(messages[:i:i], messages[i+1:]...)
+			return true, nil
+		}
+	}
+	return nil, badRequest("message to delete not found")
+}
+
+func getStickerSet(server *Server, call Call) (interface{}, *telegraph.APIError) {
+	set, ok := server.stickerSets[call.Params["name"]]
+	if !ok {
+		return nil, badRequest("STICKERSET_INVALID")
+	}
+	return *set, nil
+}
+
+func uploadStickerFile(server *Server, call Call) (interface{}, *telegraph.APIError) {
+	if call.Int64("user_id") == 0 {
+		return nil, badRequest("user not found")
+	}
+	upload, ok := call.Files["png_sticker"]
+	if !ok {
+		return nil, badRequest("there is no png_sticker in the request")
+	}
+	return server.storeFile(upload.Name, upload.Content).File, nil
+}
+
+func createNewStickerSet(server *Server, call Call) (interface{}, *telegraph.APIError) {
+	name := call.Params["name"]
+	if !strings.HasSuffix(strings.ToLower(name), "_by_"+strings.ToLower(server.bot.Username)) {
+		return nil, badRequest("invalid sticker set name is specified")
+	}
+	if _, ok := server.stickerSets[name]; ok {
+		return nil, badRequest("sticker set name is already occupied")
+	}
+	sticker, err := server.sticker(call, name)
+	if err != nil {
+		return nil, err
+	}
+
+	server.stickerSets[name] = &telegraph.StickerSet{
+		Name:          name,
+		Title:         call.Params["title"],
+		ContainsMasks: call.Bool("contains_masks"),
+		Stickers:      []telegraph.Sticker{sticker},
+	}
+	return true, nil
+}
+
+func addStickerToSet(server *Server, call Call) (interface{}, *telegraph.APIError) {
+	set, ok := server.stickerSets[call.Params["name"]]
+	if !ok {
+		return nil, badRequest("STICKERSET_INVALID")
+	}
+	sticker, err := server.sticker(call, set.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	set.Stickers = append(set.Stickers, sticker)
+	return true, nil
+}
+
+func setStickerPositionInSet(server *Server, call Call) (interface{}, *telegraph.APIError) {
+	set, index := server.findSticker(call.Params["sticker"])
+	if set == nil {
+		return nil, badRequest("STICKER_INVALID")
+	}
+	position := int(call.Int64("position"))
+	if position < 0 || position >= len(set.Stickers) {
+		return nil, badRequest("STICKER_POSITION_INVALID")
+	}
+
+	sticker := set.Stickers[index]
+	stickers := append(set.Stickers[:index:index], set.Stickers[index+1:]...)
+	set.Stickers = append(stickers[:position:position], append([]telegraph.Sticker{sticker}, stickers[position:]...)...)
+	return true, nil
+}
+
+func deleteStickerFromSet(server *Server, call Call) (interface{}, *telegraph.APIError) {
+	set, index := server.findSticker(call.Params["sticker"])
+	if set == nil {
+		return nil, badRequest("STICKER_INVALID")
+	}
+	set.Stickers = append(set.Stickers[:index:index], set.Stickers[index+1:]...)
+	return true, nil
+}
+
+func sendInvoice(server *Server, call Call) (interface{}, *telegraph.APIError) {
+	chat, err := server.chat(call, "chat_id")
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range []string{"title", "description", "payload", "provider_token", "start_parameter", "currency"} {
+		if call.Params[name] == "" {
+			return nil, badRequest(name + " is empty")
+		}
+	}
+	prices := []telegraph.LabeledPrice{}
+	if err := call.Decode("prices", &prices); err != nil || len(prices) == 0 {
+		return nil, badRequest("CURRENCY_TOTAL_AMOUNT_INVALID")
+	}
+
+	invoice := &telegraph.Invoice{
+		Title:          call.Params["title"],
+		Description:    call.Params["description"],
+		StartParameter: call.Params["start_parameter"],
+		Currency:       call.Params["currency"],
+	}
+	for _, price := range prices {
+		invoice.TotalAmount += price.Amount
+	}
+	return server.send(chat, call, telegraph.Message{Invoice: invoice})
+}
+
+func answerShippingQuery(server *Server, call Call) (interface{}, *telegraph.APIError) {
+	if call.Params["shipping_query_id"] == "" {
+		return nil, badRequest("QUERY_ID_INVALID")
+	}
+	if call.Bool("ok") && call.Params["shipping_options"] == "" {
+		return nil, badRequest("SHIPPING_OPTIONS_EMPTY")
+	}
+	if !call.Bool("ok") && call.Params["error_message"] == "" {
+		return nil, badRequest("error_message is empty")
+	}
+	return true, nil
+}
+
+func answerPreCheckoutQuery(server *Server, call Call) (interface{}, *telegraph.APIError) {
+	if call.Params["pre_checkout_query_id"] == "" {
+		return nil, badRequest("QUERY_ID_INVALID")
+	}
+	if !call.Bool("ok") && call.Params["error_message"] == "" {
+		return nil, badRequest("error_message is empty")
+	}
+	return true, nil
+}
+
+func sendGame(server *Server, call Call) (interface{}, *telegraph.APIError) {
+	chat, err := server.chat(call, "chat_id")
+	if err != nil {
+		return nil, err
+	}
+	if call.Params["game_short_name"] == "" {
+		return nil, badRequest("GAME_SHORTNAME_INVALID")
+	}
+
+	return server.send(chat, call, telegraph.Message{Game: &telegraph.Game{
+		Title:       call.Params["game_short_name"],
+		Description: call.Params["game_short_name"],
+		Photo:       []telegraph.PhotoSize{},
+	}})
+}
+
+func setGameScore(server *Server, call Call) (interface{}, *telegraph.APIError) {
+	key, message, err := server.gameMessage(call)
+	if err != nil {
+		return nil, err
+	}
+	userId, score := call.Int64("user_id"), int(call.Int64("score"))
+	if userId == 0 {
+		return nil, badRequest("USER_ID_INVALID")
+	}
+
+	if server.scores[key] == nil {
+		server.scores[key] = map[int64]telegraph.GameHighScore{}
+	}
+	if current, ok := server.scores[key][userId]; ok && current.Score >= score && !call.Bool("force") {
+		return nil, badRequest("BOT_SCORE_NOT_MODIFIED")
+	}
+	server.scores[key][userId] = telegraph.GameHighScore{User: server.user(userId), Score: score}
+
+	if message == nil || call.Bool("disable_edit_message") {
+		return true, nil
+	}
+	message.EditDate = time.Now().Unix()
+	return *message, nil
+}
+
+func getGameHighScores(server *Server, call Call) (interface{}, *telegraph.APIError) {
+	key, _, err := server.gameMessage(call)
+	if err != nil {
+		return nil, err
+	}
+
+	scores := []telegraph.GameHighScore{}
+	for _, score := range server.scores[key] {
+		scores = append(scores, score)
+	}
+	sort.Slice(scores, func(i, j int) bool {
+		if scores[i].Score != scores[j].Score {
+			return scores[i].Score > scores[j].Score
+		}
+		return scores[i].User.ID < scores[j].User.ID
+	})
+	for i := range scores {
+		scores[i].Position = i + 1
+	}
+	return scores, nil
+}
+
+// requireTrue answer true when parameters are present
+func requireTrue(names ...string) func(server *Server, call Call) (interface{}, *telegraph.APIError) {
+	return func(server *Server, call Call) (interface{}, *telegraph.APIError) {
+		for _, name := range names {
+			if call.Params[name] == "" {
+				return nil, badRequest(name + " is empty")
+			}
+		}
+		return true, nil
+	}
+}
+
+// chat find chat by identifier or @username in parameter name
+func (server *Server) chat(call Call, name string) (*telegraph.Chat, *telegraph.APIError) {
+	value := call.Params[name]
+	if value == "" {
+		return nil, badRequest(name + " is empty")
+	}
+
+	if strings.HasPrefix(value, "@") {
+		for _, chat := range server.chats {
+			if strings.EqualFold("@"+chat.Username, value) {
+				return chat, nil
+			}
+		}
+	} else if id, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if chat, ok := server.chats[id]; ok {
+			return chat, nil
+		}
+	}
+
+	return nil, badRequest("chat not found")
+}
+
+// group find chat in chat_id which must be a group, supergroup or channel
+func (server *Server) group(call Call) (*telegraph.Chat, *telegraph.APIError) {
+	chat, err := server.chat(call, "chat_id")
+	if err != nil {
+		return nil, err
+	}
+	if chat.Type == telegraph.ChatTypePrivate {
+		return nil, badRequest("chat is not a group")
+	}
+	return chat, nil
+}
+
+// message find stored message in chat
+func (server *Server) message(chatId, messageId int64) *telegraph.Message {
+	for _, message := range server.messages[chatId] {
+		if message.MessageID == messageId {
+			return message
+		}
+	}
+	return nil
+}
+
+// send store message sent by the bot to chat and return a copy of it
+func (server *Server) send(chat *telegraph.Chat, call Call, message telegraph.Message) (telegraph.Message, *telegraph.APIError) {
+	if replyId := call.Int64("reply_to_message_id"); replyId != 0 {
+		reply := server.message(chat.ID, replyId)
+		if reply == nil {
+			return telegraph.Message{}, badRequest("reply message not found")
+		}
+		replied := *reply
+		replied.ReplyToMessage = nil
+		message.ReplyToMessage = &replied
+	}
+
+	bot := server.bot
+	server.lastMessageID[chat.ID]++
+	message.MessageID = server.lastMessageID[chat.ID]
+	message.From = &bot
+	message.Date = time.Now().Unix()
+	message.Chat = *chat
+	if message.Caption == "" {
+		message.Caption = call.Params["caption"]
+	}
+
+	stored := message
+	server.messages[chat.ID] = append(server.messages[chat.ID], &stored)
+	return message, nil
+}
+
+// edit apply fn to message in chat_id and message_id, message sent with inline mode is not stored so true is returned
+func (server *Server) edit(call Call, fn func(message *telegraph.Message) *telegraph.APIError) (interface{}, *telegraph.APIError) {
+	if call.Params["inline_message_id"] != "" {
+		return true, nil
+	}
+
+	chat, err := server.chat(call, "chat_id")
+	if err != nil {
+		return nil, err
+	}
+	message := server.message(chat.ID, call.Int64("message_id"))
+	if message == nil {
+		return nil, badRequest("message to edit not found")
+	}
+
+	edited := *message
+	if err := fn(&edited); err != nil {
+		return nil, err
+	}
+	edited.EditDate = time.Now().Unix()
+	*message = edited
+
+	return edited, nil
+}
+
+// inputFile file sent in field, uploaded file is stored, file id must be known and url is fetched as empty file
+func (server *Server) inputFile(call Call, field string) (*storedFile, *telegraph.APIError) {
+	if upload, ok := call.Files[field]; ok {
+		return server.storeFile(upload.Name, upload.Content), nil
+	}
+
+	value := call.Params[field]
+	if strings.HasPrefix(value, "attach://") {
+		upload, ok := call.Files[strings.TrimPrefix(value, "attach://")]
+		if !ok {
+			return nil, badRequest("wrong file identifier/HTTP URL specified")
+		}
+		return server.storeFile(upload.Name, upload.Content), nil
+	}
+
+	switch {
+	case value == "":
+		return nil, badRequest("there is no " + field + " in the request")
+	case server.files[value] != nil:
+		return server.files[value], nil
+	case strings.HasPrefix(value, "http://") || strings.HasPrefix(value, "https://"):
+		return server.storeFile(path.Base(value), nil), nil
+	}
+
+	return nil, badRequest("wrong file identifier/HTTP URL specified")
+}
+
+// updateMember apply fn to member user_id of group chat_id, unknown user become a member
+func (server *Server) updateMember(call Call, fn func(member *telegraph.ChatMember)) (interface{}, *telegraph.APIError) {
+	chat, err := server.group(call)
+	if err != nil {
+		return nil, err
+	}
+	userId := call.Int64("user_id")
+	if userId == 0 {
+		return nil, badRequest("user not found")
+	}
+
+	member, ok := server.members[chat.ID][userId]
+	if !ok {
+		user := server.user(userId)
+		member = &telegraph.ChatMember{User: &user, Status: telegraph.StatusTypeMember}
+		server.members[chat.ID][userId] = member
+	}
+	if member.Status == telegraph.StatusTypeCreator {
+		return nil, badRequest("can't remove chat owner")
+	}
+	fn(member)
+
+	return true, nil
+}
+
+// sortedMembers members of chat ordered by user id
+func (server *Server) sortedMembers(chatId int64) []telegraph.ChatMember {
+	members := []telegraph.ChatMember{}
+	for _, member := range server.members[chatId] {
+		members = append(members, *member)
+	}
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].User.ID < members[j].User.ID
+	})
+	return members
+}
+
+// user known user with id from chat members, otherwise user with id only
+func (server *Server) user(id int64) telegraph.User {
+	for _, members := range server.members {
+		if member, ok := members[id]; ok && member.User != nil {
+			return *member.User
+		}
+	}
+	return telegraph.User{ID: id}
+}
+
+// sticker new sticker of set from png_sticker and emojis
+func (server *Server) sticker(call Call, set string) (telegraph.Sticker, *telegraph.APIError) {
+	if call.Int64("user_id") == 0 {
+		return telegraph.Sticker{}, badRequest("USER_ID_INVALID")
+	}
+	if call.Params["emojis"] == "" {
+		return telegraph.Sticker{}, badRequest("invalid sticker emojis")
+	}
+	file, err := server.inputFile(call, "png_sticker")
+	if err != nil {
+		return telegraph.Sticker{}, err
+	}
+
+	return telegraph.Sticker{
+		FileID:   file.FileID,
+		Width:    512,
+		Height:   512,
+		Emoji:    call.Params["emojis"],
+		SetName:  set,
+		FileSize: file.FileSize,
+	}, nil
+}
+
+// findSticker sticker set containing sticker file id and the index of the sticker
+func (server *Server) findSticker(fileId string) (*telegraph.StickerSet, int) {
+	for _, set := range server.stickerSets {
+		for i, sticker := range set.Stickers {
+			if sticker.FileID == fileId {
+				return set, i
+			}
+		}
+	}
+	return nil, -1
+}
+
+// gameMessage key of game scores and the game message, message is nil for game sent with inline mode
+func (server *Server) gameMessage(call Call) (string, *telegraph.Message, *telegraph.APIError) {
+	if id := call.Params["inline_message_id"]; id != "" {
+		return "inline:" + id, nil, nil
+	}
+
+	chat, err := server.chat(call, "chat_id")
+	if err != nil {
+		return "", nil, err
+	}
+	message := server.message(chat.ID, call.Int64("message_id"))
+	if message == nil || message.Game == nil {
+		return "", nil, badRequest("message with game not found")
+	}
+	return fmt.Sprintf("%d:%d", chat.ID, message.MessageID), message, nil
+}
+
+func notModified() *telegraph.APIError {
+	return badRequest("message is not modified: specified new message content and reply markup are exactly the same " +
+		"as a current content and reply markup of the message")
+}
+
+func isClosed(closed chan struct{}) bool {
+	select {
+	case <-closed:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,524 @@
+/*
+Package telegraphtest in-process fake of telegram bot api for testing bot without network access or gock mock.
+
+Server keep chats, members, messages, files, sticker sets, game scores and web hook in memory,
+answer every method supported by telegraph with realistic response and error, serve incoming updates pushed by the test
+to GetUpdates and record every call for assertion.
+
+	server := telegraphtest.NewServer("123:token")
+	defer server.Close()
+
+	server.AddChat(telegraph.Chat{ID: 42, Type: telegraph.ChatTypePrivate, FirstName: "Jane"})
+	client := server.Client()
+
+	message, _, err := client.SendMessage(42, "hello").Commit()
+	calls := server.CallsTo("sendMessage")
+
+Recorder record real traffic of client to a cassette file and replay it in regression test.
+*/
+package telegraphtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"telegraph"
+	"time"
+)
+
+type (
+	// Server fake telegram bot api served by httptest.Server
+	Server struct {
+		server *httptest.Server
+		token  string
+		bot    telegraph.User
+
+		mutex         sync.Mutex
+		chats         map[int64]*telegraph.Chat
+		members       map[int64]map[int64]*telegraph.ChatMember
+		messages      map[int64][]*telegraph.Message
+		lastMessageID map[int64]int64
+		files         map[string]*storedFile
+		lastFileID    int
+		stickerSets   map[string]*telegraph.StickerSet
+		scores        map[string]map[int64]telegraph.GameHighScore
+		updates       []telegraph.Update
+		lastUpdateID  int64
+		updateSignal  chan struct{}
+		webhook       telegraph.WebhookInfo
+		calls         []Call
+		failures      map[string][]telegraph.APIError
+		closed        chan struct{}
+		closeOnce     sync.Once
+	}
+
+	// Call request received by the server, parameters sent as query, JSON, form or multipart are merged into Params.
+	// Object and array parameters are kept as JSON
+	Call struct {
+		Method string
+		Params map[string]string
+		Files  map[string]UploadedFile
+	}
+
+	// UploadedFile file uploaded with multipart form
+	UploadedFile struct {
+		Name    string
+		Content []byte
+	}
+
+	storedFile struct {
+		telegraph.File
+		name    string
+		content []byte
+	}
+)
+
+// NewServer start fake bot api accepting request of bot with token
+func NewServer(token string) *Server {
+	server := &Server{
+		token:         token,
+		bot:           telegraph.User{ID: botID(token), IsBot: true, FirstName: "Test Bot", Username: "test_bot"},
+		chats:         map[int64]*telegraph.Chat{},
+		members:       map[int64]map[int64]*telegraph.ChatMember{},
+		messages:      map[int64][]*telegraph.Message{},
+		lastMessageID: map[int64]int64{},
+		files:         map[string]*storedFile{},
+		stickerSets:   map[string]*telegraph.StickerSet{},
+		scores:        map[string]map[int64]telegraph.GameHighScore{},
+		updateSignal:  make(chan struct{}),
+		failures:      map[string][]telegraph.APIError{},
+		closed:        make(chan struct{}),
+	}
+	server.server = httptest.NewServer(server)
+
+	return server
+}
+
+// URL base url of the server, use it with telegraph.WithBaseURL
+func (server *Server) URL() string {
+	return server.server.URL
+}
+
+// Close shut down the server and block until all request are done, pending long polling getUpdates return immediately
+func (server *Server) Close() {
+	server.closeOnce.Do(func() {
+		close(server.closed)
+	})
+	server.server.Close()
+}
+
+// Client create client sending request to the server which never retry failed request, options are applied after
+func (server *Server) Client(options ...telegraph.ClientOption) *telegraph.Client {
+	options = append([]telegraph.ClientOption{
+		telegraph.WithBaseURL(server.URL()),
+		telegraph.WithRetryPolicy(telegraph.NoRetry),
+	}, options...)
+
+	return telegraph.NewClient(server.token, options...)
+}
+
+// Bot user of the bot returned by getMe
+func (server *Server) Bot() telegraph.User {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	return server.bot
+}
+
+// SetBot user of the bot returned by getMe
+func (server *Server) SetBot(bot telegraph.User) *Server {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	server.bot = bot
+	return server
+}
+
+// AddChat make chat known to the server, request to unknown chat fails with chat not found
+func (server *Server) AddChat(chat telegraph.Chat) *Server {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	server.chats[chat.ID] = &chat
+	if server.members[chat.ID] == nil {
+		server.members[chat.ID] = map[int64]*telegraph.ChatMember{}
+	}
+	return server
+}
+
+// AddMember add user to chat with status, the chat must be added first
+func (server *Server) AddMember(chatId int64, user telegraph.User, status telegraph.StatusType) *Server {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	if server.members[chatId] == nil {
+		server.members[chatId] = map[int64]*telegraph.ChatMember{}
+	}
+	server.members[chatId][user.ID] = &telegraph.ChatMember{User: &user, Status: status}
+	return server
+}
+
+// Chat current state of chat, false if chat is unknown
+func (server *Server) Chat(chatId int64) (telegraph.Chat, bool) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	chat, ok := server.chats[chatId]
+	if !ok {
+		return telegraph.Chat{}, false
+	}
+	return *chat, true
+}
+
+// Member current state of chat member, false if user is not a member of chat
+func (server *Server) Member(chatId, userId int64) (telegraph.ChatMember, bool) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	member, ok := server.members[chatId][userId]
+	if !ok {
+		return telegraph.ChatMember{}, false
+	}
+	return *member, true
+}
+
+// AddFile store file content which can be sent by file id, fetched with getFile and downloaded
+func (server *Server) AddFile(name string, content []byte) telegraph.File {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	return server.storeFile(name, content).File
+}
+
+// Messages messages in chat sent by the bot or pushed as update, in order
+func (server *Server) Messages(chatId int64) []telegraph.Message {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	messages := []telegraph.Message{}
+	for _, message := range server.messages[chatId] {
+		messages = append(messages, *message)
+	}
+	return messages
+}
+
+// StickerSet current state of sticker set, false if set doesn't exist
+func (server *Server) StickerSet(name string) (telegraph.StickerSet, bool) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	set, ok := server.stickerSets[name]
+	if !ok {
+		return telegraph.StickerSet{}, false
+	}
+	return *set, true
+}
+
+// Webhook current web hook, pending update count is the number of updates not yet received
+func (server *Server) Webhook() telegraph.WebhookInfo {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	webhook := server.webhook
+	webhook.PendingUpdateCount = len(server.updates)
+	return webhook
+}
+
+/*
+PushUpdate queue incoming update to be received with getUpdates, update id is assigned when it's zero.
+Message of the update is stored in its chat, so the bot can reply to it, edit or forward it
+*/
+func (server *Server) PushUpdate(update telegraph.Update) telegraph.Update {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	if update.UpdateID == 0 {
+		update.UpdateID = server.lastUpdateID + 1
+	}
+	if update.UpdateID > server.lastUpdateID {
+		server.lastUpdateID = update.UpdateID
+	}
+	for _, message := range []*telegraph.Message{update.Message, update.ChannelPost} {
+		if message == nil {
+			continue
+		}
+		if message.MessageID == 0 {
+			message.MessageID = server.lastMessageID[message.Chat.ID] + 1
+		}
+		if message.MessageID > server.lastMessageID[message.Chat.ID] {
+			server.lastMessageID[message.Chat.ID] = message.MessageID
+		}
+		if message.Date == 0 {
+			message.Date = time.Now().Unix()
+		}
+		stored := *message
+		server.messages[message.Chat.ID] = append(server.messages[message.Chat.ID], &stored)
+	}
+
+	server.updates = append(server.updates, update)
+	close(server.updateSignal)
+	server.updateSignal = make(chan struct{})
+
+	return update
+}
+
+// PushMessage queue message update with text sent by user in chat, the chat must be added first
+func (server *Server) PushMessage(chatId int64, from telegraph.User, text string) telegraph.Update {
+	chat, _ := server.Chat(chatId)
+	return server.PushUpdate(telegraph.Update{Message: &telegraph.Message{From: &from, Chat: chat, Text: text}})
+}
+
+// FailNext make the next call of method fail with err, failures of the same method are used in order
+func (server *Server) FailNext(method string, err telegraph.APIError) *Server {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	method = strings.ToLower(method)
+	server.failures[method] = append(server.failures[method], err)
+	return server
+}
+
+// Calls every call received by the server, in order
+func (server *Server) Calls() []Call {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	return append([]Call{}, server.calls...)
+}
+
+// CallsTo calls of method received by the server, method name is case insensitive
+func (server *Server) CallsTo(method string) []Call {
+	calls := []Call{}
+	for _, call := range server.Calls() {
+		if strings.EqualFold(call.Method, method) {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// LastCall the latest call received by the server, false if there is no call
+func (server *Server) LastCall() (Call, bool) {
+	calls := server.Calls()
+	if len(calls) == 0 {
+		return Call{}, false
+	}
+	return calls[len(calls)-1], true
+}
+
+// Int64 integer parameter, zero if it's missing or not an integer
+func (call Call) Int64(name string) int64 {
+	value, _ := strconv.ParseInt(call.Params[name], 10, 64)
+	return value
+}
+
+// Float64 number parameter, zero if it's missing or not a number
+func (call Call) Float64(name string) float64 {
+	value, _ := strconv.ParseFloat(call.Params[name], 64)
+	return value
+}
+
+// Bool boolean parameter, false if it's missing
+func (call Call) Bool(name string) bool {
+	value, _ := strconv.ParseBool(call.Params[name])
+	return value
+}
+
+// Decode JSON parameter such as reply_markup into v
+func (call Call) Decode(name string, v interface{}) error {
+	return json.Unmarshal([]byte(call.Params[name]), v)
+}
+
+// ServeHTTP answer bot api and file download request
+func (server *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if path := "/file/bot" + server.token + "/"; strings.HasPrefix(r.URL.Path, path) {
+		server.serveFile(w, r, strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, path), "test/"))
+		return
+	}
+
+	prefix := "/bot" + server.token + "/"
+	if !strings.HasPrefix(r.URL.Path, prefix) {
+		writeError(w, &telegraph.APIError{ErrorCode: http.StatusUnauthorized, Description: "Unauthorized"})
+		return
+	}
+	method := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, prefix), "test/")
+
+	call, err := parseCall(method, r)
+	if err != nil {
+		writeError(w, badRequest(err.Error()))
+		return
+	}
+
+	server.mutex.Lock()
+	server.calls = append(server.calls, call)
+	failure, failed := server.nextFailure(method)
+	server.mutex.Unlock()
+
+	if failed {
+		writeError(w, &failure)
+		return
+	}
+
+	handler, ok := methods[strings.ToLower(method)]
+	if !ok {
+		writeError(w, &telegraph.APIError{ErrorCode: http.StatusNotFound, Description: "Not Found"})
+		return
+	}
+
+	result, apiErr := handler(server, r, call)
+	if apiErr != nil {
+		writeError(w, apiErr)
+		return
+	}
+	writeResult(w, result)
+}
+
+// serveFile serve file content by file path, range request is supported
+func (server *Server) serveFile(w http.ResponseWriter, r *http.Request, path string) {
+	server.mutex.Lock()
+	var found *storedFile
+	for _, file := range server.files {
+		if file.FilePath == path {
+			found = file
+			break
+		}
+	}
+	server.mutex.Unlock()
+
+	if found == nil {
+		writeError(w, &telegraph.APIError{ErrorCode: http.StatusNotFound, Description: "Not Found"})
+		return
+	}
+	http.ServeContent(w, r, found.name, time.Time{}, bytes.NewReader(found.content))
+}
+
+// nextFailure pop failure queued for method
+func (server *Server) nextFailure(method string) (telegraph.APIError, bool) {
+	method = strings.ToLower(method)
+	failures := server.failures[method]
+	if len(failures) == 0 {
+		return telegraph.APIError{}, false
+	}
+	server.failures[method] = failures[1:]
+	return failures[0], true
+}
+
+// storeFile save content with new file id and path
+func (server *Server) storeFile(name string, content []byte) *storedFile {
+	server.lastFileID++
+	file := &storedFile{
+		File: telegraph.File{
+			FileID:   fmt.Sprintf("file-%d", server.lastFileID),
+			FileSize: len(content),
+			FilePath: fmt.Sprintf("documents/file_%d", server.lastFileID),
+		},
+		name:    name,
+		content: content,
+	}
+	server.files[file.FileID] = file
+
+	return file
+}
+
+// parseCall merge parameters from query, JSON body, url encoded form and multipart form
+func parseCall(method string, r *http.Request) (Call, error) {
+	call := Call{Method: method, Params: map[string]string{}, Files: map[string]UploadedFile{}}
+	for name, values := range r.URL.Query() {
+		call.Params[name] = values[0]
+	}
+
+	mediaType, params, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case "application/json":
+		body := map[string]json.RawMessage{}
+		decoder := json.NewDecoder(r.Body)
+		decoder.UseNumber()
+		if err := decoder.Decode(&body); err != nil && err != io.EOF {
+			return call, fmt.Errorf("can't parse JSON body: %v", err)
+		}
+		for name, value := range body {
+			call.Params[name] = rawString(value)
+		}
+
+	case "application/x-www-form-urlencoded":
+		raw, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return call, err
+		}
+		form, err := url.ParseQuery(string(raw))
+		if err != nil {
+			return call, fmt.Errorf("can't parse form: %v", err)
+		}
+		for name, values := range form {
+			call.Params[name] = values[0]
+		}
+
+	case "multipart/form-data":
+		reader := multipart.NewReader(r.Body, params["boundary"])
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return call, fmt.Errorf("can't parse multipart form: %v", err)
+			}
+			content, err := ioutil.ReadAll(part)
+			if err != nil {
+				return call, fmt.Errorf("can't read multipart form: %v", err)
+			}
+			if part.FileName() != "" {
+				call.Files[part.FormName()] = UploadedFile{Name: part.FileName(), Content: content}
+			} else {
+				call.Params[part.FormName()] = string(content)
+			}
+		}
+	}
+
+	return call, nil
+}
+
+// rawString JSON string value is unquoted, other value is kept as JSON
+func rawString(value json.RawMessage) string {
+	var text string
+	if err := json.Unmarshal(value, &text); err == nil {
+		return text
+	}
+	return string(value)
+}
+
+func writeResult(w http.ResponseWriter, result interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "result": result})
+}
+
+func writeError(w http.ResponseWriter, err *telegraph.APIError) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(err.ErrorCode)
+	json.NewEncoder(w).Encode(telegraph.ErrorResponse{
+		OK:          false,
+		ErrorCode:   err.ErrorCode,
+		Description: err.Description,
+		Parameters:  err.Parameters,
+	})
+}
+
+func badRequest(description string) *telegraph.APIError {
+	return &telegraph.APIError{ErrorCode: http.StatusBadRequest, Description: "Bad Request: " + description}
+}
+
+// botID bot user id is the part of token before colon
+func botID(token string) int64 {
+	id, _ := strconv.ParseInt(strings.SplitN(token, ":", 2)[0], 10, 64)
+	return id
+}
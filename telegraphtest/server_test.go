@@ -0,0 +1,335 @@
+package telegraphtest_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"telegraph"
+	"telegraph/telegraphtest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const token = "123456:token"
+
+func newServer() *telegraphtest.Server {
+	server := telegraphtest.NewServer(token)
+	server.AddChat(telegraph.Chat{ID: 42, Type: telegraph.ChatTypePrivate, FirstName: "Jane"})
+	server.AddChat(telegraph.Chat{ID: -100, Type: telegraph.ChatTypeSuperGroup, Title: "Cube", Username: "cubegroup"})
+	return server
+}
+
+func TestServer_SendMessage(t *testing.T) {
+	server := newServer()
+	defer server.Close()
+	client := server.Client()
+
+	me, _, err := client.GetMe().Commit()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(123456), me.ID)
+
+	first, _, err := client.SendMessage(42, "hello").SetParseMode("HTML").Commit()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), first.MessageID)
+	assert.Equal(t, "hello", first.Text)
+	assert.Equal(t, int64(42), first.Chat.ID)
+
+	reply, _, err := client.SendMessage("@cubegroup", "hi").Commit()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(-100), reply.Chat.ID)
+
+	second, _, err := client.SendMessage(42, "again").SetReplyToMessageID(first.MessageID).Commit()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), second.MessageID)
+	assert.Equal(t, "hello", second.ReplyToMessage.Text)
+
+	_, _, err = client.SendMessage(7, "nobody").Commit()
+	apiErr := &telegraph.APIError{}
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, "Bad Request: chat not found", apiErr.Description)
+
+	calls := server.CallsTo("sendMessage")
+	assert.Len(t, calls, 4)
+	assert.Equal(t, "HTML", calls[0].Params["parse_mode"])
+	assert.Equal(t, int64(42), calls[0].Int64("chat_id"))
+	assert.Len(t, server.Messages(42), 2)
+}
+
+func TestServer_EditDeleteForward(t *testing.T) {
+	server := newServer()
+	defer server.Close()
+	client := server.Client()
+
+	message, _, _ := client.SendMessage(42, "draft").Commit()
+
+	_, _, err := client.EditMessageText("final").SetChatID(42).SetMessageID(int(message.MessageID)).Commit()
+	assert.NoError(t, err)
+	assert.Equal(t, "final", server.Messages(42)[0].Text)
+
+	_, _, err = client.EditMessageText("final").SetChatID(42).SetMessageID(int(message.MessageID)).Commit()
+	assert.True(t, telegraph.IsBadRequest(err))
+
+	forwarded, _, err := client.ForwardMessage(-100, 42, int(message.MessageID)).Commit()
+	assert.NoError(t, err)
+	assert.Equal(t, message.MessageID, forwarded.ForwardFromMessageID)
+	assert.Equal(t, "final", forwarded.Text)
+
+	_, _, err = client.DeleteMessage(42, message.MessageID).Commit()
+	assert.NoError(t, err)
+	_, _, err = client.DeleteMessage(42, message.MessageID).Commit()
+	assert.True(t, telegraph.IsBadRequest(err))
+	assert.Empty(t, server.Messages(42))
+}
+
+func TestServer_UploadDownload(t *testing.T) {
+	server := newServer()
+	defer server.Close()
+	client := server.Client()
+
+	message, _, err := client.SendDocument(42, telegraph.FileBytes("report.txt", []byte("report content"))).
+		SetCaption("report").Commit()
+	assert.NoError(t, err)
+	assert.Equal(t, "report.txt", message.Document.FileName)
+	assert.Equal(t, "report", message.Caption)
+
+	call, _ := server.LastCall()
+	assert.Equal(t, []byte("report content"), call.Files["document"].Content)
+
+	buffer := &bytes.Buffer{}
+	file, _, err := client.DownloadFile(message.Document.FileID, buffer).Commit()
+	assert.NoError(t, err)
+	assert.Equal(t, len("report content"), file.FileSize)
+	assert.Equal(t, "report content", buffer.String())
+
+	resent, _, err := client.SendPhoto(-100, telegraph.FileID(server.AddFile("photo.png", []byte("png")).FileID)).Commit()
+	assert.NoError(t, err)
+	assert.Len(t, resent.Photos, 1)
+
+	_, _, err = client.SendPhoto(-100, telegraph.FileID("unknown")).Commit()
+	assert.True(t, telegraph.IsBadRequest(err))
+}
+
+func TestServer_TestEnvironment(t *testing.T) {
+	server := newServer()
+	defer server.Close()
+	client := server.Client(telegraph.WithTestEnvironment())
+
+	file := server.AddFile("report.txt", []byte("report content"))
+	buffer := &bytes.Buffer{}
+	_, _, err := client.DownloadFile(file.FileID, buffer).Commit()
+	assert.NoError(t, err)
+	assert.Equal(t, "report content", buffer.String())
+}
+
+func TestServer_MalformedMultipart(t *testing.T) {
+	server := newServer()
+	defer server.Close()
+
+	body := strings.NewReader("--boundary\r\nContent-Disposition: form-data; name=\"chat_id\"\r\n\r\n42")
+	res, err := http.Post(server.URL()+"/bot"+token+"/sendDocument", "multipart/form-data; boundary=boundary", body)
+	assert.NoError(t, err)
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	assert.Empty(t, server.Calls())
+}
+
+func TestServer_GetUpdates(t *testing.T) {
+	server := newServer()
+	defer server.Close()
+	client := server.Client()
+
+	server.PushMessage(42, telegraph.User{ID: 42, FirstName: "Jane"}, "/start")
+	server.PushMessage(42, telegraph.User{ID: 42, FirstName: "Jane"}, "hello")
+
+	updates, _, err := client.GetUpdates().Commit()
+	assert.NoError(t, err)
+	assert.Len(t, updates, 2)
+	assert.Equal(t, "/start", updates[0].Message.Text)
+	assert.Equal(t, 2, server.Webhook().PendingUpdateCount)
+
+	updates, _, err = client.GetUpdates().SetOffset(int(updates[1].UpdateID + 1)).Commit()
+	assert.NoError(t, err)
+	assert.Empty(t, updates)
+	assert.Equal(t, 0, server.Webhook().PendingUpdateCount)
+
+	reply, _, err := client.SendMessage(42, "welcome").SetReplyToMessageID(1).Commit()
+	assert.NoError(t, err)
+	assert.Equal(t, "/start", reply.ReplyToMessage.Text)
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		server.PushMessage(42, telegraph.User{ID: 42}, "late")
+	}()
+	start := time.Now()
+	updates, _, err = client.GetUpdates().SetTimeout(5).Commit()
+	assert.NoError(t, err)
+	assert.Len(t, updates, 1)
+	assert.True(t, time.Since(start) < 5*time.Second)
+}
+
+func TestServer_Polling(t *testing.T) {
+	server := newServer()
+	client := server.Client()
+
+	server.PushMessage(42, telegraph.User{ID: 42}, "ping")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	received := make(chan string, 1)
+	go client.StartPolling(ctx, telegraph.HandlerFunc(func(ctx context.Context, update *telegraph.Update) {
+		received <- update.Message.Text
+	}))
+
+	select {
+	case text := <-received:
+		assert.Equal(t, "ping", text)
+	case <-time.After(5 * time.Second):
+		t.Fatal("update is not received")
+	}
+
+	cancel()
+	server.Close()
+}
+
+func TestServer_Webhook(t *testing.T) {
+	server := newServer()
+	defer server.Close()
+	client := server.Client()
+
+	_, _, err := client.SetWebHook("http://example.com/hook").Commit()
+	assert.True(t, telegraph.IsBadRequest(err))
+
+	_, _, err = client.SetWebHook("https://example.com/hook").SetMaxConnection(10).SetAllowedUpdates("message").Commit()
+	assert.NoError(t, err)
+
+	info, _, err := client.GetWebHookInfo().Commit()
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com/hook", info.URL)
+	assert.Equal(t, 10, info.MaxConnections)
+	assert.Equal(t, []string{"message"}, info.AllowedUpdates)
+
+	_, _, err = client.GetUpdates().Commit()
+	apiErr := &telegraph.APIError{}
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusConflict, apiErr.ErrorCode)
+
+	_, _, err = client.DeleteWebHook().Commit()
+	assert.NoError(t, err)
+	assert.Equal(t, "", server.Webhook().URL)
+}
+
+func TestServer_Chat(t *testing.T) {
+	server := newServer()
+	defer server.Close()
+	client := server.Client()
+
+	server.AddMember(-100, telegraph.User{ID: 1, FirstName: "Owner"}, telegraph.StatusTypeCreator)
+	server.AddMember(-100, telegraph.User{ID: 2, FirstName: "Member"}, telegraph.StatusTypeMember)
+
+	_, _, err := client.SetChatTitle(-100, "Cube Team").Commit()
+	assert.NoError(t, err)
+	chat, _, err := client.GetChat(-100).Commit()
+	assert.NoError(t, err)
+	assert.Equal(t, "Cube Team", chat.Title)
+
+	_, _, err = client.SetChatTitle(42, "private").Commit()
+	assert.True(t, telegraph.IsBadRequest(err))
+
+	_, _, err = client.PromoteChatMember(-100, 2).SetCanPinMessage(true).Commit()
+	assert.NoError(t, err)
+	administrators, _, err := client.GetChatAdministrator(-100).Commit()
+	assert.NoError(t, err)
+	assert.Len(t, administrators, 2)
+
+	_, _, err = client.KickChatMember(-100, 2).Commit()
+	assert.NoError(t, err)
+	member, _, err := client.GetChatMember(-100, 2).Commit()
+	assert.NoError(t, err)
+	assert.Equal(t, telegraph.StatusType(telegraph.StatusTypeKicked), member.Status)
+
+	count, _, err := client.GetChatMembersCount(-100).Commit()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), *count)
+
+	link, _, err := client.ExportChatInviteLink(-100).Commit()
+	assert.NoError(t, err)
+	assert.Contains(t, link, "https://t.me/joinchat/")
+}
+
+func TestServer_Sticker(t *testing.T) {
+	server := newServer()
+	defer server.Close()
+	client := server.Client()
+
+	_, _, err := client.CreateNewStickerSet(42, "cube", "Cube", telegraph.FileBytes("a.png", []byte("a")), "😀").Commit()
+	assert.True(t, telegraph.IsBadRequest(err))
+
+	_, _, err = client.CreateNewStickerSet(42, "cube_by_test_bot", "Cube", telegraph.FileBytes("a.png", []byte("a")), "😀").Commit()
+	assert.NoError(t, err)
+	_, _, err = client.AddStickerToSet(42, "cube_by_test_bot", telegraph.FileBytes("b.png", []byte("b")), "😎").Commit()
+	assert.NoError(t, err)
+
+	set, _, err := client.GetStickerSet("cube_by_test_bot").Commit()
+	assert.NoError(t, err)
+	assert.Len(t, set.Stickers, 2)
+
+	_, _, err = client.SetStickerPositionInSet(set.Stickers[1].FileID, 0).Commit()
+	assert.NoError(t, err)
+	_, _, err = client.DeleteStickerFromSet(set.Stickers[0].FileID).Commit()
+	assert.NoError(t, err)
+
+	stored, _ := server.StickerSet("cube_by_test_bot")
+	assert.Len(t, stored.Stickers, 1)
+	assert.Equal(t, "😎", stored.Stickers[0].Emoji)
+}
+
+func TestServer_Game(t *testing.T) {
+	server := newServer()
+	defer server.Close()
+	client := server.Client()
+
+	message, _, err := client.SendGame(-100, "cube").Commit()
+	assert.NoError(t, err)
+	assert.Equal(t, "cube", message.Game.Title)
+
+	_, _, err = client.SetGameScore(1, 10).SetChatID(-100).SetMessageID(int(message.MessageID)).Commit()
+	assert.NoError(t, err)
+	_, _, err = client.SetGameScore(2, 20).SetChatID(-100).SetMessageID(int(message.MessageID)).Commit()
+	assert.NoError(t, err)
+	_, _, err = client.SetGameScore(2, 5).SetChatID(-100).SetMessageID(int(message.MessageID)).Commit()
+	assert.True(t, telegraph.IsBadRequest(err))
+
+	scores, _, err := client.GetGameHighScores(1).SetChatID(-100).SetMessageID(message.MessageID).Commit()
+	assert.NoError(t, err)
+	assert.Len(t, scores, 2)
+	assert.Equal(t, int64(2), scores[0].User.ID)
+	assert.Equal(t, 1, scores[0].Position)
+}
+
+func TestServer_Failure(t *testing.T) {
+	server := newServer()
+	defer server.Close()
+	client := server.Client()
+
+	server.FailNext("sendMessage", telegraph.APIError{
+		ErrorCode:   http.StatusTooManyRequests,
+		Description: "Too Many Requests: retry after 3",
+		Parameters:  &telegraph.ResponseParameters{RetryAfter: 3},
+	})
+
+	_, _, err := client.SendMessage(42, "flood").Commit()
+	apiErr := &telegraph.APIError{}
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, 3*time.Second, apiErr.RetryAfter())
+
+	_, _, err = client.SendMessage(42, "flood").Commit()
+	assert.NoError(t, err)
+
+	_, _, err = telegraph.NewClient("other:token", telegraph.WithBaseURL(server.URL()), telegraph.WithRetryPolicy(telegraph.NoRetry)).
+		GetMe().Commit()
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusUnauthorized, apiErr.ErrorCode)
+}
@@ -1,21 +1,23 @@
 package telegraph
 
 import (
+	"context"
 	"encoding/json"
 
 	"fmt"
 
 	"net/http"
+	"net/url"
 
-	"github.com/cenkalti/backoff"
 	"github.com/parnurzeal/gorequest"
 )
 
 type (
 	// ArrayUpdateResponse struct to handle request and response from telegram api with array update
 	ArrayUpdateResponse struct {
-		Client  *Client
-		Request *gorequest.SuperAgent
+		Client      *Client
+		Request     *gorequest.SuperAgent
+		retryPolicy RetryPolicy
 	}
 )
 
@@ -30,6 +32,73 @@ func WebHookParseRequest(r []byte) (*Update, error) {
 	return update, nil
 }
 
+// Type kind of the update, which is also the name used in allowed updates. Empty if update is unknown
+func (update *Update) Type() UpdateType {
+	switch {
+	case update.Message != nil:
+		return UpdateTypeMessage
+	case update.EditedMessage != nil:
+		return UpdateTypeEditedMessage
+	case update.ChannelPost != nil:
+		return UpdateTypeChannelPost
+	case update.EditedChannelPost != nil:
+		return UpdateTypeEditedChannelPost
+	case update.InlineQuery != nil:
+		return UpdateTypeInlineQuery
+	case update.ChosenInlineResult != nil:
+		return UpdateTypeChosenInlineResult
+	case update.CallbackQuery != nil:
+		return UpdateTypeCallbackQuery
+	case update.ShippingQuery != nil:
+		return UpdateTypeShippingQuery
+	case update.PreCheckoutQuery != nil:
+		return UpdateTypePreCheckoutQuery
+	}
+	return ""
+}
+
+// Chat chat where the update happened, nil if the update is not bound to a chat such as inline query
+func (update *Update) Chat() *Chat {
+	switch {
+	case update.Message != nil:
+		return &update.Message.Chat
+	case update.EditedMessage != nil:
+		return &update.EditedMessage.Chat
+	case update.ChannelPost != nil:
+		return &update.ChannelPost.Chat
+	case update.EditedChannelPost != nil:
+		return &update.EditedChannelPost.Chat
+	case update.CallbackQuery != nil && update.CallbackQuery.Message != nil:
+		return &update.CallbackQuery.Message.Chat
+	}
+	return nil
+}
+
+// From user who caused the update, nil for channel post
+func (update *Update) From() *User {
+	switch {
+	case update.Message != nil:
+		return update.Message.From
+	case update.EditedMessage != nil:
+		return update.EditedMessage.From
+	case update.ChannelPost != nil:
+		return update.ChannelPost.From
+	case update.EditedChannelPost != nil:
+		return update.EditedChannelPost.From
+	case update.InlineQuery != nil:
+		return &update.InlineQuery.From
+	case update.ChosenInlineResult != nil:
+		return &update.ChosenInlineResult.From
+	case update.CallbackQuery != nil:
+		return &update.CallbackQuery.From
+	case update.ShippingQuery != nil:
+		return &update.ShippingQuery.From
+	case update.PreCheckoutQuery != nil:
+		return &update.PreCheckoutQuery.From
+	}
+	return nil
+}
+
 /*
 GetUpdates Use this method to receive incoming updates using long polling (wiki). An Array of Update objects is returned.
 Notes
@@ -44,7 +113,7 @@ Available method can used with this method
 */
 func (client *Client) GetUpdates() *ArrayUpdateResponse {
 	url := client.baseURL + fmt.Sprintf(EndpointGetUpdate, client.accessToken)
-	request := gorequest.New().Get(url).Set(UserAgentHeader, UserAgent+"/"+Version)
+	request := gorequest.New().Get(url).Set(UserAgentHeader, client.userAgent)
 
 	return &ArrayUpdateResponse{
 		Client:  client,
@@ -88,32 +157,35 @@ Please note that this parameter doesn't affect updates created before the call t
 so unwanted updates may be received for a short period of time.
 */
 func (update *ArrayUpdateResponse) SetAllowedUpdates(updates ...string) *ArrayUpdateResponse {
-	update.Request = update.Request.Query(fmt.Sprintf("allowed_updates=%v", updates))
+	if updates == nil {
+		updates = []string{}
+	}
+	allowed, _ := json.Marshal(updates)
+	update.Request = update.Request.Query(url.Values{"allowed_updates": {string(allowed)}}.Encode())
+	return update
+}
+
+// SetRetryPolicy decide how this request is retried when it fails, override retry policy of the client
+func (update *ArrayUpdateResponse) SetRetryPolicy(policy RetryPolicy) *ArrayUpdateResponse {
+	update.retryPolicy = policy
+
 	return update
 }
 
 // Commit request to telegram api
 func (update *ArrayUpdateResponse) Commit() ([]Update, *http.Response, error) {
-	var errs []error
-	res := &http.Response{}
+	return update.CommitContext(context.Background())
+}
+
+// CommitContext request to telegram api, the request and its back off retry are aborted when ctx is done
+func (update *ArrayUpdateResponse) CommitContext(ctx context.Context) ([]Update, *http.Response, error) {
 	model := struct {
-		ErrorResponse
 		Result []Update `json:"result,omitempty"`
 	}{}
 
-	operation := func() error {
-		res, _, errs = update.Request.EndStruct(&model)
-		if len(errs) > 0 {
-			return errs[0]
-		}
-		return nil
-	}
-
-	if err := backoff.Retry(operation, update.Client.expBackOff); err != nil {
-		return nil, MakeHTTPResponse(update.Request), err
-	}
-	if res.StatusCode != http.StatusOK {
-		return nil, res, fmt.Errorf("%v %v", model.ErrorCode, model.Description)
+	_, res, err := update.Client.commit(ctx, update.Request, update.retryPolicy, &model)
+	if err != nil {
+		return nil, res, err
 	}
 
 	return model.Result, res, nil
@@ -94,7 +94,7 @@ func TestGetUpdates_Error(t *testing.T) {
 	gock.New(telegraph.BaseURL).Head(fmt.Sprintf(telegraph.EndpointGetUpdate, "token")).Reply(http.StatusInternalServerError).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 
 	model, res, err := client.GetUpdates().SetOffset(5).SetLimit(5).SetTimeout(5).SetAllowedUpdates("1", "2").Commit()
 
@@ -111,7 +111,7 @@ func TestGetUpdates_Failed(t *testing.T) {
 	}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 
 	model, res, err := client.GetUpdates().Commit()
 
@@ -119,3 +119,28 @@ func TestGetUpdates_Failed(t *testing.T) {
 	assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
 	assert.Error(t, err)
 }
+
+func TestUpdate_TypeChatFrom(t *testing.T) {
+	user := telegraph.User{ID: 234234, FirstName: "Dimas"}
+	message := &telegraph.Message{MessageID: 19, From: &user, Chat: telegraph.Chat{ID: 23423423, Type: telegraph.ChatTypePrivate}}
+
+	update := &telegraph.Update{UpdateID: 1, Message: message}
+	assert.Equal(t, telegraph.UpdateTypeMessage, update.Type())
+	assert.Equal(t, int64(23423423), update.Chat().ID)
+	assert.Equal(t, int64(234234), update.From().ID)
+
+	update = &telegraph.Update{UpdateID: 2, CallbackQuery: &telegraph.CallbackQuery{ID: "1", From: user, Message: message}}
+	assert.Equal(t, telegraph.UpdateTypeCallbackQuery, update.Type())
+	assert.Equal(t, int64(23423423), update.Chat().ID)
+	assert.Equal(t, int64(234234), update.From().ID)
+
+	update = &telegraph.Update{UpdateID: 3, InlineQuery: &telegraph.InlineQuery{ID: "1", From: user}}
+	assert.Equal(t, telegraph.UpdateTypeInlineQuery, update.Type())
+	assert.Nil(t, update.Chat())
+	assert.Equal(t, int64(234234), update.From().ID)
+
+	update = &telegraph.Update{UpdateID: 4}
+	assert.Equal(t, telegraph.UpdateType(""), update.Type())
+	assert.Nil(t, update.Chat())
+	assert.Nil(t, update.From())
+}
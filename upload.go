@@ -0,0 +1,218 @@
+package telegraph
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/parnurzeal/gorequest"
+)
+
+// uploadFiles files in request data which are uploaded
+func uploadFiles(agent *gorequest.SuperAgent) map[string]*InputFile {
+	var files map[string]*InputFile
+	for field, value := range agent.Data {
+		if file, ok := value.(*InputFile); ok {
+			if files == nil {
+				files = map[string]*InputFile{}
+			}
+			files[field] = file
+		}
+	}
+
+	return files
+}
+
+// checkUploads check size of uploaded files against client upload limit before anything is sent,
+// photo uses photo upload limit
+func (client *Client) checkUploads(agent *gorequest.SuperAgent) error {
+	for field, file := range uploadFiles(agent) {
+		if err := file.check(field, client.uploadLimitOf(field)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// uploadLimitOf upload limit of file sent as field, zero means unlimited
+func (client *Client) uploadLimitOf(field string) int64 {
+	if field == "photo" {
+		return client.photoUploadLimit
+	}
+	return client.uploadLimit
+}
+
+// repeatableUploads report whether uploaded files of the request can be sent again by retry
+func repeatableUploads(agent *gorequest.SuperAgent) bool {
+	for _, file := range uploadFiles(agent) {
+		if !file.repeatable() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// makeMultipartRequest build request which body is multipart form streamed from files through a pipe,
+// other request data is sent as form fields with object encoded as JSON.
+// File longer than limit of its field stops the stream, the reason is kept in multipartBody
+func makeMultipartRequest(agent *gorequest.SuperAgent, files map[string]*InputFile, limit func(field string) int64) (*http.Request, error) {
+	fields := map[string]interface{}{}
+	for field, value := range agent.Data {
+		if _, ok := files[field]; !ok {
+			fields[field] = value
+		}
+	}
+
+	base := *agent
+	base.Data = nil
+	base.FileData = nil
+	base.TargetType = gorequest.TypeJSON
+	base.ForceType = ""
+	req, err := base.MakeRequest()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(files))
+	for field := range files {
+		names = append(names, field)
+	}
+	sort.Strings(names)
+
+	contents := make([]io.ReadCloser, 0, len(names))
+	sizes := make([]int64, 0, len(names))
+	for _, field := range names {
+		content, size, err := files[field].open()
+		if err != nil {
+			for _, content := range contents {
+				content.Close()
+			}
+			return nil, err
+		}
+		contents = append(contents, content)
+		sizes = append(sizes, size)
+	}
+
+	reader, writer := io.Pipe()
+	form := multipart.NewWriter(writer)
+	body := &multipartBody{PipeReader: reader, done: make(chan struct{})}
+	go func() {
+		defer close(body.done)
+		defer func() {
+			for _, content := range contents {
+				content.Close()
+			}
+		}()
+
+		err := writeFields(form, fields)
+		for i := 0; err == nil && i < len(names); i++ {
+			err = writeFile(form, names[i], files[names[i]], contents[i], sizes[i], limit(names[i]))
+		}
+		if err == nil {
+			err = form.Close()
+		}
+		body.err = err
+		writer.CloseWithError(err)
+	}()
+
+	req.Body = body
+	req.GetBody = nil
+	req.ContentLength = -1
+	req.Header.Set("Content-Type", form.FormDataContentType())
+
+	return req, nil
+}
+
+// multipartBody request body streamed from files, Close wait until files are no longer read
+// so whether the upload can be repeated and why the stream stopped is known once the request is done
+type multipartBody struct {
+	*io.PipeReader
+	done chan struct{}
+	err  error
+}
+
+func (body *multipartBody) Close() error {
+	err := body.PipeReader.Close()
+	<-body.done
+	return err
+}
+
+func writeFields(form *multipart.Writer, fields map[string]interface{}) error {
+	names := make([]string, 0, len(fields))
+	for field := range fields {
+		names = append(names, field)
+	}
+	sort.Strings(names)
+
+	for _, field := range names {
+		value, err := formValue(fields[field])
+		if err != nil {
+			return err
+		}
+		if err := form.WriteField(field, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeFile(form *multipart.Writer, field string, file *InputFile, content io.Reader, size, limit int64) error {
+	part, err := form.CreateFormFile(field, file.name)
+	if err != nil {
+		return err
+	}
+
+	if limit > 0 {
+		content = io.LimitReader(content, limit+1)
+	}
+	sent, err := io.Copy(part, &progressReader{reader: content, total: size, progress: file.progress})
+	if err != nil {
+		return err
+	}
+	if limit > 0 && sent > limit {
+		return fmt.Errorf("%w: %v exceeds limit %d bytes", ErrFileTooLarge, field, limit)
+	}
+
+	return nil
+}
+
+// formValue encode request data as form value, the same way telegram decodes it
+func formValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case json.Number:
+		return string(v), nil
+	}
+
+	body, err := json.Marshal(value)
+	return string(body), err
+}
+
+// progressReader report bytes read from reader to progress
+type progressReader struct {
+	reader   io.Reader
+	sent     int64
+	total    int64
+	progress func(sent, total int64)
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if n > 0 && r.progress != nil {
+		r.sent += int64(n)
+		r.progress(r.sent, r.total)
+	}
+	return n, err
+}
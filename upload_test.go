@@ -0,0 +1,109 @@
+package telegraph_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"telegraph"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpload_Progress(t *testing.T) {
+	var req *http.Request
+	var body []byte
+	client := telegraph.NewClient("token", telegraph.WithTransport(captureTransport(&req, &body)))
+
+	content := bytes.Repeat([]byte("a"), 100*1024)
+	var sent, total int64
+	calls := 0
+	file := telegraph.FileBytes("video.mp4", content).SetProgress(func(s, t int64) {
+		sent, total = s, t
+		calls++
+	})
+
+	_, _, err := client.SendVideo(2434234, file).SetReplyKeyboardRemove(telegraph.ReplyKeyboardRemove{RemoveKeyboard: true}).Commit()
+	assert.NoError(t, err)
+	assert.True(t, calls > 1)
+	assert.Equal(t, int64(len(content)), sent)
+	assert.Equal(t, int64(len(content)), total)
+
+	fields, files := multipartFiles(t, req, body)
+	assert.Equal(t, "video.mp4:"+string(content), files["video"])
+	assert.Equal(t, "2434234", fields["chat_id"])
+	assert.JSONEq(t, `{"remove_keyboard": true}`, fields["reply_markup"])
+}
+
+func TestUpload_PhotoSizePrecheck(t *testing.T) {
+	var req *http.Request
+	var body []byte
+	client := telegraph.NewClient("token", telegraph.WithTransport(captureTransport(&req, &body)))
+
+	photo := make([]byte, telegraph.MaxPhotoUploadSize+1)
+	_, _, err := client.SendPhoto(2434234, telegraph.FileBytes("large.png", photo)).Commit()
+
+	assert.True(t, errors.Is(err, telegraph.ErrFileTooLarge))
+	assert.Nil(t, req)
+
+	// The same file is allowed as document
+	_, _, err = client.SendDocument(2434234, telegraph.FileBytes("large.png", photo)).Commit()
+	assert.NoError(t, err)
+	assert.NotNil(t, req)
+}
+
+func TestUpload_ReaderSizePrecheck(t *testing.T) {
+	var req *http.Request
+	var body []byte
+	client := telegraph.NewClient("token", telegraph.WithTransport(captureTransport(&req, &body)),
+		telegraph.WithUploadLimit(10, 5))
+
+	reader := ioutil.NopCloser(strings.NewReader("more than ten bytes"))
+	_, _, err := client.SendDocument(2434234, telegraph.FileReader("doc.txt", reader).SetSize(19)).Commit()
+
+	assert.True(t, errors.Is(err, telegraph.ErrFileTooLarge))
+	assert.Nil(t, req)
+}
+
+func TestUpload_UnknownSizeExceedLimit(t *testing.T) {
+	var req *http.Request
+	var body []byte
+	client := telegraph.NewClient("token", telegraph.WithTransport(captureTransport(&req, &body)),
+		telegraph.WithUploadLimit(10, 5), telegraph.WithRetryPolicy(telegraph.RetryPolicyFunc(
+			func(attempt int, elapsed time.Duration, err error) (time.Duration, bool) {
+				return 0, attempt < 3
+			})))
+
+	reader := io.MultiReader(strings.NewReader("more than "), strings.NewReader("ten bytes"))
+	_, _, err := client.SendDocument(2434234, telegraph.FileReader("doc.txt", reader)).Commit()
+
+	assert.True(t, errors.Is(err, telegraph.ErrFileTooLarge))
+}
+
+func TestUpload_UnknownSizeExceedLimitOverNetwork(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ioutil.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok": true, "result": {"message_id": 1}}`))
+	}))
+	defer server.Close()
+
+	limited := telegraph.NewClient("token", telegraph.WithBaseURL(server.URL), telegraph.WithUploadLimit(10, 5),
+		telegraph.WithRetryPolicy(telegraph.NoRetry))
+	reader := io.MultiReader(strings.NewReader("more than "), strings.NewReader("ten bytes"))
+	_, _, err := limited.SendDocument(2434234, telegraph.FileReader("doc.txt", reader)).Commit()
+	assert.True(t, errors.Is(err, telegraph.ErrFileTooLarge))
+
+	// Limit of one client doesn't stick to the file sent by another client
+	file := telegraph.FilePath("./LICENSE")
+	_, _, err = telegraph.NewClient("token", telegraph.WithBaseURL(server.URL), telegraph.WithUploadLimit(0, 0)).
+		SendDocument(2434234, file).Commit()
+	assert.NoError(t, err)
+	_, _, err = limited.SendDocument(2434234, file).Commit()
+	assert.True(t, errors.Is(err, telegraph.ErrFileTooLarge))
+}
@@ -1,25 +1,27 @@
 package telegraph
 
 import (
+	"context"
 	"fmt"
 
 	"net/http"
 
-	"github.com/cenkalti/backoff"
 	"github.com/parnurzeal/gorequest"
 )
 
 type (
 	// UserResponse struct to handle request and response telegram api
 	UserResponse struct {
-		Client  *Client
-		Request *gorequest.SuperAgent
+		Client      *Client
+		Request     *gorequest.SuperAgent
+		retryPolicy RetryPolicy
 	}
 
 	// UserProfilePhotosResponse struct to handle request and response telegram api
 	UserProfilePhotosResponse struct {
-		Client  *Client
-		Request *gorequest.SuperAgent
+		Client      *Client
+		Request     *gorequest.SuperAgent
+		retryPolicy RetryPolicy
 	}
 )
 
@@ -29,7 +31,7 @@ Returns basic information about the bot in form of a User object.
 */
 func (client *Client) GetMe() *UserResponse {
 	url := client.baseURL + fmt.Sprintf(EndpointGetMe, client.accessToken)
-	request := gorequest.New().Get(url).Set(UserAgentHeader, UserAgent+"/"+Version)
+	request := gorequest.New().Get(url).Set(UserAgentHeader, client.userAgent)
 
 	return &UserResponse{
 		Client:  client,
@@ -37,28 +39,27 @@ func (client *Client) GetMe() *UserResponse {
 	}
 }
 
+// SetRetryPolicy decide how this request is retried when it fails, override retry policy of the client
+func (user *UserResponse) SetRetryPolicy(policy RetryPolicy) *UserResponse {
+	user.retryPolicy = policy
+
+	return user
+}
+
 // Commit execute request to telegram
 func (user *UserResponse) Commit() (*User, *http.Response, error) {
-	var errs []error
-	res := &http.Response{}
+	return user.CommitContext(context.Background())
+}
+
+// CommitContext execute request to telegram, the request and its back off retry are aborted when ctx is done
+func (user *UserResponse) CommitContext(ctx context.Context) (*User, *http.Response, error) {
 	model := struct {
-		ErrorResponse
 		Result *User `json:"result,omitempty"`
 	}{}
 
-	operation := func() error {
-		res, _, errs = user.Request.EndStruct(&model)
-		if len(errs) > 0 {
-			return errs[0]
-		}
-		return nil
-	}
-
-	if err := backoff.Retry(operation, user.Client.expBackOff); err != nil {
-		return nil, MakeHTTPResponse(user.Request), err
-	}
-	if res.StatusCode != http.StatusOK {
-		return nil, res, fmt.Errorf("%v %v", model.ErrorCode, model.Description)
+	_, res, err := user.Client.commit(ctx, user.Request, user.retryPolicy, &model)
+	if err != nil {
+		return nil, res, err
 	}
 
 	return model.Result, res, nil
@@ -74,7 +75,7 @@ Available method can used with this method
 */
 func (client *Client) GetUserProfilePhotos(userId int) *UserProfilePhotosResponse {
 	url := client.baseURL + fmt.Sprintf(EndpointGetUserProfilePhoto, client.accessToken)
-	request := gorequest.New().Get(url).Set(UserAgentHeader, UserAgent+"/"+Version).Query(fmt.Sprintf("user_id=%v", userId))
+	request := gorequest.New().Get(url).Set(UserAgentHeader, client.userAgent).Query(fmt.Sprintf("user_id=%v", userId))
 
 	return &UserProfilePhotosResponse{
 		Client:  client,
@@ -94,28 +95,27 @@ func (user *UserProfilePhotosResponse) SetLimit(limit int) *UserProfilePhotosRes
 	return user
 }
 
+// SetRetryPolicy decide how this request is retried when it fails, override retry policy of the client
+func (user *UserProfilePhotosResponse) SetRetryPolicy(policy RetryPolicy) *UserProfilePhotosResponse {
+	user.retryPolicy = policy
+
+	return user
+}
+
 // Commit execute request to telegram
 func (user *UserProfilePhotosResponse) Commit() (*UserProfilePhotos, *http.Response, error) {
-	var errs []error
-	res := &http.Response{}
+	return user.CommitContext(context.Background())
+}
+
+// CommitContext execute request to telegram, the request and its back off retry are aborted when ctx is done
+func (user *UserProfilePhotosResponse) CommitContext(ctx context.Context) (*UserProfilePhotos, *http.Response, error) {
 	model := struct {
-		ErrorResponse
 		Result *UserProfilePhotos `json:"result,omitempty"`
 	}{}
 
-	operation := func() error {
-		res, _, errs = user.Request.EndStruct(&model)
-		if len(errs) > 0 {
-			return errs[0]
-		}
-		return nil
-	}
-
-	if err := backoff.Retry(operation, user.Client.expBackOff); err != nil {
-		return nil, MakeHTTPResponse(user.Request), err
-	}
-	if res.StatusCode != http.StatusOK {
-		return nil, res, fmt.Errorf("%v %v", model.ErrorCode, model.Description)
+	_, res, err := user.Client.commit(ctx, user.Request, user.retryPolicy, &model)
+	if err != nil {
+		return nil, res, err
 	}
 
 	return model.Result, res, nil
@@ -35,7 +35,7 @@ func TestGetMe_Error(t *testing.T) {
 	gock.New(telegraph.BaseURL).Head(fmt.Sprintf(telegraph.EndpointGetMe, "token")).Reply(http.StatusInternalServerError).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 
 	user, res, err := client.GetMe().Commit()
 
@@ -52,7 +52,7 @@ func TestGetMe_Failed(t *testing.T) {
 	}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 
 	user, res, err := client.GetMe().Commit()
 
@@ -105,7 +105,7 @@ func TestGetUserProfilePhotos_Error(t *testing.T) {
 	gock.New(telegraph.BaseURL).Head(fmt.Sprintf(telegraph.EndpointGetUserProfilePhoto, "token")).Reply(http.StatusOK).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 
 	model, res, err := client.GetUserProfilePhotos(2312312).Commit()
 	assert.Nil(t, model)
@@ -121,7 +121,7 @@ func TestGetUserProfilePhotos_Failed(t *testing.T) {
 	}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 
 	model, res, err := client.GetUserProfilePhotos(234234234).Commit()
 	assert.Nil(t, model)
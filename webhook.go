@@ -1,28 +1,197 @@
 package telegraph
 
 import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"io/ioutil"
+	"mime"
+	"path"
+	"strings"
+	"sync"
 
 	"net/http"
 
-	"github.com/cenkalti/backoff"
 	"github.com/parnurzeal/gorequest"
 )
 
 type (
 	// WebHookInfoResponse struct to handle request and response telegram api
 	WebHookInfoResponse struct {
-		Client  *Client
-		Request *gorequest.SuperAgent
+		Client      *Client
+		Request     *gorequest.SuperAgent
+		retryPolicy RetryPolicy
+	}
+
+	// WebHookHandler http.Handler which receive updates sent by telegram web hook and pass them to handler.
+	// Only POST request with JSON body is accepted, when secret is set the last path segment of request url
+	// and X-Telegram-Bot-Api-Secret-Token header must be equal to the secret
+	WebHookHandler struct {
+		secret      string
+		maxBodySize int64
+		handler     Handler
+		synchronous bool
+		wg          sync.WaitGroup
+		ctx         context.Context
+		cancel      context.CancelFunc
 	}
 )
 
+// NewWebHookSecret generate random secret for web hook path and secret token
+func NewWebHookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(secret), nil
+}
+
+/*
+NewWebHookHandler create http.Handler to receive web hook updates, an empty secret disable verification.
+Secret must be 1-256 characters, only A-Z, a-z, 0-9, _ and - are allowed.
+Telegram is answered with 200 as soon as the update is decoded and the update is passed to handler in its own goroutine,
+so slow handler doesn't hit web hook timeout and the update isn't delivered again.
+Handler is called with the hook context, which is canceled when Shutdown gives up waiting.
+When handler is a Submitter such as WorkerPool, the update is submitted instead and telegram is answered with 503
+if it is refused, so telegram delivers it again.
+Use RegisterWebHook to set web hook with url and secret token of the handler.
+
+Available method can used with this method
++ SetMaxBodySize()
++ SetSynchronous()
+*/
+func NewWebHookHandler(secret string, handler Handler) *WebHookHandler {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &WebHookHandler{
+		secret:      secret,
+		maxBodySize: 1 << 20,
+		handler:     handler,
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+}
+
+// SetMaxBodySize Maximum size of request body in bytes, larger request is rejected. Defaults to 1 MB
+func (hook *WebHookHandler) SetMaxBodySize(size int64) *WebHookHandler {
+	hook.maxBodySize = size
+	return hook
+}
+
+// SetSynchronous pass update to handler with the request context before the response is sent,
+// telegram waits for the response so handler must return quickly
+func (hook *WebHookHandler) SetSynchronous(synchronous bool) *WebHookHandler {
+	hook.synchronous = synchronous
+	return hook
+}
+
+// Shutdown wait until updates being handled are done, call it after http.Server.Shutdown.
+// When ctx is done first, the hook context is canceled and ctx.Err() is returned
+func (hook *WebHookHandler) Shutdown(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		hook.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		hook.cancel()
+		return ctx.Err()
+	}
+}
+
+// Path path where the handler should be served, the secret is used as the last path segment
+func (hook *WebHookHandler) Path() string {
+	return "/" + hook.secret
+}
+
+// URL web hook url sent to telegram when handler is served on Path() of baseURL
+func (hook *WebHookHandler) URL(baseURL string) string {
+	return strings.TrimSuffix(baseURL, "/") + hook.Path()
+}
+
+// ServeHTTP validate request from telegram, decode the update, answer 200 and pass the update to handler
+func (hook *WebHookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	if hook.secret != "" && (!secretEqual(path.Base(r.URL.Path), hook.secret) ||
+		!secretEqual(r.Header.Get(WebHookSecretHeader), hook.secret)) {
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+	if mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err != nil || mediaType != "application/json" {
+		http.Error(w, http.StatusText(http.StatusUnsupportedMediaType), http.StatusUnsupportedMediaType)
+		return
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, hook.maxBodySize))
+	if maxErr := (*http.MaxBytesError)(nil); errors.As(err, &maxErr) {
+		http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+		return
+	}
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	update, err := WebHookParseRequest(body)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	if hook.synchronous {
+		hook.handler.HandleUpdate(r.Context(), update)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	if submitter, ok := hook.handler.(Submitter); ok {
+		if err := submitter.Submit(r.Context(), update); err != nil {
+			http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	hook.wg.Add(1)
+	go func() {
+		defer hook.wg.Done()
+		hook.handler.HandleUpdate(hook.ctx, update)
+	}()
+	w.WriteHeader(http.StatusOK)
+}
+
+func secretEqual(given, secret string) bool {
+	return subtle.ConstantTimeCompare([]byte(given), []byte(secret)) == 1
+}
+
+// RegisterWebHook set web hook to the url of hook served on baseURL, with the hook secret as secret token.
+// See SetWebHook for available method can used with this method
+func (client *Client) RegisterWebHook(baseURL string, hook *WebHookHandler) *VoidResponse {
+	webHook := client.SetWebHook(hook.URL(baseURL))
+	if hook.secret != "" {
+		webHook.SetSecretToken(hook.secret)
+	}
+
+	return webHook
+}
+
 // GetWebHookInfo Use this method to get current webhook status. Requires no parameters.
 // On success, returns a WebhookInfo object. If the bot is using getUpdates,
 // will return an object with the url field empty.
 func (client *Client) GetWebHookInfo() *WebHookInfoResponse {
 	url := client.baseURL + fmt.Sprintf(EndpointGetWebHookInfo, client.accessToken)
-	request := gorequest.New().Get(url).Set(UserAgentHeader, UserAgent+"/"+Version)
+	request := gorequest.New().Get(url).Set(UserAgentHeader, client.userAgent)
 
 	return &WebHookInfoResponse{
 		Client:  client,
@@ -30,28 +199,27 @@ func (client *Client) GetWebHookInfo() *WebHookInfoResponse {
 	}
 }
 
+// SetRetryPolicy decide how this request is retried when it fails, override retry policy of the client
+func (info *WebHookInfoResponse) SetRetryPolicy(policy RetryPolicy) *WebHookInfoResponse {
+	info.retryPolicy = policy
+
+	return info
+}
+
 // Commit execute request to telegram
 func (info *WebHookInfoResponse) Commit() (*WebhookInfo, *http.Response, error) {
-	var errs []error
-	res := &http.Response{}
+	return info.CommitContext(context.Background())
+}
+
+// CommitContext execute request to telegram, the request and its back off retry are aborted when ctx is done
+func (info *WebHookInfoResponse) CommitContext(ctx context.Context) (*WebhookInfo, *http.Response, error) {
 	model := struct {
-		ErrorResponse
 		Result *WebhookInfo `json:"result,omitempty"`
 	}{}
 
-	operation := func() error {
-		res, _, errs = info.Request.EndStruct(&model)
-		if len(errs) > 0 {
-			return errs[0]
-		}
-		return nil
-	}
-
-	if err := backoff.Retry(operation, info.Client.expBackOff); err != nil {
-		return nil, MakeHTTPResponse(info.Request), err
-	}
-	if res.StatusCode != http.StatusOK {
-		return nil, res, fmt.Errorf("%v %v", model.ErrorCode, model.Description)
+	_, res, err := info.Client.commit(ctx, info.Request, info.retryPolicy, &model)
+	if err != nil {
+		return nil, res, err
 	}
 
 	return model.Result, res, nil
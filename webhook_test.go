@@ -1,10 +1,14 @@
 package telegraph_test
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"telegraph"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/h2non/gock.v1"
@@ -35,7 +39,7 @@ func TestGetWebHookInfo_Error(t *testing.T) {
 	gock.New(telegraph.BaseURL).Head(fmt.Sprintf(telegraph.EndpointGetWebHookInfo, "token")).Reply(http.StatusInternalServerError).JSON("")
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 
 	info, res, err := client.GetWebHookInfo().Commit()
 
@@ -52,7 +56,7 @@ func TestGetWebHookInfo_Failed(t *testing.T) {
 	}`)
 	defer gock.Off()
 
-	client := telegraph.NewClient("token")
+	client := telegraph.NewClient("token", telegraph.WithRetryPolicy(telegraph.NoRetry))
 
 	info, res, err := client.GetWebHookInfo().Commit()
 
@@ -60,3 +64,150 @@ func TestGetWebHookInfo_Failed(t *testing.T) {
 	assert.Equal(t, http.StatusNotFound, res.StatusCode)
 	assert.Error(t, err)
 }
+
+func newWebHookRequest(target, secret, body string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json; charset=utf-8")
+	if secret != "" {
+		r.Header.Set(telegraph.WebHookSecretHeader, secret)
+	}
+	return r
+}
+
+func TestWebHookHandler_Success(t *testing.T) {
+	var received *telegraph.Update
+	hook := telegraph.NewWebHookHandler("secret_token", telegraph.HandlerFunc(func(ctx context.Context, update *telegraph.Update) {
+		received = update
+	}))
+
+	w := httptest.NewRecorder()
+	hook.ServeHTTP(w, newWebHookRequest("/telegram/secret_token", "secret_token", `{
+		"update_id": 651868729,
+		"message": {
+			"message_id": 19,
+			"date": 1508298329,
+			"chat": {
+				"id": 23423423,
+				"type": "private"
+			},
+			"text": "test text"
+		}
+	}`))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NoError(t, hook.Shutdown(context.Background()))
+	assert.Equal(t, int64(651868729), received.UpdateID)
+	assert.Equal(t, "test text", received.Message.Text)
+	assert.Equal(t, "/secret_token", hook.Path())
+	assert.Equal(t, "https://www.cubesoft.co.id/telegram/secret_token", hook.URL("https://www.cubesoft.co.id/telegram/"))
+}
+
+func TestWebHookHandler_Rejected(t *testing.T) {
+	hook := telegraph.NewWebHookHandler("secret_token", telegraph.HandlerFunc(func(ctx context.Context, update *telegraph.Update) {
+		t.Fail()
+	})).SetMaxBodySize(32)
+
+	w := httptest.NewRecorder()
+	hook.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/secret_token", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+
+	w = httptest.NewRecorder()
+	hook.ServeHTTP(w, newWebHookRequest("/secret_token", "wrong_token", `{"update_id": 1}`))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = httptest.NewRecorder()
+	hook.ServeHTTP(w, newWebHookRequest("/wrong_token", "secret_token", `{"update_id": 1}`))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = httptest.NewRecorder()
+	r := newWebHookRequest("/secret_token", "secret_token", `{"update_id": 1}`)
+	r.Header.Set("Content-Type", "text/plain")
+	hook.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+
+	w = httptest.NewRecorder()
+	hook.ServeHTTP(w, newWebHookRequest("/secret_token", "secret_token", `{"update_id": 1, "message": {"text": "too large body"}}`))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+
+	w = httptest.NewRecorder()
+	hook.ServeHTTP(w, newWebHookRequest("/secret_token", "secret_token", `<-`))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestWebHookHandler_NoSecret(t *testing.T) {
+	handled := false
+	hook := telegraph.NewWebHookHandler("", telegraph.HandlerFunc(func(ctx context.Context, update *telegraph.Update) {
+		handled = true
+	})).SetSynchronous(true)
+
+	w := httptest.NewRecorder()
+	hook.ServeHTTP(w, newWebHookRequest("/webhook", "", `{"update_id": 1}`))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, handled)
+	secret, err := telegraph.NewWebHookSecret()
+	assert.NoError(t, err)
+	assert.Len(t, secret, 64)
+}
+
+func TestWebHookHandler_AnswerBeforeHandled(t *testing.T) {
+	release := make(chan struct{})
+	var handledCtx context.Context
+	hook := telegraph.NewWebHookHandler("", telegraph.HandlerFunc(func(ctx context.Context, update *telegraph.Update) {
+		<-release
+		handledCtx = ctx
+	}))
+
+	w := httptest.NewRecorder()
+	hook.ServeHTTP(w, newWebHookRequest("/webhook", "", `{"update_id": 1}`))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, hook.Shutdown(ctx))
+
+	close(release)
+	assert.NoError(t, hook.Shutdown(context.Background()))
+	assert.Error(t, handledCtx.Err())
+}
+
+func TestWebHookHandler_Submitter(t *testing.T) {
+	handled := make(chan int64, 1)
+	pool := telegraph.NewWorkerPool(telegraph.HandlerFunc(func(ctx context.Context, update *telegraph.Update) {
+		handled <- update.UpdateID
+	}), 1, 1)
+	hook := telegraph.NewWebHookHandler("", pool)
+
+	w := httptest.NewRecorder()
+	hook.ServeHTTP(w, newWebHookRequest("/webhook", "", `{"update_id": 1}`))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	assert.NoError(t, pool.Shutdown(context.Background()))
+	assert.Equal(t, int64(1), <-handled)
+
+	w = httptest.NewRecorder()
+	hook.ServeHTTP(w, newWebHookRequest("/webhook", "", `{"update_id": 2}`))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
+
+func TestRegisterWebHook_Success(t *testing.T) {
+	gock.New(telegraph.BaseURL).Post(fmt.Sprintf(telegraph.EndpointSetWebHook, "token")).
+		MatchType("json").JSON(telegraph.JSON{
+		"url":          "https://www.cubesoft.co.id/secret_token",
+		"secret_token": "secret_token",
+	}).Reply(http.StatusOK).JSON(`{
+		"ok": true,
+		"result": true,
+		"description": "Webhook was set"
+	}`)
+	defer gock.Off()
+
+	client := telegraph.NewClient("token")
+	hook := telegraph.NewWebHookHandler("secret_token", telegraph.HandlerFunc(func(ctx context.Context, update *telegraph.Update) {}))
+
+	body, res, err := client.RegisterWebHook("https://www.cubesoft.co.id", hook).Commit()
+
+	assert.NotNil(t, body)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,175 @@
+package telegraph
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrWorkerPoolClosed returned by WorkerPool.Submit after the pool is shut down
+var ErrWorkerPoolClosed = errors.New("telegraph: worker pool is closed")
+
+// WorkerPool handle updates from different chats concurrently while updates from the same chat are handled
+// one by one in the order they are submitted. Updates are keyed by Chat.ID, or User.ID when update has no chat
+// such as inline query and callback query of inline message.
+// Each key has its own queue served by any free worker in turn, so a slow chat doesn't hold updates of other chats.
+//
+// Handler is called with the pool context instead of the submit context, because submit context such as
+// web hook request is usually done before the update is handled. The pool context is canceled when Shutdown gives up waiting.
+//
+// Updates are queued in memory, an update acknowledged to telegram but not handled yet is lost when the process exits
+type WorkerPool struct {
+	handler Handler
+	slots   chan struct{}
+	ready   chan int64
+	wg      sync.WaitGroup
+	pending sync.WaitGroup
+	ctx     context.Context
+	cancel  context.CancelFunc
+
+	mu       sync.Mutex
+	queues   map[int64][]*Update
+	closed   bool
+	done     chan struct{}
+	doneOnce sync.Once
+}
+
+// NewWorkerPool start workers goroutines passing updates to handler, the pool hold up to queueSize updates per worker
+// waiting to be handled. Submit block when the pool is full
+func NewWorkerPool(handler Handler, workers, queueSize int) *WorkerPool {
+	if workers < 1 {
+		workers = 1
+	}
+	if queueSize < 0 {
+		queueSize = 0
+	}
+
+	// every slot is an update submitted but not handled yet, so a key is never ready more than once per slot
+	capacity := workers * (queueSize + 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := &WorkerPool{
+		handler: handler,
+		slots:   make(chan struct{}, capacity),
+		ready:   make(chan int64, capacity),
+		ctx:     ctx,
+		cancel:  cancel,
+		queues:  map[int64][]*Update{},
+		done:    make(chan struct{}),
+	}
+
+	for i := 0; i < workers; i++ {
+		pool.wg.Add(1)
+		go pool.work()
+	}
+
+	return pool
+}
+
+// HandleUpdate submit update to the pool, the update is dropped if ctx is done or pool is closed before it is queued.
+// Poller and WebHookHandler use Submit instead, so the dropped update is delivered again by telegram
+func (pool *WorkerPool) HandleUpdate(ctx context.Context, update *Update) {
+	_ = pool.Submit(ctx, update)
+}
+
+// Submit queue update after updates of its chat, block while the pool is full until ctx is done or pool is closed
+func (pool *WorkerPool) Submit(ctx context.Context, update *Update) error {
+	select {
+	case <-pool.done:
+		return ErrWorkerPoolClosed
+	default:
+	}
+
+	select {
+	case pool.slots <- struct{}{}:
+	case <-pool.done:
+		return ErrWorkerPoolClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	if pool.closed {
+		<-pool.slots
+		return ErrWorkerPoolClosed
+	}
+
+	pool.pending.Add(1)
+	key := pool.key(update)
+	queue := pool.queues[key]
+	pool.queues[key] = append(queue, update)
+	if len(queue) == 0 {
+		pool.ready <- key
+	}
+	return nil
+}
+
+// Shutdown stop accepting update and wait until queued updates are handled.
+// When ctx is done first, the pool context is canceled and ctx.Err() is returned
+func (pool *WorkerPool) Shutdown(ctx context.Context) error {
+	pool.doneOnce.Do(func() {
+		close(pool.done)
+
+		pool.mu.Lock()
+		pool.closed = true
+		pool.mu.Unlock()
+
+		go func() {
+			pool.pending.Wait()
+			close(pool.ready)
+		}()
+	})
+
+	drained := make(chan struct{})
+	go func() {
+		pool.wg.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		pool.cancel()
+		return nil
+	case <-ctx.Done():
+		pool.cancel()
+		return ctx.Err()
+	}
+}
+
+// work handle the first update of a ready key, the key is ready again afterwards while it has queued updates
+// so keys take turns instead of a busy chat holding the worker
+func (pool *WorkerPool) work() {
+	defer pool.wg.Done()
+
+	for key := range pool.ready {
+		pool.mu.Lock()
+		update := pool.queues[key][0]
+		pool.mu.Unlock()
+
+		pool.handler.HandleUpdate(pool.ctx, update)
+
+		pool.mu.Lock()
+		if queue := pool.queues[key][1:]; len(queue) > 0 {
+			pool.queues[key] = queue
+			pool.ready <- key
+		} else {
+			delete(pool.queues, key)
+		}
+		pool.mu.Unlock()
+
+		<-pool.slots
+		pool.pending.Done()
+	}
+}
+
+// key of the queue for update, updates with the same key are handled in order
+func (pool *WorkerPool) key(update *Update) int64 {
+	if chat := update.Chat(); chat != nil {
+		return chat.ID
+	}
+	if user := update.From(); user != nil {
+		return user.ID
+	}
+	return update.UpdateID
+}
@@ -0,0 +1,127 @@
+package telegraph_test
+
+import (
+	"context"
+	"sync"
+	"telegraph"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func chatUpdate(updateID, chatID int64) *telegraph.Update {
+	return &telegraph.Update{UpdateID: updateID, Message: &telegraph.Message{Chat: telegraph.Chat{ID: chatID}}}
+}
+
+func TestWorkerPool_OrderPerChat(t *testing.T) {
+	var mu sync.Mutex
+	handled := map[int64][]int64{}
+	pool := telegraph.NewWorkerPool(telegraph.HandlerFunc(func(ctx context.Context, update *telegraph.Update) {
+		mu.Lock()
+		defer mu.Unlock()
+		chatID := update.Chat().ID
+		handled[chatID] = append(handled[chatID], update.UpdateID)
+	}), 4, 8)
+
+	expected := map[int64][]int64{}
+	for i := int64(1); i <= 100; i++ {
+		chatID := -(i % 5)
+		expected[chatID] = append(expected[chatID], i)
+		assert.NoError(t, pool.Submit(context.Background(), chatUpdate(i, chatID)))
+	}
+
+	assert.NoError(t, pool.Shutdown(context.Background()))
+	assert.Equal(t, expected, handled)
+}
+
+func TestWorkerPool_ParallelChats(t *testing.T) {
+	started := make(chan int64, 2)
+	release := make(chan struct{})
+	pool := telegraph.NewWorkerPool(telegraph.HandlerFunc(func(ctx context.Context, update *telegraph.Update) {
+		started <- update.Chat().ID
+		<-release
+	}), 2, 1)
+
+	pool.HandleUpdate(context.Background(), chatUpdate(1, 1))
+	pool.HandleUpdate(context.Background(), chatUpdate(2, 2))
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-started:
+		case <-time.After(time.Second):
+			t.Fatal("updates from different chats are not handled concurrently")
+		}
+	}
+
+	close(release)
+	assert.NoError(t, pool.Shutdown(context.Background()))
+}
+
+func TestWorkerPool_SlowChat(t *testing.T) {
+	release := make(chan struct{})
+	handled := make(chan int64, 3)
+	pool := telegraph.NewWorkerPool(telegraph.HandlerFunc(func(ctx context.Context, update *telegraph.Update) {
+		if update.Chat().ID == 2 {
+			<-release
+		}
+		handled <- update.UpdateID
+	}), 2, 4)
+
+	// Chat 2 and 4 used to share a worker, chat 4 must not wait for the slow chat 2
+	pool.HandleUpdate(context.Background(), chatUpdate(1, 2))
+	pool.HandleUpdate(context.Background(), chatUpdate(2, 2))
+	pool.HandleUpdate(context.Background(), chatUpdate(3, 4))
+
+	select {
+	case id := <-handled:
+		assert.Equal(t, int64(3), id)
+	case <-time.After(time.Second):
+		t.Fatal("update of other chat is blocked by slow chat")
+	}
+
+	close(release)
+	assert.NoError(t, pool.Shutdown(context.Background()))
+	assert.Equal(t, int64(1), <-handled)
+	assert.Equal(t, int64(2), <-handled)
+}
+
+func TestWorkerPool_Backpressure(t *testing.T) {
+	release := make(chan struct{})
+	pool := telegraph.NewWorkerPool(telegraph.HandlerFunc(func(ctx context.Context, update *telegraph.Update) {
+		<-release
+	}), 1, 1)
+
+	// First update is being handled, second fills the queue
+	assert.NoError(t, pool.Submit(context.Background(), chatUpdate(1, 1)))
+	time.Sleep(10 * time.Millisecond)
+	assert.NoError(t, pool.Submit(context.Background(), chatUpdate(2, 1)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, pool.Submit(ctx, chatUpdate(3, 1)))
+
+	close(release)
+	assert.NoError(t, pool.Shutdown(context.Background()))
+	assert.Equal(t, telegraph.ErrWorkerPoolClosed, pool.Submit(context.Background(), chatUpdate(4, 1)))
+}
+
+func TestWorkerPool_ShutdownTimeout(t *testing.T) {
+	canceled := make(chan struct{})
+	pool := telegraph.NewWorkerPool(telegraph.HandlerFunc(func(ctx context.Context, update *telegraph.Update) {
+		<-ctx.Done()
+		close(canceled)
+	}), 1, 0)
+
+	pool.HandleUpdate(context.Background(), chatUpdate(1, 1))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, pool.Shutdown(ctx))
+
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Fatal("handler context is not canceled")
+	}
+}